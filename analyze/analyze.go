@@ -0,0 +1,199 @@
+// Package analyze finds the problems of the navigation between the screens.
+package analyze
+
+import (
+	"fmt"
+
+	"github.com/ksoichiro/mocker/gen"
+	"github.com/ksoichiro/mocker/graph"
+)
+
+const (
+	Unreachable    = "unreachable"
+	DeadEnd        = "dead_end"
+	NoExplicitExit = "no_explicit_exit"
+	SelfTransit    = "self_transit"
+	MissingScreen  = "missing_screen"
+	MissingWidget  = "missing_widget"
+	NotClickable   = "not_clickable"
+	NotChangeable  = "not_changeable"
+	NotSelectable  = "not_selectable"
+	NotList        = "not_list"
+	MissingTarget  = "missing_target"
+)
+
+// Severities of the findings.
+// Info is not a problem but a note to review.
+const (
+	SeverityError = "error"
+	SeverityInfo  = "info"
+)
+
+// Types of the views which can trigger the behaviors
+var (
+	clickableTypes  = []string{"button"}
+	changeableTypes = []string{"switch", "checkbox", "radio_group"}
+	selectableTypes = []string{"picker"}
+	listTypes       = []string{"list"}
+)
+
+type Finding struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Screen   string `json:"screen"`
+	Widget   string `json:"widget,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Analyze returns the findings in the order of the screens.
+func Analyze(mock *gen.Mock) (findings []Finding) {
+	g := graph.New(mock)
+	reachable := reachableFrom(g, mock.Launch.Screen)
+	for _, screen := range mock.Screens {
+		if !reachable[screen.Id] {
+			findings = append(findings, Finding{
+				Type:     Unreachable,
+				Severity: SeverityError,
+				Screen:   screen.Id,
+				Message:  fmt.Sprintf("screen %s is not reachable from the launch screen %s", screen.Id, mock.Launch.Screen),
+			})
+		}
+		if !hasNavigation(g, screen.Id) {
+			findings = append(findings, noExitFinding(mock, screen.Id, reachable[screen.Id]))
+		}
+		for _, b := range screen.Behaviors {
+			findings = append(findings, analyzeBehavior(mock, &screen, b)...)
+		}
+	}
+	return
+}
+
+// Launch screen is the root of the navigation stack,
+// and the unreachable screen is never pushed, so they cannot go back.
+// The other screens are pushed to the navigation stack and always have the back navigation,
+// so the screen without the exit is not a dead end.
+func noExitFinding(mock *gen.Mock, id string, reachable bool) Finding {
+	if id == mock.Launch.Screen || !reachable {
+		return Finding{
+			Type:     DeadEnd,
+			Severity: SeverityError,
+			Screen:   id,
+			Message:  fmt.Sprintf("screen %s has no outgoing navigation and no back path", id),
+		}
+	}
+	return Finding{
+		Type:     NoExplicitExit,
+		Severity: SeverityInfo,
+		Screen:   id,
+		Message:  fmt.Sprintf("screen %s has no explicit exit and only goes back to the previous screen", id),
+	}
+}
+
+func analyzeBehavior(mock *gen.Mock, screen *gen.Screen, b gen.Behavior) (findings []Finding) {
+	add := func(t, format string, a ...interface{}) {
+		findings = append(findings, Finding{
+			Type:     t,
+			Severity: SeverityError,
+			Screen:   screen.Id,
+			Widget:   b.Trigger.Widget,
+			Message:  fmt.Sprintf("screen %s: %s on %s ", screen.Id, b.Trigger.Type, b.Trigger.Widget) + fmt.Sprintf(format, a...),
+		})
+	}
+	if b.Action.Transit != "" {
+		if b.Action.Transit == screen.Id {
+			add(SelfTransit, "transits to the same screen")
+		} else if !hasScreen(mock, b.Action.Transit) {
+			add(MissingScreen, "transits to undefined screen %s", b.Action.Transit)
+		}
+	}
+	if (b.Action.Type == gen.ActionShow || b.Action.Type == gen.ActionHide) && findView(screen, b.Action.Widget) == nil {
+		add(MissingTarget, "tries to %s undefined widget %s", b.Action.Type, b.Action.Widget)
+	}
+	if b.Trigger.Type != "click" && b.Trigger.Type != "changed" && b.Trigger.Type != "selected" && b.Trigger.Type != "item_click" {
+		return
+	}
+	view := findView(screen, b.Trigger.Widget)
+	if view == nil {
+		add(MissingWidget, "refers to undefined widget")
+	} else if b.Trigger.Type == "click" && !contains(clickableTypes, view.Type) {
+		add(NotClickable, "is not clickable: %s", view.Type)
+	} else if b.Trigger.Type == "changed" && !contains(changeableTypes, view.Type) {
+		add(NotChangeable, "is not changeable: %s", view.Type)
+	} else if b.Trigger.Type == "selected" && !contains(selectableTypes, view.Type) {
+		add(NotSelectable, "is not selectable: %s", view.Type)
+	} else if b.Trigger.Type == "item_click" && !contains(listTypes, view.Type) {
+		add(NotList, "is not a list: %s", view.Type)
+	}
+	return
+}
+
+// HasProblems reports whether any finding is an error.
+func HasProblems(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the screens reachable from the screen including itself.
+func reachableFrom(g *graph.Graph, id string) map[string]bool {
+	reachable := map[string]bool{id: true}
+	queue := []string{id}
+	for 0 < len(queue) {
+		from := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			if e.From == from && !reachable[e.To] {
+				reachable[e.To] = true
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return reachable
+}
+
+// Self transits are not the navigation to leave the screen.
+func hasNavigation(g *graph.Graph, id string) bool {
+	for _, e := range g.Edges {
+		if e.From == id && e.To != id {
+			return true
+		}
+	}
+	return false
+}
+
+func hasScreen(mock *gen.Mock, id string) bool {
+	for _, screen := range mock.Screens {
+		if screen.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func findView(screen *gen.Screen, id string) *gen.View {
+	var find func(views []gen.View) *gen.View
+	find = func(views []gen.View) *gen.View {
+		for i := range views {
+			if views[i].Id == id {
+				return &views[i]
+			}
+			if v := find(views[i].Sub); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+	return find(screen.Layout)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/ksoichiro/mocker/gen"
+)
+
+func click(widget, transit string) gen.Behavior {
+	return gen.Behavior{
+		Trigger: gen.Trigger{Type: "click", Widget: widget},
+		Action:  gen.Action{Type: "transit_forward", Transit: transit},
+	}
+}
+
+func changed(widget, transit string) gen.Behavior {
+	return gen.Behavior{
+		Trigger: gen.Trigger{Type: "changed", Widget: widget},
+		Action:  gen.Action{Type: "transit_forward", Transit: transit},
+	}
+}
+
+func selected(widget, transit string) gen.Behavior {
+	return gen.Behavior{
+		Trigger: gen.Trigger{Type: "selected", Widget: widget},
+		Action:  gen.Action{Type: "transit_forward", Transit: transit},
+	}
+}
+
+func itemClick(widget, transit string) gen.Behavior {
+	return gen.Behavior{
+		Trigger: gen.Trigger{Type: "item_click", Widget: widget},
+		Action:  gen.Action{Type: "transit_forward", Transit: transit},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	mock := gen.Mock{
+		Screens: []gen.Screen{
+			{Id: "top", Layout: []gen.View{{Type: "linear", Sub: []gen.View{
+				{Id: "next", Type: "button"},
+				{Id: "title", Type: "label"},
+				{Id: "agree", Type: "checkbox"},
+				{Id: "fruit", Type: "picker"},
+				{Id: "users", Type: "list"},
+			}}}, Behaviors: []gen.Behavior{
+				click("next", "second"),
+				click("title", "third"),
+				click("none", "unknown"),
+				changed("agree", "third"),
+				changed("next", "third"),
+				selected("fruit", "third"),
+				selected("agree", "third"),
+				itemClick("users", "third"),
+				itemClick("fruit", "third"),
+				{Trigger: gen.Trigger{Type: "click", Widget: "next"}, Action: gen.Action{Type: gen.ActionShow, Widget: "title"}},
+				{Trigger: gen.Trigger{Type: "click", Widget: "next"}, Action: gen.Action{Type: gen.ActionHide, Widget: "none"}},
+			}},
+			{Id: "second", Layout: []gen.View{{Type: "linear", Sub: []gen.View{
+				{Id: "again", Type: "button"},
+			}}}, Behaviors: []gen.Behavior{
+				click("again", "second"),
+			}},
+			{Id: "third", Layout: []gen.View{{Type: "linear", Sub: []gen.View{
+				{Id: "back", Type: "button"},
+			}}}, Behaviors: []gen.Behavior{
+				click("back", "top"),
+			}},
+			{Id: "orphan"},
+		},
+		Launch: gen.Launch{Screen: "top"},
+	}
+	expect := []Finding{
+		{Type: NotClickable, Screen: "top", Widget: "title"},
+		{Type: MissingScreen, Screen: "top", Widget: "none"},
+		{Type: MissingWidget, Screen: "top", Widget: "none"},
+		{Type: NotChangeable, Screen: "top", Widget: "next"},
+		{Type: NotSelectable, Screen: "top", Widget: "agree"},
+		{Type: NotList, Screen: "top", Widget: "fruit"},
+		{Type: MissingTarget, Screen: "top", Widget: "next"},
+		{Type: NoExplicitExit, Severity: SeverityInfo, Screen: "second"},
+		{Type: SelfTransit, Screen: "second", Widget: "again"},
+		{Type: Unreachable, Screen: "orphan"},
+		{Type: DeadEnd, Screen: "orphan"},
+	}
+	findings := Analyze(&mock)
+	if len(findings) != len(expect) {
+		t.Fatalf("Expected %d findings but %d: %v", len(expect), len(findings), findings)
+	}
+	for i, f := range findings {
+		severity := expect[i].Severity
+		if severity == "" {
+			severity = SeverityError
+		}
+		if f.Type != expect[i].Type || f.Severity != severity || f.Screen != expect[i].Screen || f.Widget != expect[i].Widget {
+			t.Errorf("Expected %v but %v", expect[i], f)
+		}
+		if f.Message == "" {
+			t.Errorf("Expected message: %v", f)
+		}
+	}
+}
+
+func TestAnalyzeSingleScreen(t *testing.T) {
+	// The launch screen has no back path
+	mock := gen.Mock{
+		Screens: []gen.Screen{{Id: "top"}},
+		Launch:  gen.Launch{Screen: "top"},
+	}
+	findings := Analyze(&mock)
+	if len(findings) != 1 || findings[0].Type != DeadEnd || findings[0].Severity != SeverityError {
+		t.Fatalf("Expected the dead end but %v", findings)
+	}
+	if !HasProblems(findings) {
+		t.Errorf("Expected problems: %v", findings)
+	}
+}
+
+func TestAnalyzeLeafScreen(t *testing.T) {
+	// The leaf screen goes back with the navigation
+	mock := gen.Mock{
+		Screens: []gen.Screen{
+			{Id: "top", Layout: []gen.View{{Type: "linear", Sub: []gen.View{
+				{Id: "next", Type: "button"},
+			}}}, Behaviors: []gen.Behavior{
+				click("next", "second"),
+			}},
+			{Id: "second"},
+		},
+		Launch: gen.Launch{Screen: "top"},
+	}
+	findings := Analyze(&mock)
+	if len(findings) != 1 || findings[0].Type != NoExplicitExit || findings[0].Severity != SeverityInfo {
+		t.Fatalf("Expected the info of no explicit exit but %v", findings)
+	}
+	if HasProblems(findings) {
+		t.Errorf("Expected no problems: %v", findings)
+	}
+}
@@ -0,0 +1,189 @@
+// Package export renders the screens of the Mockerfile into images
+// without building the apps.
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ksoichiro/mocker/gen"
+	"github.com/ksoichiro/mocker/layout"
+)
+
+// Height of the title bar which shows the name of the screen
+const titleHeight = 56
+
+type Options struct {
+	OutDir string
+	Width  int
+	Height int
+	Lang   string
+}
+
+type Exporter interface {
+	Export()
+}
+
+func NewExporter(opt *Options, mock *gen.Mock, formatId string) Exporter {
+	var e Exporter
+	switch formatId {
+	case "svg":
+		e = &SvgExporter{opt, mock}
+	case "png":
+		e = &PngExporter{opt, mock}
+	}
+	return e
+}
+
+// Validate finds the problems to export the screens.
+// The language must be defined in the strings.
+func Validate(mock *gen.Mock, opt *Options) (errs []error) {
+	if opt.Lang != "base" && !hasLang(mock, opt.Lang) {
+		errs = append(errs, fmt.Errorf("unknown language: %s", opt.Lang))
+	}
+	return
+}
+
+func hasLang(mock *gen.Mock, lang string) bool {
+	for _, s := range mock.Strings {
+		if s.Lang == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDevice parses the device size in the format of "360x640".
+func ParseDevice(s string) (width, height int, err error) {
+	parts := strings.Split(s, "x")
+	if len(parts) == 2 {
+		width, err = strconv.Atoi(parts[0])
+		if err == nil {
+			height, err = strconv.Atoi(parts[1])
+		}
+		if err == nil && titleHeight < height && 0 < width {
+			return
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid device size: %s", s)
+}
+
+// The view to be drawn with its resolved position.
+type item struct {
+	view  *gen.View
+	frame layout.Frame
+}
+
+// Lays out the screen under the title bar and flattens the views
+// in the drawing order: containers come before their sub views.
+// Contents of the scroll views are drawn at the initial position
+// as long as they are visible.
+func layoutItems(mock *gen.Mock, screen *gen.Screen, lang string, width, height int) (items []item) {
+	f, ok := gen.ResolveScreen(mock, screen, lang, width, height-titleHeight)
+	if !ok {
+		return
+	}
+	var walk func(view *gen.View, f layout.Frame, clip *layout.Rect)
+	walk = func(view *gen.View, f layout.Frame, clip *layout.Rect) {
+		if view.Hidden {
+			// Hidden views are not drawn but keep their spaces
+			return
+		}
+		f.Y += titleHeight
+		if clip != nil && !containsRect(*clip, f.Rect) {
+			if len(f.Sub) == 0 {
+				return
+			}
+			// Containers are cut off to show the visible part
+			f.Rect = intersectRect(*clip, f.Rect)
+		}
+		items = append(items, item{view, f})
+		subClip := clip
+		if view.Type == layout.Scroll {
+			subClip = &f.Rect
+		}
+		for i := range f.Sub {
+			walk(&view.Sub[i], f.Sub[i], subClip)
+		}
+		if view.Type == "list" {
+			// Rows are drawn with the items as long as they fit in the list
+			y := f.Y
+			for _, it := range view.Items {
+				row := gen.RowWithItem(gen.ListRow(view), it)
+				rf := gen.ResolveRow(mock, &row, lang, f.W)
+				if f.Y+f.H < y+rf.H {
+					break
+				}
+				walk(&row, offsetFrame(rf, f.X, y-titleHeight), clip)
+				y += rf.H
+			}
+		}
+	}
+	walk(&screen.Layout[0], f, nil)
+	return
+}
+
+func containsRect(outer, r layout.Rect) bool {
+	return outer.X <= r.X && outer.Y <= r.Y && r.X+r.W <= outer.X+outer.W && r.Y+r.H <= outer.Y+outer.H
+}
+
+func intersectRect(a, b layout.Rect) (r layout.Rect) {
+	r.X, r.Y = max(a.X, b.X), max(a.Y, b.Y)
+	r.W = max(min(a.X+a.W, b.X+b.W)-r.X, 0)
+	r.H = max(min(a.Y+a.H, b.Y+b.H)-r.Y, 0)
+	return
+}
+
+func offsetFrame(f layout.Frame, x, y int) layout.Frame {
+	f.X += x
+	f.Y += y
+	sub := make([]layout.Frame, len(f.Sub))
+	for i := range f.Sub {
+		sub[i] = offsetFrame(f.Sub[i], x, y)
+	}
+	f.Sub = sub
+	return f
+}
+
+// Returns the text shown in the view and whether it's a hint.
+func viewText(mock *gen.Mock, view *gen.View, lang string) (text string, hint bool) {
+	switch view.Type {
+	case "label", "button", "switch", "checkbox":
+		if view.Label != "" {
+			return localize(mock, lang, view.Label), false
+		}
+	case "input":
+		if view.Hint != "" {
+			return localize(mock, lang, view.Hint), true
+		}
+	case "picker":
+		// First item is selected initially
+		if items := localizeArray(mock, lang, view.Array); 0 < len(items) {
+			return items[0], false
+		}
+	}
+	return "", false
+}
+
+// Strings not defined in the language are taken from the base language
+// as the apps do.
+func localize(mock *gen.Mock, lang, id string) string {
+	if s := gen.LocalizedString(mock, lang, id); s != id {
+		return s
+	}
+	return gen.LocalizedString(mock, "base", id)
+}
+
+func localizeArray(mock *gen.Mock, lang, id string) []string {
+	if items := gen.LocalizedArray(mock, lang, id); items != nil {
+		return items
+	}
+	return gen.LocalizedArray(mock, "base", id)
+}
+
+// Width of the filled part of the progress bar.
+// Values out of the range are clamped.
+func progressWidth(width int, value float64) int {
+	return int(float64(width) * min(max(value, 0), 1))
+}
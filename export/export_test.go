@@ -0,0 +1,114 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ksoichiro/mocker/gen"
+)
+
+func TestParseDevice(t *testing.T) {
+	var testcases = []struct {
+		s       string
+		expectW int
+		expectH int
+		valid   bool
+	}{
+		{"360x640", 360, 640, true},
+		{"768x1024", 768, 1024, true},
+		{"360", 0, 0, false},
+		{"360x", 0, 0, false},
+		{"axb", 0, 0, false},
+		{"360x40", 0, 0, false},
+	}
+	for _, tc := range testcases {
+		w, h, err := ParseDevice(tc.s)
+		if (err == nil) != tc.valid || w != tc.expectW || h != tc.expectH {
+			t.Errorf("%s: expected %dx%d (valid: %v) but %dx%d (%v)", tc.s, tc.expectW, tc.expectH, tc.valid, w, h, err)
+		}
+	}
+}
+
+func TestWriteSvg(t *testing.T) {
+	mock := gen.Mock{
+		Screens: []gen.Screen{
+			{Id: "top", Name: "Top & Bottom", Layout: []gen.View{
+				{Type: "linear", Sub: []gen.View{
+					{Id: "title", Type: "label", Label: "title"},
+					{Id: "name", Type: "input", Hint: "hint_name"},
+				}},
+			}},
+		},
+		Strings: []gen.String{
+			{Lang: "base", Defs: []gen.Def{{Id: "title", Value: "<Welcome>"}}},
+		},
+	}
+	var b strings.Builder
+	writeSvg(&b, &mock, &mock.Screens[0], "base", 360, 640)
+	svg := b.String()
+	for _, expect := range []string{
+		`width="360" height="640"`,
+		`>Top &amp; Bottom</text>`,
+		`>&lt;Welcome&gt;</text>`,
+		`fill="#999999" text-anchor="start" dominant-baseline="central">hint_name</text>`,
+		`>title</text>`,
+		`>name</text>`,
+		`>linear</text>`,
+	} {
+		if !strings.Contains(svg, expect) {
+			t.Errorf("Expected %q in the SVG", expect)
+		}
+	}
+}
+
+func TestRenderScreen(t *testing.T) {
+	mock := gen.Mock{
+		Screens: []gen.Screen{
+			{Id: "top", Name: "Top", Layout: []gen.View{
+				{Type: "linear", Sub: []gen.View{
+					{Id: "next", Type: "button", Label: "next"},
+				}},
+			}},
+		},
+		Strings: []gen.String{
+			{Lang: "base", Defs: []gen.Def{{Id: "next", Value: "Next"}}},
+			{Lang: "ja", Defs: []gen.Def{{Id: "next", Value: "次へ"}}},
+		},
+	}
+	a := renderScreen(&mock, &mock.Screens[0], "base", 360, 640)
+	if a.Bounds().Dx() != 360 || a.Bounds().Dy() != 640 {
+		t.Errorf("Expected 360x640 but %v", a.Bounds())
+	}
+	if n := countDiffPixels(a, renderScreen(&mock, &mock.Screens[0], "base", 360, 640)); n != 0 {
+		t.Errorf("Expected the same image but %d pixels differ", n)
+	}
+	if n := countDiffPixels(a, renderScreen(&mock, &mock.Screens[0], "ja", 360, 640)); n == 0 {
+		t.Errorf("Expected the texts of the language to be rendered")
+	}
+	// The button is drawn under the title bar
+	if c := a.RGBAAt(180, titleHeight); c != colorBorder {
+		t.Errorf("Expected the border of the button but %v", c)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	mock := gen.Mock{
+		Strings: []gen.String{
+			{Lang: "base", Defs: []gen.Def{{Id: "next", Value: "Next"}}},
+			{Lang: "ja", Defs: []gen.Def{{Id: "next", Value: "次へ"}}},
+		},
+	}
+	var testcases = []struct {
+		lang   string
+		errors int
+	}{
+		{"base", 0},
+		{"ja", 0},
+		{"fr", 1},
+	}
+	for _, tc := range testcases {
+		if errs := Validate(&mock, &Options{Lang: tc.lang}); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: lang=%s: %v", tc.errors, len(errs), tc.lang, errs)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ksoichiro/mocker/font"
+	"github.com/ksoichiro/mocker/gen"
+	"github.com/ksoichiro/mocker/layout"
+)
+
+type PngExporter struct {
+	opt  *Options
+	mock *gen.Mock
+}
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorTitleBar   = color.RGBA{0xf7, 0xf7, 0xf7, 0xff}
+	colorBorder     = color.RGBA{0x66, 0x66, 0x66, 0xff}
+	colorContainer  = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	colorButton     = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+	colorText       = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	colorHint       = color.RGBA{0x99, 0x99, 0x99, 0xff}
+)
+
+func (e *PngExporter) Export() {
+	var wg sync.WaitGroup
+	for i := range e.mock.Screens {
+		wg.Add(1)
+		go func(mock *gen.Mock, screen *gen.Screen) {
+			defer wg.Done()
+			exportPng(e.opt, mock, screen)
+		}(e.mock, &e.mock.Screens[i])
+	}
+	wg.Wait()
+}
+
+func pngFilename(dir, screenId string) string {
+	return filepath.Join(dir, screenId+".png")
+}
+
+func exportPng(opt *Options, mock *gen.Mock, screen *gen.Screen) {
+	img := renderScreen(mock, screen, opt.Lang, opt.Width, opt.Height)
+	os.MkdirAll(opt.OutDir, 0777)
+	f, err := os.Create(pngFilename(opt.OutDir, screen.Id))
+	if err != nil {
+		fmt.Println("Error creating file", err)
+		return
+	}
+	defer f.Close()
+	png.Encode(f, img)
+}
+
+// Rasterizes the screen.
+// The result only depends on the Mockerfile and the options
+// so that the images can be compared with the previous ones.
+func renderScreen(mock *gen.Mock, screen *gen.Screen, lang string, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fill(img, img.Bounds(), colorBackground)
+
+	title := image.Rect(0, 0, width, titleHeight)
+	fill(img, title, colorTitleBar)
+	fill(img, image.Rect(0, titleHeight-1, width, titleHeight), colorContainer)
+	font.DrawText(img, title, screen.Name, colorText, true)
+
+	for _, it := range layoutItems(mock, screen, lang, width, height) {
+		renderItem(img, mock, it, lang)
+	}
+	return img
+}
+
+func renderItem(img *image.RGBA, mock *gen.Mock, it item, lang string) {
+	r := rect(it.frame.Rect)
+	view := it.view
+	switch view.Type {
+	case layout.Linear, layout.Relative, layout.Scroll, layout.FrameLayout, layout.Grid:
+		// Containers are invisible in the apps
+	case "button":
+		fill(img, r, colorButton)
+		stroke(img, r, colorBorder)
+	case "input":
+		stroke(img, r, colorBorder)
+	case "image":
+		fill(img, r, colorTitleBar)
+		stroke(img, r, colorBorder)
+	case "progress":
+		fill(img, r, colorButton)
+		fill(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+progressWidth(r.Dx(), view.Value), r.Max.Y), colorBorder)
+	case "spinner_indicator":
+		stroke(img, r.Inset(2), colorBorder)
+	}
+
+	if text, hint := viewText(mock, view, lang); text != "" {
+		c := colorText
+		if hint {
+			c = colorHint
+		}
+		centered := view.Gravity != gen.GravityCenterV && view.Type != "input"
+		font.DrawText(img, r.Inset(4), text, c, centered)
+	}
+}
+
+func rect(r layout.Rect) image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func stroke(img *image.RGBA, r image.Rectangle, c color.Color) {
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1), c)
+	fill(img, image.Rect(r.Min.X, r.Max.Y-1, r.Max.X, r.Max.Y), c)
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+1, r.Max.Y), c)
+	fill(img, image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
+
+// ComparePng compares the images in the directory with the baseline images
+// and returns the differences for each screen.
+func ComparePng(dir, baselineDir string, mock *gen.Mock) (diffs []string) {
+	for _, screen := range mock.Screens {
+		a, err := readPng(pngFilename(dir, screen.Id))
+		if err != nil {
+			diffs = append(diffs, fmt.Sprintf("%s: %v", screen.Id, err))
+			continue
+		}
+		b, err := readPng(pngFilename(baselineDir, screen.Id))
+		if err != nil {
+			diffs = append(diffs, fmt.Sprintf("%s: no baseline: %v", screen.Id, err))
+			continue
+		}
+		if n := countDiffPixels(a, b); 0 < n {
+			diffs = append(diffs, fmt.Sprintf("%s: %d pixels differ from the baseline", screen.Id, n))
+		}
+	}
+	return
+}
+
+func readPng(filename string) (image.Image, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// Images of the different sizes differ in all pixels.
+func countDiffPixels(a, b image.Image) (n int) {
+	if a.Bounds() != b.Bounds() {
+		return max(a.Bounds().Dx()*a.Bounds().Dy(), b.Bounds().Dx()*b.Bounds().Dy())
+	}
+	r := a.Bounds()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if color.RGBAModel.Convert(a.At(x, y)) != color.RGBAModel.Convert(b.At(x, y)) {
+				n++
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ksoichiro/mocker/gen"
+	"github.com/ksoichiro/mocker/layout"
+)
+
+type SvgExporter struct {
+	opt  *Options
+	mock *gen.Mock
+}
+
+func (e *SvgExporter) Export() {
+	var wg sync.WaitGroup
+	for i := range e.mock.Screens {
+		wg.Add(1)
+		go func(mock *gen.Mock, screen *gen.Screen) {
+			defer wg.Done()
+			exportSvg(e.opt, mock, screen)
+		}(e.mock, &e.mock.Screens[i])
+	}
+	wg.Wait()
+}
+
+func exportSvg(opt *Options, mock *gen.Mock, screen *gen.Screen) {
+	var b strings.Builder
+	writeSvg(&b, mock, screen, opt.Lang, opt.Width, opt.Height)
+	os.MkdirAll(opt.OutDir, 0777)
+	ioutil.WriteFile(filepath.Join(opt.OutDir, screen.Id+".svg"), []byte(b.String()), 0666)
+}
+
+// Draws the wireframe of the screen.
+// The IDs of the views are annotated at the top left corners.
+func writeSvg(b *strings.Builder, mock *gen.Mock, screen *gen.Screen, lang string, width, height int) {
+	fmt.Fprintf(b, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">
+    <rect x="0" y="0" width="%d" height="%d" fill="#ffffff" stroke="#333333"/>
+    <rect x="0" y="0" width="%d" height="%d" fill="#f7f7f7" stroke="#cccccc"/>
+    <text x="%d" y="%d" font-size="18" font-weight="bold" text-anchor="middle" dominant-baseline="central">%s</text>
+`,
+		width, height, width, height,
+		width, height,
+		width, titleHeight,
+		width/2, titleHeight/2, html.EscapeString(screen.Name))
+
+	for _, it := range layoutItems(mock, screen, lang, width, height) {
+		writeSvgItem(b, mock, it, lang)
+	}
+	fmt.Fprintf(b, "</svg>\n")
+}
+
+func writeSvgItem(b *strings.Builder, mock *gen.Mock, it item, lang string) {
+	r := it.frame.Rect
+	view := it.view
+	switch view.Type {
+	case layout.Linear, layout.Relative, layout.Scroll, layout.FrameLayout, layout.Grid:
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#999999" stroke-dasharray="4 2"/>
+`, r.X, r.Y, r.W, r.H)
+	case "button":
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="#e0e0e0" stroke="#666666"/>
+`, r.X, r.Y, r.W, r.H)
+	case "input":
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" fill="#ffffff" stroke="#666666"/>
+`, r.X, r.Y, r.W, r.H)
+	case "image":
+		// Crossed box as the wireframes usually draw images
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" fill="#f0f0f0" stroke="#666666"/>
+    <path d="M %d %d L %d %d M %d %d L %d %d" stroke="#999999"/>
+`, r.X, r.Y, r.W, r.H, r.X, r.Y, r.X+r.W, r.Y+r.H, r.X+r.W, r.Y, r.X, r.Y+r.H)
+	case "progress":
+		// Track and the filled part of the value
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" fill="#e0e0e0"/>
+    <rect x="%d" y="%d" width="%d" height="%d" fill="#666666"/>
+`, r.X, r.Y, r.W, r.H, r.X, r.Y, progressWidth(r.W, view.Value), r.H)
+	case "spinner_indicator":
+		fmt.Fprintf(b, `    <circle cx="%d" cy="%d" r="%d" fill="none" stroke="#666666" stroke-width="2" stroke-dasharray="6 3"/>
+`, r.X+r.W/2, r.Y+r.H/2, max(min(r.W, r.H)/2-2, 0))
+	default:
+		fmt.Fprintf(b, `    <rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#cccccc"/>
+`, r.X, r.Y, r.W, r.H)
+	}
+
+	if text, hint := viewText(mock, view, lang); text != "" {
+		x, anchor := r.X+r.W/2, "middle"
+		if view.Gravity == gen.GravityCenterV || view.Type == "input" {
+			x, anchor = r.X+8, "start"
+		}
+		fill := "#000000"
+		if hint {
+			fill = "#999999"
+		}
+		fmt.Fprintf(b, `    <text x="%d" y="%d" font-size="16" fill="%s" text-anchor="%s" dominant-baseline="central">%s</text>
+`, x, r.Y+r.H/2, fill, anchor, html.EscapeString(text))
+	}
+
+	// Annotate the view with the ID, or the type if it's anonymous
+	annotation := view.Id
+	if annotation == "" {
+		annotation = view.Type
+	}
+	fmt.Fprintf(b, `    <text x="%d" y="%d" font-size="9" fill="#3366cc">%s</text>
+`, r.X+2, r.Y+10, html.EscapeString(annotation))
+}
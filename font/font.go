@@ -0,0 +1,89 @@
+// Package font draws texts with the bundled bitmap font
+// so that the images can be rendered without the fonts of the system.
+package font
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+)
+
+// DrawText draws the text vertically centered in the rect.
+// Texts overflowing the rect are clipped.
+func DrawText(img draw.Image, r image.Rectangle, text string, c color.Color, centered bool) {
+	w := 0
+	for _, ch := range text {
+		w += GlyphWidth(ch)
+	}
+	x := r.Min.X
+	if centered {
+		x += (r.Dx() - w) / 2
+	}
+	y := r.Min.Y + (r.Dy()-Height)/2
+	src := image.NewUniform(c)
+	for _, ch := range text {
+		mask := glyphMask(ch)
+		dr := image.Rect(x, y, x+mask.Rect.Dx(), y+Height).Intersect(r)
+		if !dr.Empty() {
+			draw.DrawMask(img, dr, src, image.Point{}, mask, image.Pt(dr.Min.X-x, dr.Min.Y-y), draw.Over)
+		}
+		x += GlyphWidth(ch)
+	}
+}
+
+// GlyphWidth returns the width of the character.
+// Wide characters take twice the width as the layout estimates.
+func GlyphWidth(ch rune) int {
+	if 0x1100 <= ch {
+		return Width * 2
+	}
+	return Width
+}
+
+// Has reports whether the font has the glyph of the character.
+func Has(ch rune) bool {
+	if i := int(ch) - first; 0 <= i && i < len(glyphs) {
+		return true
+	}
+	_, ok := unicodeGlyph(ch)
+	return ok
+}
+
+var (
+	glyphMasks   []*image.Alpha
+	missingMasks = map[int]*image.Alpha{}
+	glyphOnce    sync.Once
+)
+
+// Returns the mask of the glyph.
+// Characters which the font does not have are drawn as boxes.
+func glyphMask(ch rune) *image.Alpha {
+	glyphOnce.Do(func() {
+		for _, rows := range glyphs {
+			mask := image.NewAlpha(image.Rect(0, 0, Width, Height))
+			for y, row := range rows {
+				for x := 0; x < Width; x++ {
+					if row&(0x80>>uint(x)) != 0 {
+						mask.SetAlpha(x, y, color.Alpha{0xff})
+					}
+				}
+			}
+			glyphMasks = append(glyphMasks, mask)
+		}
+		for _, w := range []int{Width, Width * 2} {
+			mask := image.NewAlpha(image.Rect(0, 0, w, Height))
+			box := image.Rect(1, 3, w-1, Height-3)
+			draw.Draw(mask, box, image.Opaque, image.Point{}, draw.Src)
+			draw.Draw(mask, box.Inset(1), image.Transparent, image.Point{}, draw.Src)
+			missingMasks[w] = mask
+		}
+	})
+	if i := int(ch) - first; 0 <= i && i < len(glyphMasks) {
+		return glyphMasks[i]
+	}
+	if mask, ok := unicodeMask(ch); ok {
+		return mask
+	}
+	return missingMasks[GlyphWidth(ch)]
+}
@@ -0,0 +1,39 @@
+package font
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestHas(t *testing.T) {
+	for _, text := range []string{"Next", "次へ", "下一步", "다음", "Café", "Далее", "Επόμενο"} {
+		for _, ch := range text {
+			if !Has(ch) {
+				t.Errorf("Expected the glyph of %q in %q", ch, text)
+			}
+		}
+	}
+	if Has('\U0001F600') {
+		t.Errorf("Expected no glyph of the emoji")
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	draw := func(text string) *image.Alpha {
+		img := image.NewAlpha(image.Rect(0, 0, 64, Height))
+		DrawText(img, img.Rect, text, color.Alpha{0xff}, false)
+		return img
+	}
+	missing := draw("\U0001F600\U0001F600")
+	for _, text := range []string{"次へ", "다음"} {
+		img := draw(text)
+		if string(img.Pix) == string(missing.Pix) {
+			t.Errorf("Expected the glyphs of %q to be drawn instead of the boxes", text)
+		}
+	}
+	// Glyphs of the different characters differ
+	if string(draw("次").Pix) == string(draw("へ").Pix) {
+		t.Errorf("Expected the different glyphs")
+	}
+}
@@ -0,0 +1,227 @@
+//go:build ignore
+
+// Generates glyphs.gz from the BDF fonts in bitmapfont:
+//
+//	go run gen_glyphs.go -src $(go env GOMODCACHE)/github.com/hajimehoshi/bitmapfont/v3@v3.2.0/internal
+//
+// Glyphs are placed in the cells of the bundled font,
+// and the records of the characters are written in the order of the code points.
+// Each record is the code point in 3 bytes followed by the rows of the cell,
+// which have GlyphWidth(ch)/8 bytes with the leftmost pixel in the top bit.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ksoichiro/mocker/font"
+)
+
+// Row of the cell where the baseline of the glyphs is placed,
+// which matches the ASCII glyphs
+const baseline = 12
+
+type glyph struct {
+	dw, w, h, xoff, yoff int
+	rows                 [][]byte
+}
+
+func (g *glyph) at(x, y int) bool {
+	return g.rows[y][x/8]&(0x80>>uint(x%8)) != 0
+}
+
+func main() {
+	src := flag.String("src", "", "internal directory of bitmapfont")
+	out := flag.String("out", "glyphs.gz", "output file")
+	flag.Parse()
+
+	cp932 := readTable(filepath.Join(*src, "mplus", "CP932.TXT"))
+	ksx1001 := readTable(filepath.Join(*src, "baekmuk", "KSX1001.TXT"))
+	mplus := readBDF(filepath.Join(*src, "mplus", "mplus_j12r.bdf"), func(code int) (rune, bool) {
+		r, ok := cp932[jisToShiftJIS(code)]
+		return r, ok
+	})
+	baekmuk := readBDF(filepath.Join(*src, "baekmuk", "gulim12.bdf"), func(code int) (rune, bool) {
+		r, ok := ksx1001[code]
+		return r, ok
+	})
+	ark := readBDF(filepath.Join(*src, "ark", "ark-pixel-12px-monospaced-zh_cn.bdf"), unicode)
+	fixed := readBDF(filepath.Join(*src, "fixed", "6x13.bdf"), unicode)
+
+	// Japanese glyphs come first for the kanji shared with Chinese,
+	// and the narrow characters are drawn with the narrow font
+	wide := []map[rune]*glyph{mplus, ark, baekmuk, fixed}
+	narrow := []map[rune]*glyph{fixed, ark}
+
+	found := map[rune]bool{}
+	var chars []rune
+	for _, fonts := range [][]map[rune]*glyph{wide, narrow} {
+		for _, f := range fonts {
+			for r := range f {
+				// ASCII is in glyphs.go, and the control characters are not drawn
+				if r < 0xa0 || (0xd800 <= r && r < 0xe000) || 0x10000 <= r {
+					continue
+				}
+				if !found[r] {
+					found[r] = true
+					chars = append(chars, r)
+				}
+			}
+		}
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	w, _ := gzip.NewWriterLevel(file, gzip.BestCompression)
+	defer w.Close()
+	for _, r := range chars {
+		fonts := narrow
+		if font.GlyphWidth(r) != font.Width {
+			fonts = wide
+		}
+		for _, f := range fonts {
+			if g, ok := f[r]; ok {
+				w.Write([]byte{byte(r >> 16), byte(r >> 8), byte(r)})
+				w.Write(cell(g, font.GlyphWidth(r)))
+				break
+			}
+		}
+	}
+}
+
+// Places the glyph in the cell centering its advance.
+func cell(g *glyph, width int) []byte {
+	stride := width / 8
+	b := make([]byte, stride*font.Height)
+	left := (width-g.dw)/2 + g.xoff
+	top := baseline - (g.h + g.yoff)
+	for y := 0; y < g.h; y++ {
+		for x := 0; x < g.w; x++ {
+			cx, cy := left+x, top+y
+			if cx < 0 || width <= cx || cy < 0 || font.Height <= cy || !g.at(x, y) {
+				continue
+			}
+			b[cy*stride+cx/8] |= 0x80 >> uint(cx%8)
+		}
+	}
+	return b
+}
+
+func unicode(code int) (rune, bool) {
+	return rune(code), true
+}
+
+// Reads the glyphs of the BDF font converting the encodings into the code points.
+func readBDF(filename string, conv func(code int) (rune, bool)) map[rune]*glyph {
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	glyphs := map[rune]*glyph{}
+	var g *glyph
+	code, bitmap := -1, false
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case fields[0] == "STARTCHAR":
+			g, code, bitmap = &glyph{}, -1, false
+		case fields[0] == "ENCODING":
+			code = atoi(fields[1])
+		case fields[0] == "DWIDTH":
+			g.dw = atoi(fields[1])
+		case fields[0] == "BBX":
+			g.w, g.h, g.xoff, g.yoff = atoi(fields[1]), atoi(fields[2]), atoi(fields[3]), atoi(fields[4])
+		case fields[0] == "BITMAP":
+			bitmap = true
+		case fields[0] == "ENDCHAR":
+			if r, ok := conv(code); ok && 0 <= code && len(g.rows) == g.h {
+				glyphs[r] = g
+			}
+			bitmap = false
+		case bitmap:
+			row := make([]byte, len(fields[0])/2)
+			for i := range row {
+				v, _ := strconv.ParseUint(fields[0][i*2:i*2+2], 16, 8)
+				row[i] = byte(v)
+			}
+			for len(row) < (g.w+7)/8 {
+				row = append(row, 0)
+			}
+			g.rows = append(g.rows, row)
+		}
+	}
+	if err := s.Err(); err != nil {
+		log.Fatal(err)
+	}
+	return glyphs
+}
+
+// Reads the mapping table of the encoding into the code points.
+func readTable(filename string) map[int]rune {
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	table := map[int]rune{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		from, err1 := strconv.ParseInt(fields[0], 0, 32)
+		to, err2 := strconv.ParseInt(fields[1], 0, 32)
+		if err1 == nil && err2 == nil {
+			table[int(from)] = rune(to)
+		}
+	}
+	return table
+}
+
+// Converts the code of JIS X 0208 into Shift_JIS to look up CP932.TXT.
+func jisToShiftJIS(code int) int {
+	hi, lo := code>>8-0x21, code&0xff
+	if hi&1 == 0 {
+		lo += 0x1f
+		if 0x7f <= lo {
+			lo++
+		}
+	} else {
+		lo += 0x7e
+	}
+	hi >>= 1
+	if hi <= 0x1e {
+		hi += 0x81
+	} else {
+		hi += 0xc1
+	}
+	return hi<<8 | lo
+}
+
+func atoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		log.Fatal(fmt.Errorf("invalid number: %s", s))
+	}
+	return n
+}
@@ -0,0 +1,108 @@
+package font
+
+// Bitmap font of the printable ASCII characters.
+// Glyphs are rasterized from DejaVu Sans Mono at 13 pixels.
+// Each byte is a row of 8 pixels with the leftmost pixel in the top bit.
+const (
+	Width  = 8
+	Height = 16
+	first  = 0x20
+)
+
+var glyphs = [...][Height]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x00, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x00, 0x10, 0x18, 0x00, 0x00, 0x00, 0x00}, // '!'
+	{0x00, 0x00, 0x20, 0x24, 0x24, 0x24, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '"'
+	{0x00, 0x00, 0x00, 0x12, 0x16, 0x7f, 0x34, 0x24, 0xfe, 0x6c, 0x48, 0x48, 0x00, 0x00, 0x00, 0x00}, // '#'
+	{0x00, 0x00, 0x00, 0x18, 0x3e, 0x60, 0x60, 0x3c, 0x0e, 0x02, 0x02, 0x7c, 0x00, 0x00, 0x00, 0x00}, // '$'
+	{0x00, 0x00, 0x00, 0x70, 0x90, 0x90, 0x76, 0x18, 0x4e, 0x09, 0x1b, 0x0e, 0x00, 0x00, 0x00, 0x00}, // '%'
+	{0x00, 0x00, 0x18, 0x38, 0x60, 0x20, 0x30, 0x59, 0xcb, 0xce, 0xc6, 0x7e, 0x00, 0x00, 0x00, 0x00}, // '&'
+	{0x00, 0x00, 0x00, 0x18, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '\''
+	{0x00, 0x00, 0x08, 0x08, 0x18, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x18, 0x08, 0x08, 0x00, 0x00}, // '('
+	{0x00, 0x00, 0x30, 0x10, 0x18, 0x08, 0x08, 0x08, 0x08, 0x08, 0x18, 0x10, 0x10, 0x20, 0x00, 0x00}, // ')'
+	{0x00, 0x00, 0x00, 0x10, 0x3c, 0x18, 0x76, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '*'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x18, 0x7e, 0x7e, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00, 0x00}, // '+'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x18, 0x10, 0x10, 0x00, 0x00}, // ','
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '-'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // '.'
+	{0x00, 0x00, 0x00, 0x04, 0x04, 0x0c, 0x08, 0x18, 0x10, 0x30, 0x20, 0x60, 0x40, 0x00, 0x00, 0x00}, // '/'
+	{0x00, 0x00, 0x18, 0x3c, 0x66, 0x42, 0x52, 0x5a, 0x42, 0x46, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // '0'
+	{0x00, 0x00, 0x18, 0x78, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x3e, 0x00, 0x00, 0x00, 0x00}, // '1'
+	{0x00, 0x00, 0x38, 0x7c, 0x06, 0x06, 0x04, 0x0c, 0x18, 0x30, 0x60, 0x7e, 0x00, 0x00, 0x00, 0x00}, // '2'
+	{0x00, 0x00, 0x38, 0x7c, 0x06, 0x06, 0x1c, 0x1c, 0x06, 0x02, 0x06, 0x7c, 0x00, 0x00, 0x00, 0x00}, // '3'
+	{0x00, 0x00, 0x04, 0x0c, 0x1c, 0x34, 0x24, 0x44, 0x4c, 0x7e, 0x04, 0x04, 0x00, 0x00, 0x00, 0x00}, // '4'
+	{0x00, 0x00, 0x3c, 0x7c, 0x60, 0x60, 0x7c, 0x06, 0x06, 0x06, 0x06, 0x7c, 0x00, 0x00, 0x00, 0x00}, // '5'
+	{0x00, 0x00, 0x1c, 0x3c, 0x60, 0x40, 0x7c, 0x66, 0x42, 0x42, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // '6'
+	{0x00, 0x00, 0x7e, 0x7e, 0x04, 0x04, 0x0c, 0x08, 0x18, 0x18, 0x10, 0x30, 0x00, 0x00, 0x00, 0x00}, // '7'
+	{0x00, 0x00, 0x38, 0x6e, 0x46, 0x66, 0x3c, 0x3c, 0x46, 0x42, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // '8'
+	{0x00, 0x00, 0x38, 0x6c, 0x46, 0x46, 0x46, 0x66, 0x3a, 0x06, 0x04, 0x7c, 0x00, 0x00, 0x00, 0x00}, // '9'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x18, 0x00, 0x00, 0x00, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // ':'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x18, 0x00, 0x00, 0x00, 0x18, 0x18, 0x10, 0x10, 0x00, 0x00}, // ';'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x06, 0x1c, 0x60, 0x70, 0x1c, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00}, // '<'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xfe, 0x00, 0x00, 0x7e, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '='
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0xe0, 0x38, 0x06, 0x0e, 0x78, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00}, // '>'
+	{0x00, 0x00, 0x18, 0x7c, 0x06, 0x04, 0x0c, 0x18, 0x10, 0x10, 0x10, 0x10, 0x00, 0x00, 0x00, 0x00}, // '?'
+	{0x00, 0x00, 0x00, 0x3c, 0x62, 0x43, 0x9f, 0x93, 0x91, 0x93, 0x9f, 0x40, 0x60, 0x3e, 0x00, 0x00}, // '@'
+	{0x00, 0x00, 0x10, 0x18, 0x38, 0x2c, 0x24, 0x24, 0x7e, 0x7e, 0x42, 0xc3, 0x00, 0x00, 0x00, 0x00}, // 'A'
+	{0x00, 0x00, 0x78, 0x7e, 0x46, 0x46, 0x7c, 0x7e, 0x42, 0x42, 0x46, 0x7c, 0x00, 0x00, 0x00, 0x00}, // 'B'
+	{0x00, 0x00, 0x1c, 0x36, 0x60, 0x40, 0x40, 0x40, 0x40, 0x60, 0x20, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'C'
+	{0x00, 0x00, 0x70, 0x7c, 0x46, 0x46, 0x42, 0x42, 0x42, 0x46, 0x44, 0x78, 0x00, 0x00, 0x00, 0x00}, // 'D'
+	{0x00, 0x00, 0x3e, 0x7e, 0x60, 0x60, 0x7c, 0x7c, 0x60, 0x60, 0x60, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'E'
+	{0x00, 0x00, 0x3e, 0x7e, 0x60, 0x60, 0x7c, 0x7c, 0x60, 0x60, 0x60, 0x60, 0x00, 0x00, 0x00, 0x00}, // 'F'
+	{0x00, 0x00, 0x1c, 0x36, 0x60, 0x40, 0x40, 0x4e, 0x46, 0x42, 0x62, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'G'
+	{0x00, 0x00, 0x42, 0x42, 0x42, 0x42, 0x7e, 0x7e, 0x42, 0x42, 0x42, 0x42, 0x00, 0x00, 0x00, 0x00}, // 'H'
+	{0x00, 0x00, 0x3c, 0x7c, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'I'
+	{0x00, 0x00, 0x1c, 0x1c, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0c, 0x78, 0x00, 0x00, 0x00, 0x00}, // 'J'
+	{0x00, 0x00, 0x42, 0x46, 0x4c, 0x58, 0x70, 0x78, 0x4c, 0x4c, 0x46, 0x43, 0x00, 0x00, 0x00, 0x00}, // 'K'
+	{0x00, 0x00, 0x00, 0x60, 0x60, 0x60, 0x60, 0x60, 0x60, 0x60, 0x60, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'L'
+	{0x00, 0x00, 0x42, 0xe6, 0xe6, 0xee, 0xda, 0xda, 0xd2, 0xc2, 0xc2, 0xc2, 0x00, 0x00, 0x00, 0x00}, // 'M'
+	{0x00, 0x00, 0x42, 0x62, 0x62, 0x72, 0x52, 0x5a, 0x4a, 0x4e, 0x46, 0x46, 0x00, 0x00, 0x00, 0x00}, // 'N'
+	{0x00, 0x00, 0x38, 0x7c, 0x66, 0x42, 0x42, 0x42, 0x42, 0x46, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // 'O'
+	{0x00, 0x00, 0x38, 0x7e, 0x62, 0x62, 0x66, 0x7e, 0x60, 0x60, 0x60, 0x60, 0x00, 0x00, 0x00, 0x00}, // 'P'
+	{0x00, 0x00, 0x38, 0x7c, 0x66, 0x42, 0x42, 0x42, 0x42, 0x46, 0x66, 0x3c, 0x0c, 0x04, 0x00, 0x00}, // 'Q'
+	{0x00, 0x00, 0x78, 0x7c, 0x46, 0x46, 0x46, 0x7c, 0x44, 0x46, 0x42, 0x43, 0x00, 0x00, 0x00, 0x00}, // 'R'
+	{0x00, 0x00, 0x3c, 0x7c, 0x40, 0x40, 0x70, 0x3c, 0x06, 0x02, 0x46, 0x7c, 0x00, 0x00, 0x00, 0x00}, // 'S'
+	{0x00, 0x00, 0x7e, 0xfe, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // 'T'
+	{0x00, 0x00, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // 'U'
+	{0x00, 0x00, 0x02, 0x42, 0x42, 0x66, 0x64, 0x24, 0x2c, 0x3c, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // 'V'
+	{0x00, 0x00, 0x80, 0x83, 0xc3, 0xda, 0xda, 0x5a, 0x7e, 0x66, 0x66, 0x66, 0x00, 0x00, 0x00, 0x00}, // 'W'
+	{0x00, 0x00, 0x42, 0x66, 0x24, 0x3c, 0x18, 0x18, 0x3c, 0x24, 0x66, 0xc3, 0x00, 0x00, 0x00, 0x00}, // 'X'
+	{0x00, 0x00, 0x02, 0x42, 0x66, 0x2c, 0x38, 0x18, 0x18, 0x18, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // 'Y'
+	{0x00, 0x00, 0x7e, 0x7e, 0x06, 0x0c, 0x08, 0x18, 0x10, 0x20, 0x60, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'Z'
+	{0x00, 0x00, 0x1c, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1c, 0x00, 0x00}, // '['
+	{0x00, 0x00, 0x40, 0x40, 0x60, 0x20, 0x30, 0x10, 0x18, 0x08, 0x0c, 0x04, 0x06, 0x00, 0x00, 0x00}, // '\\'
+	{0x00, 0x00, 0x38, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x18, 0x38, 0x00, 0x00}, // ']'
+	{0x00, 0x00, 0x10, 0x38, 0x24, 0x42, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '^'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0x00}, // '_'
+	{0x00, 0x00, 0x30, 0x18, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '`'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x7c, 0x06, 0x1e, 0x76, 0x46, 0x46, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'a'
+	{0x00, 0x00, 0x40, 0x40, 0x40, 0x7c, 0x66, 0x62, 0x42, 0x62, 0x66, 0x7c, 0x00, 0x00, 0x00, 0x00}, // 'b'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x60, 0x60, 0x60, 0x60, 0x20, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'c'
+	{0x00, 0x00, 0x06, 0x06, 0x06, 0x3e, 0x46, 0x46, 0x46, 0x46, 0x66, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'd'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3c, 0x66, 0x42, 0x7e, 0x40, 0x60, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'e'
+	{0x00, 0x00, 0x0e, 0x18, 0x10, 0x7e, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x00, 0x00, 0x00, 0x00}, // 'f'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x46, 0x46, 0x46, 0x46, 0x66, 0x3e, 0x06, 0x04, 0x38, 0x00}, // 'g'
+	{0x00, 0x00, 0x40, 0x40, 0x40, 0x7c, 0x66, 0x46, 0x46, 0x46, 0x46, 0x46, 0x00, 0x00, 0x00, 0x00}, // 'h'
+	{0x00, 0x00, 0x18, 0x00, 0x00, 0x38, 0x18, 0x18, 0x18, 0x18, 0x18, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'i'
+	{0x00, 0x00, 0x08, 0x08, 0x00, 0x38, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x08, 0x18, 0x70, 0x00}, // 'j'
+	{0x00, 0x00, 0x60, 0x60, 0x60, 0x66, 0x6c, 0x78, 0x78, 0x6c, 0x66, 0x62, 0x00, 0x00, 0x00, 0x00}, // 'k'
+	{0x00, 0x00, 0x70, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x0e, 0x00, 0x00, 0x00, 0x00}, // 'l'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x7e, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x00, 0x00, 0x00, 0x00}, // 'm'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x7c, 0x66, 0x46, 0x46, 0x46, 0x46, 0x46, 0x00, 0x00, 0x00, 0x00}, // 'n'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3c, 0x66, 0x42, 0x42, 0x42, 0x66, 0x3c, 0x00, 0x00, 0x00, 0x00}, // 'o'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x7c, 0x66, 0x62, 0x42, 0x62, 0x66, 0x7c, 0x40, 0x40, 0x40, 0x00}, // 'p'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x66, 0x46, 0x46, 0x46, 0x66, 0x3e, 0x06, 0x06, 0x02, 0x00}, // 'q'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x00, 0x00, 0x00, 0x00}, // 'r'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3c, 0x60, 0x60, 0x3c, 0x06, 0x06, 0x7c, 0x00, 0x00, 0x00, 0x00}, // 's'
+	{0x00, 0x00, 0x00, 0x10, 0x10, 0x7c, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1e, 0x00, 0x00, 0x00, 0x00}, // 't'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x46, 0x46, 0x46, 0x46, 0x46, 0x66, 0x3e, 0x00, 0x00, 0x00, 0x00}, // 'u'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x42, 0x46, 0x64, 0x24, 0x3c, 0x18, 0x18, 0x00, 0x00, 0x00, 0x00}, // 'v'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x83, 0xc3, 0x5a, 0x5a, 0x7e, 0x66, 0x64, 0x00, 0x00, 0x00, 0x00}, // 'w'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x66, 0x24, 0x18, 0x18, 0x3c, 0x24, 0x42, 0x00, 0x00, 0x00, 0x00}, // 'x'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x42, 0x66, 0x24, 0x24, 0x3c, 0x18, 0x18, 0x10, 0x30, 0x60, 0x00}, // 'y'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x3e, 0x04, 0x08, 0x18, 0x30, 0x20, 0x7e, 0x00, 0x00, 0x00, 0x00}, // 'z'
+	{0x00, 0x00, 0x0c, 0x18, 0x18, 0x18, 0x18, 0x30, 0x30, 0x18, 0x18, 0x18, 0x18, 0x0c, 0x00, 0x00}, // '{'
+	{0x00, 0x00, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x18, 0x00}, // '|'
+	{0x00, 0x00, 0x70, 0x18, 0x18, 0x18, 0x18, 0x18, 0x0c, 0x18, 0x18, 0x18, 0x10, 0x70, 0x00, 0x00}, // '}'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x72, 0x0e, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // '~'
+}
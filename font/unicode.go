@@ -0,0 +1,66 @@
+package font
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"image"
+	"image/color"
+	"io"
+	"sync"
+)
+
+// Glyphs of the characters other than ASCII generated by gen_glyphs.go.
+// Japanese is drawn with M+ BITMAP FONTS, Chinese with Ark Pixel,
+// Korean with Baekmuk Gulim, and the other scripts with misc-fixed 6x13.
+// See LICENSE for the notices of the fonts.
+//
+//go:embed glyphs.gz
+var unicodeData []byte
+
+var (
+	unicodeGlyphs map[rune][]byte
+	unicodeOnce   sync.Once
+)
+
+// Returns the rows of the glyph of the character other than ASCII,
+// each of which has GlyphWidth(ch)/8 bytes.
+func unicodeGlyph(ch rune) ([]byte, bool) {
+	unicodeOnce.Do(func() {
+		unicodeGlyphs = map[rune][]byte{}
+		r, err := gzip.NewReader(bytes.NewReader(unicodeData))
+		if err != nil {
+			panic(err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			panic(err)
+		}
+		for len(data) >= 3 {
+			ch := rune(data[0])<<16 | rune(data[1])<<8 | rune(data[2])
+			n := GlyphWidth(ch) / 8 * Height
+			unicodeGlyphs[ch] = data[3 : 3+n]
+			data = data[3+n:]
+		}
+	})
+	rows, ok := unicodeGlyphs[ch]
+	return rows, ok
+}
+
+// Returns the mask of the glyph of the character other than ASCII.
+func unicodeMask(ch rune) (*image.Alpha, bool) {
+	rows, ok := unicodeGlyph(ch)
+	if !ok {
+		return nil, false
+	}
+	w := GlyphWidth(ch)
+	mask := image.NewAlpha(image.Rect(0, 0, w, Height))
+	for i, b := range rows {
+		for bit := 0; bit < 8; bit++ {
+			if b&(0x80>>uint(bit)) != 0 {
+				mask.SetAlpha(i%(w/8)*8+bit, i/(w/8), color.Alpha{0xff})
+			}
+		}
+	}
+	return mask, true
+}
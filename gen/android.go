@@ -2,9 +2,12 @@ package gen
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -39,6 +42,61 @@ func defineAndroidWidgets() {
 		SizeW:    SizeFill,
 		SizeH:    SizeWrap,
 	})
+	awd.Add("image", Widget{
+		Name:     "ImageView",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("switch", Widget{
+		Name:     "Switch",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("checkbox", Widget{
+		Name:     "CheckBox",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("radio_group", Widget{
+		Name:        "RadioGroup",
+		Textable:    false,
+		Orientation: OrientationVertical,
+		SizeW:       SizeFill,
+		SizeH:       SizeWrap,
+	})
+	awd.Add("picker", Widget{
+		Name:     "Spinner",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("progress", Widget{
+		Name:     "ProgressBar",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("spinner_indicator", Widget{
+		Name:     "ProgressBar",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	awd.Add("web", Widget{
+		Name:     "WebView",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	awd.Add("list", Widget{
+		Name:     "android.support.v7.widget.RecyclerView",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
 	awd.Add("linear", Widget{
 		Name:        "LinearLayout",
 		Textable:    false,
@@ -52,6 +110,24 @@ func defineAndroidWidgets() {
 		SizeW:    SizeFill,
 		SizeH:    SizeFill,
 	})
+	awd.Add("scroll", Widget{
+		Name:     "ScrollView",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	awd.Add("frame", Widget{
+		Name:     "FrameLayout",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	awd.Add("grid", Widget{
+		Name:     "GridLayout",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
 }
 
 func (g *AndroidGenerator) Generate() {
@@ -62,6 +138,7 @@ func (g *AndroidGenerator) Generate() {
 	mainDir := filepath.Join(srcDir, "main")
 	javaDir := filepath.Join(mainDir, "java")
 	packageDir := filepath.Join(javaDir, strings.Replace(g.mock.Meta.Android.Package, ".", string(os.PathSeparator), -1))
+	testPackageDir := filepath.Join(srcDir, "androidTest", "java", strings.Replace(g.mock.Meta.Android.Package, ".", string(os.PathSeparator), -1))
 	resDir := filepath.Join(mainDir, "res")
 	layoutDir := filepath.Join(resDir, "layout")
 	valuesDir := filepath.Join(resDir, "values")
@@ -115,9 +192,19 @@ func (g *AndroidGenerator) Generate() {
 			defer wg.Done()
 			genAndroidActivity(mock, dir1, screen)
 			genAndroidActivityLayout(mock, dir2, screen)
+			genAndroidListAdapters(mock, dir1, dir2, screen)
 		}(g.mock, packageDir, layoutDir, screen)
 	}
 
+	// Generate UI tests
+	for _, screen := range g.mock.Screens {
+		wg.Add(1)
+		go func(mock *Mock, dir string, screen Screen) {
+			defer wg.Done()
+			genAndroidActivityTest(mock, dir, screen)
+		}(g.mock, testPackageDir, screen)
+	}
+
 	// Generate resources
 	wg.Add(1)
 	go func(mock *Mock, dir1, dir2 string) {
@@ -140,6 +227,16 @@ func (g *AndroidGenerator) Generate() {
 		defer wg.Done()
 		genAndroidDefaultDimensions(mock, dir)
 	}(g.mock, valuesDir)
+	wg.Add(1)
+	go func(mock *Mock, inDir, dir string) {
+		defer wg.Done()
+		genAndroidDrawables(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, resDir)
+	wg.Add(1)
+	go func(mock *Mock, inDir, dir string) {
+		defer wg.Done()
+		genAndroidWebAssets(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, mainDir)
 
 	wg.Wait()
 }
@@ -154,31 +251,44 @@ func genCodeAndroidManifest(mock *Mock, buf *CodeBuffer) {
 	buf.add(`<?xml version="1.0" encoding="utf-8"?>
 <manifest xmlns:android="http://schemas.android.com/apk/res/android"
     package="%s" >
+`, mock.Meta.Android.Package)
+
+	if hasRemoteWebViews(mock) {
+		// Web views load the pages from the network
+		buf.add(`    <uses-permission android:name="android.permission.INTERNET" />
+`)
+	}
 
-    <application
+	buf.add(`    <application
         android:allowBackup="true"
         android:icon="@drawable/ic_launcher"
         android:label="@string/app_name"
-        android:theme="@style/AppTheme" >`, mock.Meta.Android.Package)
+        android:theme="@style/AppTheme" >`)
 
 	launcherId := mock.Launch.Screen
 	for _, screen := range mock.Screens {
 		activityId := strings.Title(screen.Id)
+		// Resize the window for the keyboard to keep the inputs in the scroll views visible
+		softInputMode := ""
+		if hasScrollViews(&screen) {
+			softInputMode = `
+            android:windowSoftInputMode="adjustResize"`
+		}
 		if screen.Id == launcherId {
 			// Launcher
 			buf.add(`        <activity
             android:label="@string/activity_title_%s"
-            android:name=".%sActivity" >
+            android:name=".%sActivity"%s >
             <intent-filter>
                 <action android:name="android.intent.action.MAIN" />
 
                 <category android:name="android.intent.category.LAUNCHER" />
             </intent-filter>
-        </activity>`, screen.Id, activityId)
+        </activity>`, screen.Id, activityId, softInputMode)
 		} else {
 			buf.add(`        <activity
             android:label="@string/activity_title_%s"
-            android:name=".%sActivity" />`, screen.Id, activityId)
+            android:name=".%sActivity"%s />`, screen.Id, activityId, softInputMode)
 		}
 	}
 
@@ -203,6 +313,10 @@ func genCodeAndroidGradle(mock *Mock, buf *CodeBuffer) {
 }`, mock.Meta.Android.GradlePluginVersion)
 	buf.add(`apply plugin: 'com.android.application'
 
+repositories {
+    mavenCentral()
+}
+
 android {
     compileSdkVersion '%s'
     buildToolsVersion '%s'
@@ -213,6 +327,7 @@ android {
         targetSdkVersion %d
         versionCode %d
         versionName "%s"
+        testInstrumentationRunner "android.support.test.runner.AndroidJUnitRunner"
     }
 
     buildTypes {
@@ -226,7 +341,12 @@ android {
         checkReleaseBuilds false
         abortOnError false
     }
-}`,
+
+    packagingOptions {
+        exclude 'LICENSE.txt'
+    }
+}
+`,
 		mock.Meta.Android.CompileSdkVersion,
 		mock.Meta.Android.BuildToolsVersion,
 		mock.Meta.Android.Package,
@@ -234,6 +354,16 @@ android {
 		mock.Meta.Android.TargetSdkVersion,
 		mock.Meta.Android.VersionCode,
 		mock.Meta.Android.VersionName)
+	buf.add(`dependencies {`)
+	// Lists are shown with RecyclerView in the support library
+	if hasListViews(mock) {
+		buf.add(`    compile 'com.android.support:recyclerview-v7:%s'`, androidSupportLibraryVersion(mock))
+	}
+	buf.add(`    androidTestCompile 'com.android.support.test:runner:0.3'
+    androidTestCompile 'com.android.support.test:rules:0.3'
+    androidTestCompile 'com.android.support.test.espresso:espresso-core:2.2'
+    androidTestCompile 'com.android.support.test.espresso:espresso-intents:2.2'
+}`)
 }
 
 func genAndroidGitignore(mock *Mock, outDir string) {
@@ -263,13 +393,34 @@ func genAndroidActivity(mock *Mock, packageDir string, screen Screen) {
 
 func genCodeAndroidActivity(mock *Mock, screen Screen, buf *CodeBuffer) {
 	activityId := strings.Title(screen.Id)
+	imports := []string{
+		"android.app.Activity",
+		"android.content.Intent",
+		"android.os.Bundle",
+		"android.view.View",
+	}
+	if 0 < len(collectListViews(&screen)) {
+		imports = append(imports, "android.support.v7.widget.LinearLayoutManager", "android.support.v7.widget.RecyclerView")
+	}
+	if 0 < len(collectWebViews(&screen)) {
+		imports = append(imports, "android.webkit.WebView")
+	}
+	for _, b := range screen.Behaviors {
+		if listener := androidListener(&screen, b); listener != nil {
+			for _, i := range listener.Imports {
+				if !contains(imports, i) {
+					imports = append(imports, i)
+				}
+			}
+		}
+	}
+	sort.Strings(imports)
 	buf.add(`package %s;
-
-import android.app.Activity;
-import android.content.Intent;
-import android.os.Bundle;
-import android.view.View;
-
+`, mock.Meta.Android.Package)
+	for _, i := range imports {
+		buf.add(`import %s;`, i)
+	}
+	buf.add(`
 public class %sActivity extends Activity {
 
     @Override
@@ -280,16 +431,37 @@ public class %sActivity extends Activity {
     }
 
     private void init() {`,
-		mock.Meta.Android.Package, activityId, screen.Id)
+		activityId, screen.Id)
+
+	// Lists show the rows with the adapters
+	for _, view := range collectListViews(&screen) {
+		buf.add(`        RecyclerView %s = (RecyclerView) findViewById(R.id.%s);
+        %s.setLayoutManager(new LinearLayoutManager(this));
+        %s.setAdapter(new %s());`, javaIdentifier(view.Id), view.Id, javaIdentifier(view.Id), javaIdentifier(view.Id), androidListAdapterName(&screen, view))
+	}
+
+	// Web views load the pages, or the HTML files in the assets
+	for _, view := range collectWebViews(&screen) {
+		if url := androidWebViewUrl(view); view.Id != "" && url != "" {
+			buf.add(`        WebView %s = (WebView) findViewById(R.id.%s);
+        %s.loadUrl(%s);`, javaIdentifier(view.Id), view.Id, javaIdentifier(view.Id), quoteString(url))
+		}
+	}
 
 	for _, b := range screen.Behaviors {
-		if b.Trigger.Type != "click" {
-			// Not support other than click currently
+		listener := androidListener(&screen, b)
+		if listener == nil {
 			continue
 		}
-		buf.add(`        findViewById(R.id.%s).setOnClickListener(new View.OnClickListener() {
-            @Override
-            public void onClick(View v) {`, b.Trigger.Widget)
+		buf.add(`        %s {`, fmt.Sprintf(listener.Setter, b.Trigger.Widget))
+		if listener.Fields != "" {
+			buf.add("%s", listener.Fields)
+		}
+		buf.add(`            @Override
+            public void %s {`, listener.Method)
+		if listener.Guard != "" {
+			buf.add("%s", listener.Guard)
+		}
 
 		if b.Action.Type == "transit_forward" {
 			var id string
@@ -302,9 +474,22 @@ public class %sActivity extends Activity {
 				strings.Title(screen.Id),
 				strings.Title(id))
 		}
+		if b.Action.Type == ActionShow || b.Action.Type == ActionHide {
+			// Hidden views keep their spaces as on iOS
+			visibility := "VISIBLE"
+			if b.Action.Type == ActionHide {
+				visibility = "INVISIBLE"
+			}
+			if findView(&screen, b.Action.Widget) != nil {
+				buf.add(`                findViewById(R.id.%s).setVisibility(View.%s);`, b.Action.Widget, visibility)
+			}
+		}
 
-		buf.add(`            }
-        });`)
+		buf.add(`            }`)
+		if listener.Extra != "" {
+			buf.add("%s", listener.Extra)
+		}
+		buf.add(`        });`)
 	}
 
 	buf.add(`    }
@@ -312,6 +497,124 @@ public class %sActivity extends Activity {
 }`)
 }
 
+// Listener to handle the trigger of the behavior
+type androidListenerDef struct {
+	// Statement to set the listener, which has the format verb for the widget ID
+	Setter  string
+	Method  string
+	Imports []string
+	// Optional members of the listener class before the method
+	Fields string
+	// Optional statements at the beginning of the method
+	Guard string
+	// Optional methods after the method
+	Extra string
+}
+
+// Finds the listener for the trigger, or nil if the widget cannot handle it.
+func androidListener(screen *Screen, b Behavior) *androidListenerDef {
+	if b.Trigger.Type == "click" {
+		return &androidListenerDef{
+			Setter: `findViewById(R.id.%s).setOnClickListener(new View.OnClickListener()`,
+			Method: `onClick(View v)`,
+		}
+	}
+	view := findView(screen, b.Trigger.Widget)
+	if view == nil {
+		return nil
+	}
+	switch {
+	case b.Trigger.Type == "changed" && (view.Type == "switch" || view.Type == "checkbox"):
+		return &androidListenerDef{
+			Setter:  `((CompoundButton) findViewById(R.id.%s)).setOnCheckedChangeListener(new CompoundButton.OnCheckedChangeListener()`,
+			Method:  `onCheckedChanged(CompoundButton buttonView, boolean isChecked)`,
+			Imports: []string{"android.widget.CompoundButton"},
+		}
+	case b.Trigger.Type == "changed" && view.Type == "radio_group":
+		return &androidListenerDef{
+			Setter:  `((RadioGroup) findViewById(R.id.%s)).setOnCheckedChangeListener(new RadioGroup.OnCheckedChangeListener()`,
+			Method:  `onCheckedChanged(RadioGroup group, int checkedId)`,
+			Imports: []string{"android.widget.RadioGroup"},
+		}
+	case b.Trigger.Type == "item_click" && view.Type == "list":
+		adapter := androidListAdapterName(screen, view)
+		return &androidListenerDef{
+			Setter:  `((` + adapter + `) ((RecyclerView) findViewById(R.id.%s)).getAdapter()).setOnItemClickListener(new ` + adapter + `.OnItemClickListener()`,
+			Method:  `onItemClick(int position)`,
+			Imports: []string{"android.support.v7.widget.RecyclerView"},
+		}
+	case b.Trigger.Type == "selected" && view.Type == "picker":
+		// Spinner notifies the initial selection, which is not the user's one
+		return &androidListenerDef{
+			Setter:  `((Spinner) findViewById(R.id.%s)).setOnItemSelectedListener(new AdapterView.OnItemSelectedListener()`,
+			Method:  `onItemSelected(AdapterView<?> parent, View view, int position, long id)`,
+			Imports: []string{"android.widget.AdapterView", "android.widget.Spinner"},
+			Fields: `            private boolean initialized;
+`,
+			Guard: `                if (!initialized) {
+                    initialized = true;
+                    return;
+                }`,
+			Extra: `
+            @Override
+            public void onNothingSelected(AdapterView<?> parent) {
+            }`,
+		}
+	}
+	return nil
+}
+
+func genAndroidActivityTest(mock *Mock, testPackageDir string, screen Screen) {
+	if len(findTransitBehaviors(mock, screen)) == 0 {
+		// Test class without any tests fails, so skip it
+		return
+	}
+	var buf CodeBuffer
+	genCodeAndroidActivityTest(mock, screen, &buf)
+	genFile(&buf, filepath.Join(testPackageDir, strings.Title(screen.Id)+"ActivityTest.java"))
+}
+
+func genCodeAndroidActivityTest(mock *Mock, screen Screen, buf *CodeBuffer) {
+	activityId := strings.Title(screen.Id)
+	buf.add(`package %s;
+
+import android.support.test.espresso.intent.rule.IntentsTestRule;
+import android.support.test.runner.AndroidJUnit4;
+
+import org.junit.Rule;
+import org.junit.Test;
+import org.junit.runner.RunWith;
+
+import static android.support.test.espresso.Espresso.onView;
+import static android.support.test.espresso.action.ViewActions.click;
+import static android.support.test.espresso.intent.Intents.intended;
+import static android.support.test.espresso.intent.matcher.IntentMatchers.hasComponent;
+import static android.support.test.espresso.matcher.ViewMatchers.withId;
+
+@RunWith(AndroidJUnit4.class)
+public class %sActivityTest {
+
+    @Rule
+    public IntentsTestRule<%sActivity> activityRule = new IntentsTestRule<%sActivity>(%sActivity.class);`,
+		mock.Meta.Android.Package, activityId, activityId, activityId, activityId)
+
+	for _, b := range findTransitBehaviors(mock, screen) {
+		buf.add(`
+    @Test
+    public void click%sStarts%sActivity() {
+        onView(withId(R.id.%s)).perform(click());
+        intended(hasComponent(%sActivity.class.getName()));
+    }`,
+			strings.Title(b.Trigger.Widget),
+			strings.Title(b.Action.Transit),
+			b.Trigger.Widget,
+			strings.Title(b.Action.Transit))
+	}
+
+	buf.add(`
+}`)
+}
+
 func genAndroidActivityLayout(mock *Mock, layoutDir string, screen Screen) {
 	var buf CodeBuffer
 	genCodeAndroidActivityLayout(mock, screen, &buf)
@@ -322,11 +625,11 @@ func genCodeAndroidActivityLayout(mock *Mock, screen Screen, buf *CodeBuffer) {
 	buf.add(`<?xml version="1.0" encoding="utf-8"?>`)
 	if 0 < len(screen.Layout) {
 		// Only parse root view
-		genAndroidLayoutRecur(&screen.Layout[0], true, buf, 0)
+		genAndroidLayoutRecur(mock, &screen.Layout[0], nil, buf, 0)
 	}
 }
 
-func genAndroidLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int) {
+func genAndroidLayoutRecur(mock *Mock, view *View, parent *View, buf *CodeBuffer, indent int) {
 	if !awd.Has(view.Type) {
 		return
 	}
@@ -334,12 +637,44 @@ func genAndroidLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int) {
 
 	t := tab(indent)
 	xmlns := ""
-	if top {
+	if parent == nil {
 		xmlns = ` xmlns:android="http://schemas.android.com/apk/res/android"`
 	}
 
 	lo := convertAndroidLayoutOptions(widget, view)
+	// Views which have the weights are sized with the rest of the parent
+	weight := linearWeight(parent, view, widget)
+	if 0 < weight {
+		if viewOrientation(parent) == OrientationHorizontal {
+			lo.Width = "0dp"
+		} else {
+			lo.Height = "0dp"
+		}
+	}
+	// Weights of the cells can't be used with the old SDK,
+	// then the views are sized with their contents instead
+	gridWeight := androidGridWeightSupported(mock)
+	if parent != nil && parent.Type == "grid" {
+		// Views in the grid are sized with the cells
+		if lo.Width == "match_parent" {
+			if gridWeight {
+				lo.Width = "0dp"
+			} else {
+				lo.Width = "wrap_content"
+			}
+		}
+		if lo.Height == "match_parent" {
+			if 0 < parent.Rows && gridWeight {
+				lo.Height = "0dp"
+			} else {
+				lo.Height = "wrap_content"
+			}
+		}
+	}
 	hasSub := 0 < len(view.Sub)
+	if view.Type == "scroll" && viewOrientation(view) == OrientationHorizontal {
+		widget.Name = "HorizontalScrollView"
+	}
 
 	buf.add(t+`<%s%s`, widget.Name, xmlns)
 	if view.Id != "" {
@@ -354,9 +689,46 @@ func genAndroidLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int) {
 	if view.Hint != "" {
 		buf.add(t+`    android:hint="@string/%s"`, view.Hint)
 	}
-	if widget.Orientation != "" {
+	if view.Type == "image" {
+		buf.add(t+`    android:src="@drawable/%s"`, imageResourceName(view))
+		buf.add(t+`    android:scaleType="%s"`, convertAndroidScaleType(view.Scale))
+	}
+	if view.Checked {
+		buf.add(`%s    android:checked="true"`, t)
+	}
+	if view.Type == "picker" {
+		buf.add(t+`    android:entries="@array/%s"`, view.Array)
+	}
+	if view.Type == "progress" {
+		// Horizontal style shows the value, and the default is the circular spinner
+		buf.add(`%s    style="?android:attr/progressBarStyleHorizontal"`, t)
+		buf.add(`%s    android:max="100"`, t)
+		buf.add(t+`    android:progress="%d"`, androidProgress(view.Value))
+	}
+	if view.Type == "spinner_indicator" {
+		buf.add(`%s    android:indeterminate="true"`, t)
+	}
+	if view.Hidden {
+		buf.add(`%s    android:visibility="invisible"`, t)
+	}
+	if view.Type == "radio_group" && view.Selected != "" {
+		buf.add(t+`    android:checkedButton="@+id/%s"`, androidRadioButtonId(view, view.Selected))
+	}
+	if view.Type == "linear" {
+		buf.add(t+`    android:orientation="%s"`, viewOrientation(view))
+	} else if widget.Orientation != "" {
 		buf.add(t+`    android:orientation="%s"`, widget.Orientation)
 	}
+	if view.Type == "scroll" {
+		// Content which matches the parent fills the scroll view at least
+		buf.add(`%s    android:fillViewport="true"`, t)
+	}
+	if view.Type == "grid" {
+		buf.add(t+`    android:columnCount="%d"`, view.Columns)
+		if 0 < view.Rows {
+			buf.add(t+`    android:rowCount="%d"`, view.Rows)
+		}
+	}
 	if view.Gravity != "" {
 		gravity := ""
 		switch view.Gravity {
@@ -369,6 +741,26 @@ func genAndroidLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int) {
 	} else if widget.Gravity != "" {
 		buf.add(t+`    android:gravity="%s"`, widget.Gravity)
 	}
+	if parent != nil {
+		for _, attr := range convertAndroidAlignment(parent, view) {
+			buf.add(t+`    android:%s`, attr)
+		}
+		if 0 < weight {
+			buf.add(t+`    android:layout_weight="%s"`, strconv.FormatFloat(weight, 'f', -1, 64))
+		}
+		if parent.Type == "grid" {
+			if 1 < view.Span {
+				buf.add(t+`    android:layout_columnSpan="%d"`, view.Span)
+			}
+			// Columns share the width equally, and so do the rows if the number is specified
+			if gridWeight {
+				buf.add(`%s    android:layout_columnWeight="1"`, t)
+				if 0 < parent.Rows {
+					buf.add(`%s    android:layout_rowWeight="1"`, t)
+				}
+			}
+		}
+	}
 	if view.Margin != "" {
 		if view.Margin == "normal" {
 			buf.add(t+`    android:layout_margin="%s"`, "@dimen/default_margin")
@@ -389,18 +781,148 @@ func genAndroidLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int) {
 		t,
 		lo.Height)
 
-	if hasSub {
+	if view.Type == "radio_group" {
+		// Options are the radio buttons in the group
+		buf.add(`%s    >`, t)
+		for _, option := range view.Options {
+			buf.add(t+`    <RadioButton
+%s        android:id="@+id/%s"
+%s        android:text="@string/%s"
+%s        android:layout_width="match_parent"
+%s        android:layout_height="wrap_content"
+%s        />`, t, androidRadioButtonId(view, option), t, option, t, t, t)
+		}
+		buf.add(t+`</%s>`, widget.Name)
+	} else if hasSub {
 		// Print sub views recursively
 		buf.add(`    >`)
 		for _, sv := range view.Sub {
-			genAndroidLayoutRecur(&sv, false, buf, indent+1)
+			genAndroidLayoutRecur(mock, &sv, view, buf, indent+1)
 		}
 		buf.add(t+`</%s>`, widget.Name)
 	} else {
-		buf.add(t + `    />`)
+		buf.add(`%s    />`, t)
 	}
 }
 
+func genAndroidListAdapters(mock *Mock, packageDir, layoutDir string, screen Screen) {
+	for _, view := range collectListViews(&screen) {
+		var buf CodeBuffer
+		genCodeAndroidListAdapter(mock, &screen, view, &buf)
+		genFile(&buf, filepath.Join(packageDir, androidListAdapterName(&screen, view)+".java"))
+		buf = CodeBuffer{}
+		row := ListRow(view)
+		buf.add(`<?xml version="1.0" encoding="utf-8"?>`)
+		genAndroidLayoutRecur(mock, &row, nil, &buf, 0)
+		genFile(&buf, filepath.Join(layoutDir, androidListRowLayoutName(&screen, view)+".xml"))
+	}
+}
+
+// Adapter binds the items to the views in the row.
+// Items are embedded as the arrays of the texts in the order of the columns.
+func genCodeAndroidListAdapter(mock *Mock, screen *Screen, view *View, buf *CodeBuffer) {
+	name := androidListAdapterName(screen, view)
+	columns := listColumns(view)
+	buf.add(`package %s;
+
+import android.support.v7.widget.RecyclerView;
+import android.view.LayoutInflater;
+import android.view.View;
+import android.view.ViewGroup;
+import android.widget.TextView;
+
+public class %s extends RecyclerView.Adapter<%s.ViewHolder> {
+
+    public interface OnItemClickListener {
+        void onItemClick(int position);
+    }
+
+    private static final String[][] ITEMS = {`, mock.Meta.Android.Package, name, name)
+	for _, item := range view.Items {
+		texts := []string{}
+		for _, c := range columns {
+			if text, ok := item[c]; ok {
+				texts = append(texts, quoteString(text))
+			} else {
+				texts = append(texts, "null")
+			}
+		}
+		buf.add(`        {%s},`, strings.Join(texts, ", "))
+	}
+	buf.add(`    };
+
+    private OnItemClickListener onItemClickListener;
+
+    public void setOnItemClickListener(OnItemClickListener listener) {
+        onItemClickListener = listener;
+    }
+
+    @Override
+    public ViewHolder onCreateViewHolder(ViewGroup parent, int viewType) {
+        View view = LayoutInflater.from(parent.getContext()).inflate(R.layout.%s, parent, false);
+        return new ViewHolder(view);
+    }
+
+    @Override
+    public void onBindViewHolder(final ViewHolder holder, int position) {`, androidListRowLayoutName(screen, view))
+	if 0 < len(columns) {
+		buf.add(`        String[] item = ITEMS[position];`)
+	}
+	for i, c := range columns {
+		buf.add(`        bind(holder.%s, item[%d]);`, javaIdentifier(c), i)
+	}
+	buf.add(`        holder.itemView.setOnClickListener(new View.OnClickListener() {
+            @Override
+            public void onClick(View v) {
+                if (onItemClickListener != null) {
+                    onItemClickListener.onItemClick(holder.getAdapterPosition());
+                }
+            }
+        });
+    }
+
+    @Override
+    public int getItemCount() {
+        return ITEMS.length;
+    }
+
+    // Texts not in the item are left as the row layout
+    private static void bind(TextView view, String text) {
+        if (text != null) {
+            view.setText(text);
+        }
+    }
+
+    static class ViewHolder extends RecyclerView.ViewHolder {`)
+	for _, c := range columns {
+		buf.add(`        TextView %s;`, javaIdentifier(c))
+	}
+	buf.add(`
+        ViewHolder(View itemView) {
+            super(itemView);`)
+	for _, c := range columns {
+		buf.add(`            %s = (TextView) itemView.findViewById(R.id.%s);`, javaIdentifier(c), c)
+	}
+	buf.add(`        }
+    }
+}`)
+}
+
+// Lists in the different screens may have the same ID,
+// so the names of the adapters and the rows have the screen ID.
+func androidListAdapterName(screen *Screen, view *View) string {
+	return strings.Title(screen.Id) + strings.Title(javaIdentifier(view.Id)) + "Adapter"
+}
+
+func androidListRowLayoutName(screen *Screen, view *View) string {
+	return "row_" + screen.Id + "_" + view.Id
+}
+
+// Converts snake_case ID to lowerCamelCase identifier
+func javaIdentifier(id string) string {
+	return swiftIdentifier(id)
+}
+
 func genAndroidStrings(mock *Mock, valuesDir string) {
 	var buf CodeBuffer
 	genCodeAndroidStrings(mock, &buf)
@@ -442,6 +964,13 @@ func genCodeAndroidLocalizedStrings(s String, buf *CodeBuffer) {
 	for _, def := range s.Defs {
 		buf.add(`    <string name="%s">%s</string>`, def.Id, def.Value)
 	}
+	for _, a := range s.Arrays {
+		buf.add(`    <string-array name="%s">`, a.Id)
+		for _, item := range a.Items {
+			buf.add(`        <item>%s</item>`, item)
+		}
+		buf.add(`    </string-array>`)
+	}
 	buf.add(`</resources>`)
 }
 
@@ -500,6 +1029,132 @@ func genCodeAndroidDefaultDimensions(mock *Mock, buf *CodeBuffer) {
 	buf.add(`</resources>`)
 }
 
+// Copies the image files into the drawable directory of each density.
+// Placeholders are generated for all densities.
+func genAndroidDrawables(mock *Mock, inDir, resDir string) {
+	for _, view := range collectImageViews(mock) {
+		if _, _, ok := imagePlaceholderSize(view); ok {
+			for _, d := range androidDensities {
+				genPlaceholderPng(view, d.Scale, filepath.Join(resDir, "drawable-"+d.Density, imageResourceName(view)+".png"))
+			}
+			continue
+		}
+		files := findImageFiles(inDir, view.Src)
+		for _, s := range imageScales {
+			file, ok := files[s.Scale]
+			if !ok {
+				continue
+			}
+			dst := filepath.Join(resDir, "drawable-"+s.Density, imageResourceName(view)+strings.ToLower(filepath.Ext(file)))
+			if err := copyFile(file, dst); err != nil {
+				fmt.Println("Error copying file", err)
+			}
+		}
+	}
+}
+
+// Copies the HTML files of the web views into the assets directory,
+// so that they can be loaded without the network.
+func genAndroidWebAssets(mock *Mock, inDir, mainDir string) {
+	for _, asset := range collectWebViewAssets(mock) {
+		src := filepath.Join(inDir, AssetsDir, asset.Path)
+		if err := copyTree(src, filepath.Join(mainDir, "assets", asset.Path)); err != nil {
+			fmt.Println("Error copying file", err)
+		}
+	}
+}
+
+// URL loaded by the web view. Local HTML files are preferred to the URL.
+func androidWebViewUrl(view *View) string {
+	if view.Html != "" {
+		return "file:///android_asset/" + webViewHtmlPath(view.Html)
+	}
+	return view.Url
+}
+
+func convertAndroidLayoutGravity(alignH, alignV string) string {
+	if alignH == AlignCenter && alignV == AlignCenter {
+		return "center"
+	}
+	var gravity []string
+	switch alignH {
+	case AlignLeft:
+		gravity = append(gravity, "left")
+	case AlignCenter:
+		gravity = append(gravity, "center_horizontal")
+	case AlignRight:
+		gravity = append(gravity, "right")
+	}
+	switch alignV {
+	case AlignTop:
+		gravity = append(gravity, "top")
+	case AlignCenter:
+		gravity = append(gravity, "center_vertical")
+	case AlignBottom:
+		gravity = append(gravity, "bottom")
+	}
+	return strings.Join(gravity, "|")
+}
+
+// Progress is the percentage of the value from 0 to 1.
+func androidProgress(value float64) int {
+	return int(math.Round(value * 100))
+}
+
+// Radio buttons are identified by the group and the option.
+func androidRadioButtonId(view *View, option string) string {
+	return view.Id + "_" + option
+}
+
+func convertAndroidScaleType(scale string) string {
+	switch scale {
+	case ScaleFill:
+		return "centerCrop"
+	case ScaleStretch:
+		return "fitXY"
+	case ScaleCenter:
+		return "center"
+	}
+	return "fitCenter"
+}
+
+// Returns the API level of the compile SDK like "android-19",
+// or 0 if the level is unknown such as the add-ons.
+func androidCompileSdkLevel(mock *Mock) int {
+	level, err := strconv.Atoi(strings.TrimPrefix(mock.Meta.Android.CompileSdkVersion, "android-"))
+	if err != nil {
+		return 0
+	}
+	return level
+}
+
+// Versions of the support library for each compile SDK.
+// The major version must match the compile SDK, and the later ones than 25
+// are only available in the Google Maven repository.
+var androidSupportLibraryVersions = map[int]string{
+	21: "21.0.3",
+	22: "22.2.1",
+	23: "23.4.0",
+	24: "24.2.1",
+	25: "25.3.1",
+}
+
+// Minimum API level of the compile SDK to use the support library.
+const androidSupportLibraryLevel = 21
+
+func androidSupportLibraryVersion(mock *Mock) string {
+	level := androidCompileSdkLevel(mock)
+	if v, ok := androidSupportLibraryVersions[level]; ok {
+		return v
+	}
+	return androidSupportLibraryVersions[25]
+}
+
+// layout_columnWeight and layout_rowWeight of GridLayout are added in API level 21.
+func androidGridWeightSupported(mock *Mock) bool {
+	return 21 <= androidCompileSdkLevel(mock)
+}
+
 func convertAndroidLayoutOptions(widget Widget, view *View) (lo LayoutOptions) {
 	base := view.SizeW
 	if base == "" {
@@ -521,3 +1176,56 @@ func convertAndroidLayoutOptions(widget Widget, view *View) (lo LayoutOptions) {
 	}
 	return
 }
+
+// Converts align_h/align_v into layout attributes which depend on the parent layout
+func convertAndroidAlignment(parent *View, view *View) (attrs []string) {
+	switch parent.Type {
+	case "linear", "scroll":
+		if gravity := convertAndroidLayoutGravity(view.AlignH, view.AlignV); gravity != "" {
+			attrs = append(attrs, `layout_gravity="`+gravity+`"`)
+		}
+	case "frame", "grid":
+		// Frame and grid don't have the gravity for the sub views
+		alignH, alignV := view.AlignH, view.AlignV
+		if alignH == "" && parent.Gravity == GravityCenter {
+			alignH = AlignCenter
+		}
+		if alignV == "" && (parent.Gravity == GravityCenter || parent.Gravity == GravityCenterV) {
+			alignV = AlignCenter
+		}
+		sizeH := view.SizeH
+		if sizeH == "" {
+			sizeH = awd.Get(view.Type).SizeH
+		}
+		gravity := convertAndroidLayoutGravity(alignH, alignV)
+		if parent.Type == "grid" && parent.Rows == 0 && sizeH == SizeFill {
+			// Fill the height of the row
+			gravity = convertAndroidLayoutGravity(alignH, "")
+			if gravity != "" {
+				gravity += "|"
+			}
+			gravity += "fill_vertical"
+		}
+		if gravity != "" {
+			attrs = append(attrs, `layout_gravity="`+gravity+`"`)
+		}
+	case "relative":
+		switch view.AlignH {
+		case AlignLeft:
+			attrs = append(attrs, `layout_alignParentLeft="true"`)
+		case AlignCenter:
+			attrs = append(attrs, `layout_centerHorizontal="true"`)
+		case AlignRight:
+			attrs = append(attrs, `layout_alignParentRight="true"`)
+		}
+		switch view.AlignV {
+		case AlignTop:
+			attrs = append(attrs, `layout_alignParentTop="true"`)
+		case AlignCenter:
+			attrs = append(attrs, `layout_centerVertical="true"`)
+		case AlignBottom:
+			attrs = append(attrs, `layout_alignParentBottom="true"`)
+		}
+	}
+	return
+}
@@ -0,0 +1,174 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenCodeAndroidActivityTest(t *testing.T) {
+	mock := Mock{
+		Meta:   Meta{Android: Android{Package: "com.example.sample"}},
+		Launch: Launch{Screen: "top"},
+		Screens: []Screen{
+			{Id: "top", Behaviors: []Behavior{
+				{Trigger: Trigger{Type: "click", Widget: "next"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+				// Same pair of the widget and the screen as above
+				{Trigger: Trigger{Type: "click", Widget: "next"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+				// Another widget to the same screen is kept
+				{Trigger: Trigger{Type: "click", Widget: "more"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+				// Missing screen and the other types are ignored
+				{Trigger: Trigger{Type: "click", Widget: "help"}, Action: Action{Type: "transit_forward", Transit: "missing"}},
+				{Trigger: Trigger{Type: "changed", Widget: "agree"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+				{Trigger: Trigger{Type: "click", Widget: "toggle"}, Action: Action{Type: "show", Widget: "detail"}},
+			}},
+			{Id: "second"},
+		},
+	}
+
+	behaviors := findTransitBehaviors(&mock, mock.Screens[0])
+	if len(behaviors) != 2 {
+		t.Fatalf("Expected 2 behaviors but %d: %v", len(behaviors), behaviors)
+	}
+
+	var buf CodeBuffer
+	genCodeAndroidActivityTest(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"package com.example.sample;",
+		"public class TopActivityTest {",
+		"public IntentsTestRule<TopActivity> activityRule = new IntentsTestRule<TopActivity>(TopActivity.class);",
+		`    public void clickNextStartsSecondActivity() {
+        onView(withId(R.id.next)).perform(click());
+        intended(hasComponent(SecondActivity.class.getName()));
+    }`,
+		"    public void clickMoreStartsSecondActivity() {",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+	if n := strings.Count(code, "@Test"); n != 2 {
+		t.Errorf("Expected 2 tests but %d in\n%s", n, code)
+	}
+	for _, unexpected := range []string{"Help", "Agree", "Toggle"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Unexpected %q in\n%s", unexpected, code)
+		}
+	}
+
+	// The screen without transitions has no test methods
+	buf = CodeBuffer{}
+	genCodeAndroidActivityTest(&mock, mock.Screens[1], &buf)
+	if code := strings.Join(buf, "\n"); strings.Contains(code, "@Test") {
+		t.Errorf("Unexpected test in\n%s", code)
+	}
+}
+
+func TestGenAndroidGridWeight(t *testing.T) {
+	defineAndroidWidgets()
+	grid := View{Type: "grid", Columns: 2, Rows: 2, Sub: []View{
+		{Id: "one", Type: "button"},
+		{Id: "two", Type: "button"},
+	}}
+	var testcases = []struct {
+		compileSdk string
+		weight     bool
+	}{
+		{"android-19", false},
+		{"android-21", true},
+		{"android-23", true},
+		{"Google Inc.:Google APIs:23", false},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Meta: Meta{Android: Android{CompileSdkVersion: tc.compileSdk}}}
+		var buf CodeBuffer
+		genAndroidLayoutRecur(&mock, &grid, nil, &buf, 0)
+		code := strings.Join(buf, "\n")
+		cells := 0
+		if tc.weight {
+			cells = 2
+		}
+		// Views without weights must not be sized 0dp
+		for _, attr := range []string{`android:layout_columnWeight="1"`, `android:layout_rowWeight="1"`, `android:layout_width="0dp"`} {
+			if n := strings.Count(code, attr); n != cells {
+				t.Errorf("Expected %d %s but %d: compileSdk=%s\n%s", cells, attr, n, tc.compileSdk, code)
+			}
+		}
+	}
+}
+
+func TestGenCodeAndroidGradle(t *testing.T) {
+	list := []Screen{{Id: "top", Layout: []View{{Id: "users", Type: "list", Row: &View{Type: "label"}}}}}
+	var testcases = []struct {
+		compileSdk string
+		screens    []Screen
+		expect     string
+	}{
+		{"android-21", list, "recyclerview-v7:21.0.3'"},
+		{"android-23", list, "recyclerview-v7:23.4.0'"},
+		// The last version in the Android Support Repository is used
+		{"android-28", list, "recyclerview-v7:25.3.1'"},
+		{"android-23", nil, ""},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Meta: Meta{Android: Android{CompileSdkVersion: tc.compileSdk}}, Screens: tc.screens}
+		var buf CodeBuffer
+		genCodeAndroidGradle(&mock, &buf)
+		code := strings.Join(buf, "\n")
+		if strings.Contains(code, ":+'") {
+			t.Errorf("Unexpected dynamic version: compileSdk=%s\n%s", tc.compileSdk, code)
+		}
+		if tc.expect == "" {
+			if strings.Contains(code, "recyclerview") {
+				t.Errorf("Unexpected recyclerview: compileSdk=%s\n%s", tc.compileSdk, code)
+			}
+		} else if !strings.Contains(code, tc.expect) {
+			t.Errorf("Expected %q: compileSdk=%s\n%s", tc.expect, tc.compileSdk, code)
+		}
+	}
+}
+
+func TestGenAndroidLayoutWidgets(t *testing.T) {
+	defineAndroidWidgets()
+	var testcases = []struct {
+		view    View
+		expects []string
+	}{
+		{View{Id: "agree", Type: "checkbox", Label: "agree", Checked: true},
+			[]string{"<CheckBox", `android:text="@string/agree"`, `android:checked="true"`}},
+		{View{Id: "notify", Type: "switch", Label: "notify"},
+			[]string{"<Switch", `android:text="@string/notify"`}},
+		{View{Id: "gender", Type: "radio_group", Options: []string{"male", "female"}, Selected: "female"},
+			[]string{"<RadioGroup", `android:checkedButton="@+id/gender_female"`, `android:id="@+id/gender_male"`, `android:text="@string/female"`}},
+		{View{Id: "fruit", Type: "picker", Array: "fruits"},
+			[]string{"<Spinner", `android:entries="@array/fruits"`}},
+		{View{Id: "users", Type: "list", Row: &View{Id: "name", Type: "label"}},
+			[]string{"<android.support.v7.widget.RecyclerView", `android:id="@+id/users"`}},
+		{View{Id: "form", Type: "scroll", Sub: []View{{Type: "linear", Sub: []View{{Id: "name", Type: "input"}}}}},
+			[]string{"<ScrollView", `android:fillViewport="true"`, "<EditText"}},
+		{View{Id: "buttons", Type: "linear", Orientation: "horizontal", Sub: []View{{Id: "ok", Type: "button", Weight: 2}}},
+			[]string{`android:orientation="horizontal"`, `android:layout_weight="2"`, `android:layout_width="0dp"`}},
+		{View{Id: "frame", Type: "frame", Sub: []View{{Id: "a", Type: "label", AlignH: "right"}}},
+			[]string{"<FrameLayout", `android:layout_gravity="right"`}},
+		{View{Id: "grid", Type: "grid", Columns: 2, Sub: []View{{Id: "a", Type: "label", Span: 2}}},
+			[]string{"<GridLayout", `android:columnCount="2"`, `android:layout_columnSpan="2"`}},
+		{View{Id: "bar", Type: "progress", Value: 0.5},
+			[]string{"progressBarStyleHorizontal", `android:max="100"`, `android:progress="50"`}},
+		{View{Id: "spinner", Type: "spinner_indicator", Hidden: true},
+			[]string{`android:indeterminate="true"`, `android:visibility="invisible"`}},
+		{View{Id: "help", Type: "web", Html: "help/index.html"},
+			[]string{"<WebView", `android:id="@+id/help"`}},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(tc.view)
+		mock.Strings = []String{{Lang: "base", Arrays: []Array{{Id: "fruits", Items: []string{"Apple", "Banana"}}}}}
+		var buf CodeBuffer
+		genAndroidLayoutRecur(&mock, &mock.Screens[0].Layout[0], nil, &buf, 0)
+		code := strings.Join(buf, "\n")
+		for _, expect := range tc.expects {
+			if !strings.Contains(code, expect) {
+				t.Errorf("Expected %q in\n%s", expect, code)
+			}
+		}
+	}
+}
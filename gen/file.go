@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 )
@@ -24,3 +25,28 @@ func fileExists(filename string) bool {
 	}
 	return true
 }
+
+func copyFile(src, dst string) error {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	f := createFile(dst)
+	defer f.Close()
+	_, err = f.Write(b)
+	return err
+}
+
+// Copies the file, or the directory with all the files in it.
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		return copyFile(p, filepath.Join(dst, rel))
+	})
+}
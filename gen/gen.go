@@ -1,6 +1,9 @@
 package gen
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Generator interface {
 	Generate()
@@ -13,6 +16,12 @@ func NewGenerator(opt *Options, mock *Mock, genId string) Generator {
 		g = &IosGenerator{opt, mock}
 	case "android":
 		g = &AndroidGenerator{opt, mock}
+	case "swiftui":
+		g = &SwiftUIGenerator{opt, mock}
+	case "web":
+		g = &WebGenerator{opt, mock}
+	case "spec":
+		g = &SpecGenerator{opt, mock}
 	}
 	return g
 }
@@ -36,6 +45,12 @@ func genFile(buf *CodeBuffer, filename string) {
 	f.Close()
 }
 
+// Quotes the string as the literal of Java, Objective-C and Swift.
+func quoteString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
 func tab(level int) string {
 	s := ""
 	for i := 0; i < level; i++ {
@@ -43,3 +58,121 @@ func tab(level int) string {
 	}
 	return s
 }
+
+// Collects click behaviors which transit to the existing screens.
+// Duplicated pairs of the widget and the next screen are removed
+// because they describe the same navigation.
+func findTransitBehaviors(mock *Mock, screen Screen) (behaviors []Behavior) {
+	found := map[string]bool{}
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Type != "click" || b.Action.Type != "transit_forward" {
+			continue
+		}
+		for _, next := range mock.Screens {
+			key := b.Trigger.Widget + "|" + next.Id
+			if next.Id == b.Action.Transit && !found[key] {
+				found[key] = true
+				behaviors = append(behaviors, b)
+			}
+		}
+	}
+	return
+}
+
+// Finds the click behaviors to follow from the launch screen to the screen.
+// ok is false if the screen is not reachable.
+func findTransitPath(mock *Mock, screenId string) (path []Behavior, ok bool) {
+	paths := map[string][]Behavior{mock.Launch.Screen: {}}
+	queue := []string{mock.Launch.Screen}
+	for 0 < len(queue) {
+		id := queue[0]
+		queue = queue[1:]
+		if id == screenId {
+			return paths[id], true
+		}
+		for _, screen := range mock.Screens {
+			if screen.Id != id {
+				continue
+			}
+			for _, b := range findTransitBehaviors(mock, screen) {
+				if _, visited := paths[b.Action.Transit]; visited {
+					continue
+				}
+				paths[b.Action.Transit] = append(append([]Behavior{}, paths[id]...), b)
+				queue = append(queue, b.Action.Transit)
+			}
+		}
+	}
+	return nil, false
+}
+
+// Visits the views in the layout of the screen in depth-first order,
+// including the row templates of the lists.
+func walkViews(screen *Screen, visit func(view *View)) {
+	for i := range screen.Layout {
+		walkView(&screen.Layout[i], visit)
+	}
+}
+
+func walkView(view *View, visit func(view *View)) {
+	visit(view)
+	for i := range view.Sub {
+		walkView(&view.Sub[i], visit)
+	}
+	if view.Row != nil {
+		walkView(view.Row, visit)
+	}
+}
+
+// Finds the view which has the ID from the layout of the screen.
+func findView(screen *Screen, id string) *View {
+	for i := range screen.Layout {
+		if v := findViewRecur(&screen.Layout[i], id); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func findViewRecur(view *View, id string) *View {
+	if view.Id == id {
+		return view
+	}
+	for i := range view.Sub {
+		if v := findViewRecur(&view.Sub[i], id); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// Splits the sub views of the relative layout into chains.
+// Each chain starts with the view which is not below any sibling.
+func chainViewsBelow(views []View) (chains [][]View) {
+	index := map[string]int{}
+	for _, v := range views {
+		if v.Below != "" {
+			if i, ok := index[v.Below]; ok {
+				chains[i] = append(chains[i], v)
+				if v.Id != "" {
+					index[v.Id] = i
+				}
+				continue
+			}
+		}
+		chains = append(chains, []View{v})
+		if v.Id != "" {
+			index[v.Id] = len(chains) - 1
+		}
+	}
+	return
+}
+
+func findScreen(mock *Mock, id string) *Screen {
+	for i := range mock.Screens {
+		if mock.Screens[i].Id == id {
+			return &mock.Screens[i]
+		}
+	}
+	return nil
+}
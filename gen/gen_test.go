@@ -0,0 +1,57 @@
+package gen
+
+import "testing"
+
+func TestFindTransitPath(t *testing.T) {
+	click := func(widget, next string) Behavior {
+		return Behavior{Trigger: Trigger{Type: "click", Widget: widget}, Action: Action{Type: "transit_forward", Transit: next}}
+	}
+	mock := Mock{
+		Launch: Launch{Screen: "top"},
+		Screens: []Screen{
+			{Id: "top", Behaviors: []Behavior{click("to_second", "second"), click("to_third", "third")}},
+			{Id: "second", Behaviors: []Behavior{click("to_fourth", "fourth")}},
+			{Id: "third", Behaviors: []Behavior{click("to_fourth_long", "fourth")}},
+			{Id: "fourth", Behaviors: []Behavior{click("to_top", "top")}},
+			// Only transits to the reachable screen
+			{Id: "orphan", Behaviors: []Behavior{click("to_second", "second")}},
+		},
+	}
+	var testcases = []struct {
+		screen string
+		path   []string
+		ok     bool
+	}{
+		{"top", []string{}, true},
+		{"second", []string{"to_second"}, true},
+		{"third", []string{"to_third"}, true},
+		// The first path found by the breadth first search is used
+		{"fourth", []string{"to_second", "to_fourth"}, true},
+		{"orphan", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tc := range testcases {
+		path, ok := findTransitPath(&mock, tc.screen)
+		if ok != tc.ok {
+			t.Errorf("Expected ok=%v but %v: screen=%s", tc.ok, ok, tc.screen)
+			continue
+		}
+		if len(path) != len(tc.path) {
+			t.Errorf("Expected %v but %v: screen=%s", tc.path, path, tc.screen)
+			continue
+		}
+		for i, b := range path {
+			if b.Trigger.Widget != tc.path[i] {
+				t.Errorf("Expected %v but %v: screen=%s", tc.path, path, tc.screen)
+				break
+			}
+		}
+	}
+}
+
+// Mock of the single screen which has the views in the linear layout.
+func mockWithViews(views ...View) Mock {
+	return Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{{Type: "linear", Sub: views}}},
+	}}
+}
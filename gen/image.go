@@ -0,0 +1,178 @@
+package gen
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ksoichiro/mocker/font"
+)
+
+// Directory next to the Mockerfile which has the image files
+const AssetsDir = "assets"
+
+// Size of the images in the static layout and the placeholders
+const imageDefaultSize = 100
+
+var (
+	colorPlaceholder       = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	colorPlaceholderBorder = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	colorPlaceholderText   = color.RGBA{0x55, 0x55, 0x55, 0xff}
+)
+
+// Scales of the image files.
+// Files for 2x and 3x are optional and named like "logo@2x.png".
+// They match the scales of iOS, so the files are not copied for hdpi and xxxhdpi:
+// hdpi devices scale down the xhdpi images,
+// and xxxhdpi devices scale up the xxhdpi ones.
+var imageScales = []struct {
+	Scale   int
+	Density string
+}{
+	{1, "mdpi"},
+	{2, "xhdpi"},
+	{3, "xxhdpi"},
+}
+
+// Scales of the Android densities, in which the placeholders are generated.
+var androidDensities = []struct {
+	Scale   float64
+	Density string
+}{
+	{1, "mdpi"},
+	{1.5, "hdpi"},
+	{2, "xhdpi"},
+	{3, "xxhdpi"},
+	{4, "xxxhdpi"},
+}
+
+// Finds the image files of the source for each scale.
+// The file for 1x is always returned even if it does not exist.
+func findImageFiles(inDir, src string) map[int]string {
+	path := filepath.Join(inDir, AssetsDir, filepath.FromSlash(src))
+	ext := filepath.Ext(path)
+	files := map[int]string{1: path}
+	for _, s := range imageScales[1:] {
+		scaled := strings.TrimSuffix(path, ext) + "@" + strconv.Itoa(s.Scale) + "x" + ext
+		if fileExists(scaled) {
+			files[s.Scale] = scaled
+		}
+	}
+	return files
+}
+
+var imageInvalidChars = regexp.MustCompile(`[^a-z0-9_]`)
+
+// Converts the image of the view into the name of the resource.
+// Android only allows lowercase letters, digits and underscores.
+// Placeholders of the same ID and size are the same image, so they share the name.
+func imageResourceName(view *View) string {
+	if w, h, ok := imagePlaceholderSize(view); ok {
+		name := "placeholder"
+		if view.Id != "" {
+			name += "_" + imageInvalidChars.ReplaceAllString(strings.ToLower(view.Id), "_")
+		}
+		return fmt.Sprintf("%s_%dx%d", name, w, h)
+	}
+	base := filepath.Base(filepath.FromSlash(view.Src))
+	name := imageInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSuffix(base, filepath.Ext(base))), "_")
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		name = "image_" + name
+	}
+	return name
+}
+
+// Describes the image of the view to tell the different images apart.
+// Placeholders show the view IDs, so they differ by the IDs as well as the sizes.
+func imageSource(view *View) string {
+	if w, h, ok := imagePlaceholderSize(view); ok {
+		return fmt.Sprintf("placeholder %dx%d of %s", w, h, view.Id)
+	}
+	return path.Clean(filepath.ToSlash(view.Src))
+}
+
+// Returns the size of the placeholder if the view uses it instead of the file.
+// Placeholder is used when the source is not specified or the placeholder is specified.
+func imagePlaceholderSize(view *View) (w, h int, ok bool) {
+	if view.Type != "image" || (view.Src != "" && view.Placeholder == "") {
+		return 0, 0, false
+	}
+	if view.Placeholder == "" {
+		return imageDefaultSize, imageDefaultSize, true
+	}
+	w, h, err := parseImageSize(view.Placeholder)
+	return w, h, err == nil
+}
+
+// Parses the size such as "320x180".
+func parseImageSize(s string) (w, h int, err error) {
+	parts := strings.Split(s, "x")
+	if len(parts) == 2 {
+		w, err = strconv.Atoi(parts[0])
+		if err == nil {
+			h, err = strconv.Atoi(parts[1])
+		}
+		if err == nil && 0 < w && 0 < h {
+			return w, h, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid size: %s", s)
+}
+
+// Draws the grey box with the size and the view ID.
+// It's drawn at 1x and scaled up so that the texts look the same at all scales.
+func renderPlaceholder(view *View, scale float64) *image.RGBA {
+	w, h, _ := imagePlaceholderSize(view)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorPlaceholderBorder), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds().Inset(1), image.NewUniform(colorPlaceholder), image.Point{}, draw.Src)
+	lines := []string{fmt.Sprintf("%dx%d", w, h)}
+	if view.Id != "" {
+		lines = append(lines, view.Id)
+	}
+	y := (h - len(lines)*font.Height) / 2
+	for _, line := range lines {
+		font.DrawText(img, image.Rect(0, y, w, y+font.Height), line, colorPlaceholderText, true)
+		y += font.Height
+	}
+	if scale == 1 {
+		return img
+	}
+	// Pixels are repeated without smoothing to keep the texts sharp
+	sw, sh := int(float64(w)*scale+0.5), int(float64(h)*scale+0.5)
+	scaled := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			scaled.SetRGBA(x, y, img.RGBAAt(x*w/sw, y*h/sh))
+		}
+	}
+	return scaled
+}
+
+func genPlaceholderPng(view *View, scale float64, filename string) {
+	f := createFile(filename)
+	defer f.Close()
+	png.Encode(f, renderPlaceholder(view, scale))
+}
+
+// Collects the image views in all screens.
+// Views which have the same resource name are collected only once.
+func collectImageViews(mock *Mock) (views []*View) {
+	found := map[string]bool{}
+	for i := range mock.Screens {
+		walkViews(&mock.Screens[i], func(view *View) {
+			if view.Type == "image" && !found[imageResourceName(view)] {
+				found[imageResourceName(view)] = true
+				views = append(views, view)
+			}
+		})
+	}
+	return
+}
@@ -0,0 +1,70 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImagePlaceholder(t *testing.T) {
+	var testcases = []struct {
+		view  View
+		name  string
+		scale float64
+		w     int
+		h     int
+	}{
+		{View{Id: "logo", Type: "image", Src: "Logo-Main.png"}, "logo_main", 0, 0, 0},
+		{View{Id: "logo", Type: "image"}, "placeholder_logo_100x100", 1, 100, 100},
+		{View{Id: "Top-Banner", Type: "image", Placeholder: "320x180"}, "placeholder_top_banner_320x180", 2, 640, 360},
+		{View{Type: "image", Src: "banner.png", Placeholder: "32x18"}, "placeholder_32x18", 3, 96, 54},
+		// hdpi and xxxhdpi of Android
+		{View{Id: "icon", Type: "image", Placeholder: "33x20"}, "placeholder_icon_33x20", 1.5, 50, 30},
+		{View{Id: "icon", Type: "image", Placeholder: "33x20"}, "placeholder_icon_33x20", 4, 132, 80},
+	}
+	for _, tc := range testcases {
+		if name := imageResourceName(&tc.view); name != tc.name {
+			t.Errorf("Expected %s but %s", tc.name, name)
+		}
+		if tc.scale == 0 {
+			continue
+		}
+		b := renderPlaceholder(&tc.view, tc.scale).Bounds()
+		if b.Dx() != tc.w || b.Dy() != tc.h {
+			t.Errorf("Expected %dx%d but %dx%d: %s", tc.w, tc.h, b.Dx(), b.Dy(), tc.name)
+		}
+	}
+}
+
+func TestImageInListRow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mocker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, AssetsDir), 0777)
+	ioutil.WriteFile(filepath.Join(dir, AssetsDir, "icon.png"), []byte("icon"), 0666)
+
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Id: "users", Type: "list", Row: &View{Type: "linear", Sub: []View{
+				{Id: "icon", Type: "image", Src: "icon.png"},
+				{Id: "name", Type: "label"},
+			}}, Items: []map[string]string{{"name": "Alice"}}},
+		}},
+	}}
+	if views := collectImageViews(&mock); len(views) != 1 || views[0].Id != "icon" {
+		t.Errorf("Expected the image in the row but %v", views)
+	}
+	if files := WebFiles(&mock, dir); files["images/icon.png"] != "icon" {
+		t.Errorf("Expected the image file in the row")
+	}
+	if errs := ValidateAssets(&mock, dir); len(errs) != 0 {
+		t.Errorf("Expected no errors but %v", errs)
+	}
+	mock.Screens[0].Layout[0].Row.Sub[0].Src = "missing.png"
+	if errs := ValidateAssets(&mock, dir); len(errs) != 1 {
+		t.Errorf("Expected the missing asset in the row but %v", errs)
+	}
+}
@@ -2,7 +2,9 @@ package gen
 
 import (
 	"encoding/hex"
+	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -37,6 +39,60 @@ func defineIosWidgets() {
 		SizeW:    SizeFill,
 		SizeH:    SizeWrap,
 	})
+	iwd.Add("image", Widget{
+		Name:     "image",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("switch", Widget{
+		Name:     "switch",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("checkbox", Widget{
+		Name:     "checkbox",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("radio_group", Widget{
+		Name:     "radio_group",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("picker", Widget{
+		Name:     "picker",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("web", Widget{
+		Name:     "web",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	iwd.Add("progress", Widget{
+		Name:     "progress",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("spinner_indicator", Widget{
+		Name:     "spinner_indicator",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	iwd.Add("list", Widget{
+		Name:     "list",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
 	iwd.Add("linear", Widget{
 		Textable:    false,
 		Orientation: OrientationVertical,
@@ -48,6 +104,22 @@ func defineIosWidgets() {
 		SizeW:    SizeFill,
 		SizeH:    SizeFill,
 	})
+	iwd.Add("scroll", Widget{
+		Name:     "scroll",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	iwd.Add("frame", Widget{
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	iwd.Add("grid", Widget{
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
 }
 
 func (g *IosGenerator) Generate() {
@@ -55,6 +127,8 @@ func (g *IosGenerator) Generate() {
 
 	outDir := g.opt.OutDir
 	projectDir := filepath.Join(outDir, g.mock.Meta.Ios.Project)
+	swift := g.mock.Meta.Ios.Language == IosLanguageSwift
+	storyboard := g.mock.Meta.Ios.Layout == IosLayoutStoryboard
 
 	var wg sync.WaitGroup
 
@@ -73,11 +147,13 @@ func (g *IosGenerator) Generate() {
 	}(g.mock, outDir)
 
 	// Generate main.m
-	wg.Add(1)
-	go func(mock *Mock, dir string) {
-		defer wg.Done()
-		genIosMain(mock, dir)
-	}(g.mock, outDir)
+	if !swift {
+		wg.Add(1)
+		go func(mock *Mock, dir string) {
+			defer wg.Done()
+			genIosMain(mock, dir)
+		}(g.mock, outDir)
+	}
 
 	// Generate Info.plist
 	wg.Add(1)
@@ -94,11 +170,13 @@ func (g *IosGenerator) Generate() {
 	}(g.mock, outDir)
 
 	// Generate Prefix.pch
-	wg.Add(1)
-	go func(mock *Mock, dir string) {
-		defer wg.Done()
-		genIosPch(mock, dir)
-	}(g.mock, outDir)
+	if !swift {
+		wg.Add(1)
+		go func(mock *Mock, dir string) {
+			defer wg.Done()
+			genIosPch(mock, dir)
+		}(g.mock, outDir)
+	}
 
 	// Generate Images.xcassets
 	wg.Add(1)
@@ -111,34 +189,80 @@ func (g *IosGenerator) Generate() {
 		defer wg.Done()
 		genIosImagesXcAssetsLaunchImage(mock, dir)
 	}(g.mock, outDir)
-
-	// Generate AppDelegate
 	wg.Add(1)
-	go func(mock *Mock, dir string) {
+	go func(mock *Mock, inDir, dir string) {
 		defer wg.Done()
-		genIosAppDelegateHeader(mock, dir)
-	}(g.mock, outDir)
+		genIosImagesXcAssetsImageSets(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, outDir)
 	wg.Add(1)
-	go func(mock *Mock, dir string) {
+	go func(mock *Mock, inDir, dir string) {
 		defer wg.Done()
-		genIosAppDelegateImplementation(mock, dir)
-	}(g.mock, outDir)
+		genIosWebViewHtmlFiles(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, outDir)
+
+	// Generate AppDelegate
+	if swift {
+		wg.Add(1)
+		go func(mock *Mock, dir string) {
+			defer wg.Done()
+			genIosSwiftAppDelegate(mock, dir)
+		}(g.mock, outDir)
+	} else {
+		wg.Add(1)
+		go func(mock *Mock, dir string) {
+			defer wg.Done()
+			genIosAppDelegateHeader(mock, dir)
+		}(g.mock, outDir)
+		wg.Add(1)
+		go func(mock *Mock, dir string) {
+			defer wg.Done()
+			genIosAppDelegateImplementation(mock, dir)
+		}(g.mock, outDir)
+	}
 
 	// Generate ViewControllers
 	for _, screen := range g.mock.Screens {
 		wg.Add(1)
 		go func(mock *Mock, dir string, screen Screen) {
 			defer wg.Done()
+			if storyboard {
+				genIosStoryboardViewController(mock, dir, screen)
+				return
+			}
+			if swift {
+				genIosSwiftViewController(mock, dir, screen)
+				return
+			}
 			layoutCodeBuf := genIosViewControllerLayout(mock, dir, screen)
 			genIosViewController(mock, dir, screen, &layoutCodeBuf)
 		}(g.mock, projectDir, screen)
 	}
 
-	// Generate view helper
+	// Generate UI tests
+	for _, screen := range g.mock.Screens {
+		wg.Add(1)
+		go func(mock *Mock, dir string, screen Screen) {
+			defer wg.Done()
+			genIosUITest(mock, dir, screen)
+		}(g.mock, outDir, screen)
+	}
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genIosUITestsInfoPlist(mock, dir)
+	}(g.mock, outDir)
+
+	// Generate view helper or storyboard
 	wg.Add(1)
 	go func(mock *Mock, dir string) {
 		defer wg.Done()
-		genIosViewHelper(mock, dir)
+		if storyboard {
+			genIosStoryboard(mock, dir)
+		} else if swift {
+			genIosSwiftViewHelper(mock, dir)
+		} else {
+			genIosViewHelper(mock, dir)
+		}
 	}(g.mock, projectDir)
 
 	// Generate resources
@@ -150,7 +274,11 @@ func (g *IosGenerator) Generate() {
 	wg.Add(1)
 	go func(mock *Mock, dir string) {
 		defer wg.Done()
-		genIosColors(mock, dir)
+		if swift {
+			genIosSwiftColors(mock, dir)
+		} else {
+			genIosColors(mock, dir)
+		}
 	}(g.mock, projectDir)
 
 	// Generate project.pbxproj
@@ -222,6 +350,12 @@ func genIosInfoPlist(mock *Mock, dir string) {
 }
 
 func genCodeIosInfoPlist(mock *Mock, buf *CodeBuffer) {
+	mainStoryboard := ""
+	if mock.Meta.Ios.Layout == IosLayoutStoryboard {
+		mainStoryboard = `	<key>UIMainStoryboardFile</key>
+	<string>Main</string>
+`
+	}
 	buf.add(`<?xml version="1.0" encoding="UTF-8"?>
 <!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
 <plist version="1.0">
@@ -248,7 +382,7 @@ func genCodeIosInfoPlist(mock *Mock, buf *CodeBuffer) {
 	<string>1.0</string>
 	<key>LSRequiresIPhoneOS</key>
 	<true/>
-	<key>UIRequiredDeviceCapabilities</key>
+%s	<key>UIRequiredDeviceCapabilities</key>
 	<array>
 		<string>armv7</string>
 	</array>
@@ -260,7 +394,8 @@ func genCodeIosInfoPlist(mock *Mock, buf *CodeBuffer) {
 	</array>
 </dict>
 </plist>`,
-		mock.Meta.Ios.CompanyIdentifier)
+		mock.Meta.Ios.CompanyIdentifier,
+		mainStoryboard)
 }
 
 func genIosInfoPlistStrings(mock *Mock, dir string) {
@@ -331,6 +466,74 @@ func genCodeIosImagesXcAssetsAppIcon(mock *Mock, buf *CodeBuffer) {
 }`)
 }
 
+// Images.xcassets is already in the project, so the sets don't have to be registered.
+func genIosImagesXcAssetsImageSets(mock *Mock, inDir, dir string) {
+	genXcAssetsImageSets(mock, inDir, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project, "Images.xcassets"))
+}
+
+// Copies the image files into the image sets of the asset catalog.
+// Placeholders are generated for all scales.
+func genXcAssetsImageSets(mock *Mock, inDir, catalogDir string) {
+	for _, view := range collectImageViews(mock) {
+		name := imageResourceName(view)
+		setDir := filepath.Join(catalogDir, name+".imageset")
+		filenames := map[int]string{}
+		if _, _, ok := imagePlaceholderSize(view); ok {
+			for _, s := range imageScales {
+				filenames[s.Scale] = fmt.Sprintf("%s@%dx.png", name, s.Scale)
+				genPlaceholderPng(view, float64(s.Scale), filepath.Join(setDir, filenames[s.Scale]))
+			}
+		} else {
+			for scale, file := range findImageFiles(inDir, view.Src) {
+				filenames[scale] = filepath.Base(file)
+				if err := copyFile(file, filepath.Join(setDir, filenames[scale])); err != nil {
+					fmt.Println("Error copying file", err)
+				}
+			}
+		}
+		var buf CodeBuffer
+		genCodeIosImagesXcAssetsImageSet(filenames, &buf)
+		genFile(&buf, filepath.Join(setDir, "Contents.json"))
+	}
+}
+
+// Copies the HTML files of the web views with their directories into the bundle,
+// which are registered as the resources in the project.
+func genIosWebViewHtmlFiles(mock *Mock, inDir, dir string) {
+	for _, asset := range collectWebViewAssets(mock) {
+		src := filepath.Join(inDir, AssetsDir, asset.Path)
+		if err := copyTree(src, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project, asset.Path)); err != nil {
+			fmt.Println("Error copying file", err)
+		}
+	}
+}
+
+func genCodeIosImagesXcAssetsImageSet(filenames map[int]string, buf *CodeBuffer) {
+	buf.add(`{
+  "images" : [`)
+	for i, s := range imageScales {
+		filename := ""
+		if f, ok := filenames[s.Scale]; ok {
+			filename = fmt.Sprintf(`
+      "filename" : "%s",`, f)
+		}
+		trail := ","
+		if i == len(imageScales)-1 {
+			trail = ""
+		}
+		buf.add(`    {
+      "idiom" : "universal",%s
+      "scale" : "%dx"
+    }%s`, filename, s.Scale, trail)
+	}
+	buf.add(`  ],
+  "info" : {
+    "version" : 1,
+    "author" : "xcode"
+  }
+}`)
+}
+
 func genIosImagesXcAssetsLaunchImage(mock *Mock, dir string) {
 	var buf CodeBuffer
 	genCodeIosImagesXcAssetsLaunchImage(mock, &buf)
@@ -361,8 +564,8 @@ func genCodeIosImagesXcAssetsLaunchImage(mock *Mock, buf *CodeBuffer) {
     "author" : "xcode"
   }
 }`,
-		mock.Meta.Ios.DeploymentTarget,
-		mock.Meta.Ios.DeploymentTarget)
+		iosDeploymentTarget(mock),
+		iosDeploymentTarget(mock))
 }
 
 func genIosAppDelegateHeader(mock *Mock, dir string) {
@@ -388,6 +591,22 @@ func genIosAppDelegateImplementation(mock *Mock, dir string) {
 }
 
 func genCodeIosAppDelegateImplementation(mock *Mock, buf *CodeBuffer) {
+	if mock.Meta.Ios.Layout == IosLayoutStoryboard {
+		// Window and the root view controller are loaded from the storyboard
+		buf.add(`#import "%sAppDelegate.h"
+
+@implementation %sAppDelegate
+
+- (BOOL)application:(UIApplication *)application didFinishLaunchingWithOptions:(NSDictionary *)launchOptions
+{
+    return YES;
+}
+
+@end`,
+			mock.Meta.Ios.ClassPrefix,
+			mock.Meta.Ios.ClassPrefix)
+		return
+	}
 	launchVcPrefix := mock.Meta.Ios.ClassPrefix + strings.Title(mock.Launch.Screen)
 	buf.add(`#import "%sAppDelegate.h"
 #import "%sViewController.h"
@@ -422,8 +641,11 @@ func genIosViewController(mock *Mock, dir string, screen Screen, layoutCodeBuf *
 }
 
 func genCodeIosViewControllerHeader(mock *Mock, screen Screen, buf *CodeBuffer) {
-	buf.add(`#import <UIKit/UIKit.h>
-
+	buf.add(`#import <UIKit/UIKit.h>`)
+	if 0 < len(collectWebViews(&screen)) {
+		buf.add(`#import <WebKit/WebKit.h>`)
+	}
+	buf.add(`
 @interface %s%sViewController : UIViewController
 `,
 		mock.Meta.Ios.ClassPrefix,
@@ -433,17 +655,8 @@ func genCodeIosViewControllerHeader(mock *Mock, screen Screen, buf *CodeBuffer)
 		views := []View{}
 		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
 		for _, view := range views {
-			widgetName := "UIView *"
-			switch view.Type {
-			case "button":
-				widgetName = "UIButton *"
-			case "label":
-				widgetName = "UILabel *"
-			case "input":
-				widgetName = "UITextField *"
-			}
-			buf.add(`@property %s%s;`,
-				widgetName,
+			buf.add(`@property %s *%s;`,
+				iosWidgetClass(view),
 				view.Id,
 			)
 		}
@@ -453,6 +666,85 @@ func genCodeIosViewControllerHeader(mock *Mock, screen Screen, buf *CodeBuffer)
 @end`)
 }
 
+func iosWidgetClass(view View) string {
+	switch view.Type {
+	case "button", "checkbox", "picker":
+		return "UIButton"
+	case "label":
+		return "UILabel"
+	case "input":
+		return "UITextField"
+	case "image":
+		return "UIImageView"
+	case "switch":
+		return "UISwitch"
+	case "radio_group":
+		return "UISegmentedControl"
+	case "list":
+		return "UITableView"
+	case "scroll":
+		return "UIScrollView"
+	case "web":
+		return "WKWebView"
+	case "progress":
+		return "UIProgressView"
+	case "spinner_indicator":
+		return "UIActivityIndicatorView"
+	}
+	return "UIView"
+}
+
+// Always a floating point number to be read as Double in Swift
+func iosFloatLiteral(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// Event of the control which is handled by the view controller
+type iosEventDef struct {
+	Trigger string
+	// Prefix of the handler method
+	Handler         string
+	Event           string
+	SwiftEvent      string
+	StoryboardEvent string
+}
+
+// Buttons handle click, the controls which have values handle changed,
+// and pickers handle selected.
+func iosEvent(view View) (e iosEventDef, ok bool) {
+	switch view.Type {
+	case "button":
+		return iosEventDef{"click", "didPush", "UIControlEventTouchUpInside", ".touchUpInside", "touchUpInside"}, true
+	case "switch", "radio_group":
+		return iosEventDef{"changed", "didChange", "UIControlEventValueChanged", ".valueChanged", "valueChanged"}, true
+	case "checkbox":
+		// Check box in the storyboard is toggled by the handler on tap
+		return iosEventDef{"changed", "didChange", "UIControlEventValueChanged", ".valueChanged", "touchUpInside"}, true
+	case "picker":
+		// Picker in the storyboard shows the items by the handler on tap
+		return iosEventDef{"selected", "didSelect", "UIControlEventValueChanged", ".valueChanged", "touchUpInside"}, true
+	}
+	return e, false
+}
+
+// Whether the view controller handles the trigger of the behavior.
+func iosHandlesBehavior(screen *Screen, b Behavior) bool {
+	view := findView(screen, b.Trigger.Widget)
+	if view == nil {
+		return false
+	}
+	if view.Type == "list" {
+		// Table view is not a control, and the selection is notified to the delegate
+		return b.Trigger.Type == "item_click"
+	}
+	e, ok := iosEvent(*view)
+	return ok && e.Trigger == b.Trigger.Type
+}
+
 func genCodeIosAggregateWidgets(current *View, views *[]View) {
 	if current != nil && iwd.Has((*current).Type) {
 		if (*current).Id != "" {
@@ -473,8 +765,7 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 	)
 
 	for _, b := range screen.Behaviors {
-		if b.Trigger.Type != "click" {
-			// Not support other than click currently
+		if !iosHandlesBehavior(&screen, b) {
 			continue
 		}
 
@@ -496,7 +787,7 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 
 	buf.add(`#import "UIView+Extension.h"
 
-@interface %s%sViewController ()
+@interface %s%sViewController ()%s
 
 @end
 
@@ -506,12 +797,18 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 {
     self = [super initWithNibName:nibNameOrNil bundle:nibBundleOrNil];
     if (self) {
-        UIView *root = [[UIView alloc] initWithFrame:CGRectMake(0, 44/*FIXME*/, self.view.frame.size.width, self.view.frame.size.height)];
+        // Root view is placed under the navigation bar
+        UIView *root = [UIView new];
+        root.translatesAutoresizingMaskIntoConstraints = NO;
         [self.view addSubview:root];
+        id topGuide = self.topLayoutGuide;
+        [self.view addConstraints:[NSLayoutConstraint constraintsWithVisualFormat:@"H:|[root]|" options:0 metrics:nil views:NSDictionaryOfVariableBindings(root)]];
+        [self.view addConstraints:[NSLayoutConstraint constraintsWithVisualFormat:@"V:[topGuide][root]|" options:0 metrics:nil views:NSDictionaryOfVariableBindings(topGuide, root)]];
         NSMutableDictionary *views = [NSMutableDictionary new];
         [root createWithViewInfo:[self viewInfo] views:views];`,
 		mock.Meta.Ios.ClassPrefix,
 		strings.Title(screen.Id),
+		iosListProtocols(&screen, false),
 		mock.Meta.Ios.ClassPrefix,
 		strings.Title(screen.Id))
 
@@ -519,29 +816,17 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 	if 0 < len(screen.Layout) {
 		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
 		for _, view := range views {
-			switch view.Type {
-			case "button":
-				buf.add(`
-        if ([views.allKeys containsObject:@"%s"]) {
-            self.%s = (UIButton *) [views objectForKey:@"%s"];
-            [self.%s addTarget:self action:@selector(didPush%s) forControlEvents:UIControlEventTouchUpInside];
-        }`, view.Id, view.Id, view.Id, view.Id, strings.Title(view.Id))
-			case "label":
-				buf.add(`
-        if ([views.allKeys containsObject:@"%s"]) {
-            self.%s = (UILabel *) [views objectForKey:@"%s"];
-        }`, view.Id, view.Id, view.Id)
-			case "input":
-				buf.add(`
-        if ([views.allKeys containsObject:@"%s"]) {
-            self.%s = (UITextField *) [views objectForKey:@"%s"];
-        }`, view.Id, view.Id, view.Id)
-			default:
-				buf.add(`
+			buf.add(`
         if ([views.allKeys containsObject:@"%s"]) {
-            self.%s = (UIView *) [views objectForKey:@"%s"];
-        }`, view.Id, view.Id, view.Id)
+            self.%s = (%s *) [views objectForKey:@"%s"];`, view.Id, view.Id, iosWidgetClass(view), view.Id)
+			if e, ok := iosEvent(view); ok {
+				buf.add(`            [self.%s addTarget:self action:@selector(%s%s) forControlEvents:%s];`, view.Id, e.Handler, strings.Title(view.Id), e.Event)
+			}
+			if view.Type == "list" {
+				buf.add(`            self.%s.dataSource = self;
+            self.%s.delegate = self;`, view.Id, view.Id)
 			}
+			buf.add(`        }`)
 		}
 	}
 
@@ -552,7 +837,8 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 - (void)viewDidLoad
 {
     [super viewDidLoad];
-}`)
+    self.title = @%s;
+}`, quoteString(screen.Name))
 
 	if 0 < len(views) {
 		buf.add(`
@@ -560,16 +846,12 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 	}
 
 	for _, view := range views {
-		if view.Type == "button" {
+		if e, ok := iosEvent(view); ok {
 			buf.add(`
-- (void)didPush%s
-{`, strings.Title(view.Id))
+- (void)%s%s
+{`, e.Handler, strings.Title(view.Id))
 			for _, b := range screen.Behaviors {
-				if b.Trigger.Widget != view.Id {
-					continue
-				}
-				if b.Trigger.Type != "click" {
-					// Not support other than click currently
+				if b.Trigger.Widget != view.Id || b.Trigger.Type != e.Trigger {
 					continue
 				}
 
@@ -589,10 +871,16 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 					)
 				}
 			}
+			for _, s := range iosVisibilityStatements(&screen, view.Id, e.Trigger, false, "") {
+				buf.add("    %s", s)
+			}
 			buf.add(`}`)
 		}
 	}
 
+	genCodeIosListDataSource(mock, screen, false, buf)
+	genCodeIosScrollViews(screen, false, buf)
+
 	buf.add(`
 #pragma mark - Generated layout methods
 
@@ -613,6 +901,121 @@ func genCodeIosViewControllerImplementation(mock *Mock, screen Screen, buf *Code
 @end`)
 }
 
+func genIosUITest(mock *Mock, dir string, screen Screen) {
+	if _, behaviors := findIosUITestBehaviors(mock, screen); len(behaviors) == 0 {
+		return
+	}
+	var buf CodeBuffer
+	genCodeIosUITest(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project+"UITests", mock.Meta.Ios.ClassPrefix+strings.Title(screen.Id)+"UITests.m"))
+}
+
+func genCodeIosUITest(mock *Mock, screen Screen, buf *CodeBuffer) {
+	className := mock.Meta.Ios.ClassPrefix + strings.Title(screen.Id) + "UITests"
+	buf.add(`#import <XCTest/XCTest.h>
+
+@interface %s : XCTestCase
+
+@property (nonatomic) XCUIApplication *app;
+
+@end
+
+@implementation %s
+
+- (void)setUp
+{
+    [super setUp];
+    self.continueAfterFailure = NO;
+    self.app = [XCUIApplication new];
+    [self.app launch];
+}`, className, className)
+
+	path, behaviors := findIosUITestBehaviors(mock, screen)
+	for _, b := range behaviors {
+		next := findScreen(mock, b.Action.Transit)
+		buf.add(`
+- (void)testTap%sShows%s
+{`, strings.Title(b.Trigger.Widget), strings.Title(next.Id))
+		if 0 < len(path) {
+			buf.add(`    // Go to this screen from the launch screen`)
+			for _, p := range path {
+				buf.add(`    [self.app.buttons[@"%s"] tap];`, p.Trigger.Widget)
+			}
+		}
+		// waitForExistenceWithTimeout: needs Xcode 9, so wait with the expectation
+		buf.add(`    [self.app.buttons[@"%s"] tap];
+    [self expectationForPredicate:[NSPredicate predicateWithFormat:@"exists == 1"]
+              evaluatedWithObject:self.app.navigationBars[@%s]
+                          handler:nil];
+    [self waitForExpectationsWithTimeout:5 handler:nil];
+}`, b.Trigger.Widget, quoteString(next.Name))
+	}
+
+	buf.add(`
+@end`)
+}
+
+// Collects the behaviors to be tested on the screen and the path to the screen.
+// Only buttons can be tapped because other widgets don't handle click events.
+// Transits to the same screen are skipped because its navigation bar is already shown
+// and the test could not fail.
+func findIosUITestBehaviors(mock *Mock, screen Screen) (path, behaviors []Behavior) {
+	path, ok := findTransitPath(mock, screen.Id)
+	if !ok {
+		return nil, nil
+	}
+	current := mock.Launch.Screen
+	for _, p := range path {
+		if v := findView(findScreen(mock, current), p.Trigger.Widget); v == nil || v.Type != "button" {
+			return nil, nil
+		}
+		current = p.Action.Transit
+	}
+	for _, b := range findTransitBehaviors(mock, screen) {
+		if b.Action.Transit == screen.Id {
+			continue
+		}
+		if v := findView(&screen, b.Trigger.Widget); v != nil && v.Type == "button" {
+			behaviors = append(behaviors, b)
+		}
+	}
+	return
+}
+
+func genIosUITestsInfoPlist(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeIosUITestsInfoPlist(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project+"UITests", mock.Meta.Ios.Project+"UITests-Info.plist"))
+}
+
+func genCodeIosUITestsInfoPlist(mock *Mock, buf *CodeBuffer) {
+	buf.add(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleDevelopmentRegion</key>
+	<string>en</string>
+	<key>CFBundleExecutable</key>
+	<string>${EXECUTABLE_NAME}</string>
+	<key>CFBundleIdentifier</key>
+	<string>%s.${PRODUCT_NAME:rfc1034identifier}</string>
+	<key>CFBundleInfoDictionaryVersion</key>
+	<string>6.0</string>
+	<key>CFBundleName</key>
+	<string>${PRODUCT_NAME}</string>
+	<key>CFBundlePackageType</key>
+	<string>BNDL</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.0</string>
+	<key>CFBundleSignature</key>
+	<string>????</string>
+	<key>CFBundleVersion</key>
+	<string>1</string>
+</dict>
+</plist>`,
+		mock.Meta.Ios.CompanyIdentifier)
+}
+
 func genIosViewControllerLayout(mock *Mock, dir string, screen Screen) (buf CodeBuffer) {
 	genCodeIosViewControllerLayout(mock, screen, &buf)
 	return
@@ -620,11 +1023,29 @@ func genIosViewControllerLayout(mock *Mock, dir string, screen Screen) (buf Code
 
 func genCodeIosViewControllerLayout(mock *Mock, screen Screen, buf *CodeBuffer) {
 	if 0 < len(screen.Layout) {
-		genIosLayoutRecur(&screen.Layout[0], true, buf, 2, ";")
+		genIosLayoutRecur(mock, &screen.Layout[0], nil, buf, 2, ";", objcLiteral)
 	}
 }
 
-func genIosLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int, trail string) {
+// Literal syntax for the layout information of each language
+type iosLiteral struct {
+	DictOpen  string
+	DictClose string
+	ListOpen  string
+	ListClose string
+	Prefix    string
+	Yes       string
+	No        string
+	// Cast of the dictionary nested in the value
+	DictAs string
+}
+
+var (
+	objcLiteral  = iosLiteral{"@{", "}", "@[", "]", "@", "@YES", "@NO", ""}
+	swiftLiteral = iosLiteral{"[", "]", "[", "] as [[String: Any]]", "", "true", "false", " as [String: Any]"}
+)
+
+func genIosLayoutRecur(mock *Mock, view *View, parent *View, buf *CodeBuffer, indent int, trail string, lit iosLiteral) {
 	if !iwd.Has(view.Type) {
 		return
 	}
@@ -632,81 +1053,163 @@ func genIosLayoutRecur(view *View, top bool, buf *CodeBuffer, indent int, trail
 
 	t := tab(indent)
 	tt := tab(indent + 1)
-	buf.add(t + `@{`)
+	entry := func(key, value string) {
+		buf.add(`%s%s"%s": %s,`, tt, lit.Prefix, key, value)
+	}
+	str := func(value string) string {
+		return lit.Prefix + `"` + value + `"`
+	}
+	buf.add("%s%s", t, lit.DictOpen)
 
-	matchParentW := "@YES"
-	matchParentH := "@YES"
+	matchParentW := lit.Yes
+	matchParentH := lit.Yes
 	base := view.SizeW
 	if base == "" {
 		base = widget.SizeW
 	}
 	if base == SizeFill {
-		matchParentW = "@YES"
+		matchParentW = lit.Yes
 	} else {
-		matchParentW = "@NO"
+		matchParentW = lit.No
 	}
 	base = view.SizeH
 	if base == "" {
 		base = widget.SizeH
 	}
 	if base == SizeFill {
-		matchParentH = "@YES"
+		matchParentH = lit.Yes
 	} else {
-		matchParentH = "@NO"
+		matchParentH = lit.No
 	}
-	buf.add(tt+`@"MatchParentWidth": %s,`, matchParentW)
-	buf.add(tt+`@"MatchParentHeight": %s,`, matchParentH)
+	entry("MatchParentWidth", matchParentW)
+	entry("MatchParentHeight", matchParentH)
 
 	hasSub := 0 < len(view.Sub)
 
-	buf.add(tt+`@"Widget": @"%s",`, widget.Name)
+	entry("Widget", str(widget.Name))
 	if view.Id != "" {
-		buf.add(tt+`@"Id": @"%s",`, view.Id)
+		entry("Id", str(view.Id))
 	}
 	if view.Below != "" {
-		buf.add(tt+`@"Below": @"%s",`, view.Below)
+		entry("Below", str(view.Below))
 	}
 	if widget.Textable && view.Label != "" {
-		buf.add(tt+`@"Text": @"%s",`, view.Label)
+		entry("Text", str(view.Label))
 	}
 	if view.Hint != "" {
-		buf.add(tt+`@"Hint": @"%s",`, view.Hint)
+		entry("Hint", str(view.Hint))
+	}
+	if view.Type == "web" {
+		// Local HTML file is preferred to the URL
+		if view.Html != "" {
+			entry("Html", str(webViewHtmlPath(view.Html)))
+		} else if view.Url != "" {
+			entry("Url", lit.Prefix+quoteString(view.Url))
+		}
+	}
+	if view.Type == "image" {
+		entry("Image", str(imageResourceName(view)))
+		scale := view.Scale
+		if scale == "" {
+			scale = ScaleFit
+		}
+		entry("Scale", str(scale))
+	}
+	if view.Checked {
+		entry("Checked", lit.Yes)
+	}
+	if view.Type == "radio_group" {
+		options := []string{}
+		for _, option := range view.Options {
+			options = append(options, str(option))
+		}
+		entry("Options", lit.ListOpen+strings.Join(options, ", ")+"]")
+		for i, option := range view.Options {
+			if option == view.Selected {
+				entry("Selected", lit.Prefix+strconv.Itoa(i))
+			}
+		}
+	}
+	if view.Type == "picker" {
+		// Items are the keys of the localized strings
+		items := []string{}
+		for i := range LocalizedArray(mock, "base", view.Array) {
+			items = append(items, str(iosArrayItemKey(view.Array, i)))
+		}
+		entry("Items", lit.ListOpen+strings.Join(items, ", ")+"]")
+	}
+	if view.Type == "progress" {
+		entry("Value", lit.Prefix+iosFloatLiteral(view.Value))
+	}
+	if view.Hidden {
+		entry("Hidden", lit.Yes)
+	}
+	if view.Type == "list" {
+		// Rows have the fixed height estimated in the width of the storyboard
+		row := ListRow(view)
+		entry("RowHeight", lit.Prefix+strconv.Itoa(ResolveRow(mock, &row, "base", iosStoryboardWidth).H))
+		buf.add(`%s%s"Row":`, tt, lit.Prefix)
+		genIosLayoutRecur(mock, &row, nil, buf, indent+2, lit.DictAs+",", lit)
+	}
+	if widget.Orientation != "" || view.Type == "scroll" {
+		entry("Orientation", str(viewOrientation(view)))
 	}
-	if widget.Orientation != "" {
-		buf.add(tt+`@"Orientation": @"%s",`, widget.Orientation)
+	if view.Type == "grid" {
+		entry("Columns", lit.Prefix+strconv.Itoa(view.Columns))
+		if 0 < view.Rows {
+			entry("Rows", lit.Prefix+strconv.Itoa(view.Rows))
+		}
 	}
 	if view.Gravity != "" {
-		buf.add(tt+`@"Gravity": @"%s",`, view.Gravity)
+		entry("Gravity", str(view.Gravity))
 	} else if widget.Gravity != "" {
-		buf.add(tt+`@"Gravity": @"%s",`, widget.Gravity)
+		entry("Gravity", str(widget.Gravity))
+	}
+	if parent != nil {
+		if view.AlignH != "" {
+			entry("AlignH", str(view.AlignH))
+		}
+		// Vertical alignment has no effect on views stacked vertically in a linear layout
+		if view.AlignV != "" && (parent.Type != "linear" || viewOrientation(parent) == OrientationHorizontal) {
+			entry("AlignV", str(view.AlignV))
+		}
+		if parent.Type == "grid" {
+			cell := gridCell(parent, view)
+			entry("Row", lit.Prefix+strconv.Itoa(cell.Row))
+			entry("Column", lit.Prefix+strconv.Itoa(cell.Column))
+			entry("Span", lit.Prefix+strconv.Itoa(cell.Span))
+		}
+		if 0 < view.Weight {
+			entry("Weight", lit.Prefix+iosFloatLiteral(view.Weight))
+		}
 	}
 	if view.Margin != "" {
 		if view.Margin == "normal" {
-			buf.add(tt + `@"Margin": @16,`)
+			entry("Margin", lit.Prefix+"16")
 		} else {
-			buf.add(tt+`@"Margin": @%s`, view.Margin)
+			entry("Margin", lit.Prefix+view.Margin)
 		}
 	}
 	if view.Padding != "" {
 		if view.Padding == "normal" {
-			buf.add(tt + `@"Padding": @16,`)
+			entry("Padding", lit.Prefix+"16")
 		} else {
-			buf.add(tt+`@"Padding": @%s`, view.Padding)
+			entry("Padding", lit.Prefix+view.Padding)
 		}
 	}
 	if hasSub {
-		buf.add(tt + `@"Subviews": @[`)
+		buf.add(`%s%s"Subviews": %s`, tt, lit.Prefix, lit.ListOpen)
 		// Print sub views recursively
-		for i, sv := range view.Sub {
+		for i := range view.Sub {
 			subTrail := ""
 			if i < len(view.Sub)-1 {
 				subTrail = ","
 			}
-			genIosLayoutRecur(&sv, false, buf, indent+2, subTrail)
+			genIosLayoutRecur(mock, &view.Sub[i], view, buf, indent+2, subTrail, lit)
 		}
-		buf.add(tt + `]`)
+		buf.add("%s%s", tt, lit.ListClose)
 	}
-	buf.add(t + `}` + trail)
+	buf.add("%s%s%s", t, lit.DictClose, trail)
 }
 
 func genIosViewHelper(mock *Mock, dir string) {
@@ -724,121 +1227,621 @@ func genCodeIosViewHelperHeader(mock *Mock, buf *CodeBuffer) {
 @interface UIView (Extension)
 
 - (void)createWithViewInfo:(NSDictionary *)viewInfo views:(NSMutableDictionary *)views;
+- (UITableViewCell *)cellWithItem:(NSDictionary *)item;
 
 @end
 `)
 }
 
 func genCodeIosViewHelperImplementation(mock *Mock, buf *CodeBuffer) {
+	buf.add(`#import <objc/runtime.h>`)
+	if hasWebViews(mock) {
+		buf.add(`#import <WebKit/WebKit.h>`)
+	}
 	buf.add(`#import "UIView+Extension.h"
 
 @implementation UIView (Extension)
 
+/**
+ * Creates the view described by the view info and adds it to this view.
+ * This view is treated as a relative layout without padding.
+ */
 - (void)createWithViewInfo:(NSDictionary *)viewInfo views:(NSMutableDictionary *)views
 {
-    // Get the max Y from sibling views
-    CGFloat maxY = 0;
-    for (UIView *sibling in self.subviews) {
-        CGFloat y = CGRectGetMaxY(sibling.frame);
-        if (maxY < y) {
-            maxY = y;
-        }
-    }
-
-    CGFloat margin = 0;
-    if ([viewInfo.allKeys containsObject:@"Margin"]) {
-        margin = [[viewInfo objectForKey:@"Margin"] floatValue];
-    }
-    CGFloat padding = 0;
-    // FIXME This is wrong. Padding should be got from parent's viewInfo
-    if ([viewInfo.allKeys containsObject:@"Padding"]) {
-        padding = [[viewInfo objectForKey:@"Padding"] floatValue];
-    }
+    UIView *view = [UIView viewWithViewInfo:viewInfo views:views];
+    [self addSubview:view];
+    [self constrainSubviews:@[view] withViewInfo:@{} subviewInfos:@[viewInfo]];
+}
 
+/**
+ * Creates the view and its subviews recursively.
+ * Views are laid out with Auto Layout,
+ * so they follow the device size and the rotation.
+ */
++ (UIView *)viewWithViewInfo:(NSDictionary *)viewInfo views:(NSMutableDictionary *)views
+{
     NSString *widget = [viewInfo objectForKey:@"Widget"];
+    UIView *view = nil;
+    // Control registered with the ID when it's wrapped in the view
+    UIView *control = nil;
     if ([widget isEqualToString:@"button"]) {
         // UIButton
-        UIButton *button = [[UIButton alloc] initWithFrame:CGRectMake(margin + padding, maxY + margin + padding, self.frame.size.width - (margin + padding) * 2, 100/*FIXME This is temporary */)];
+        UIButton *button = [UIButton new];
         [button setTitle:NSLocalizedString([viewInfo objectForKey:@"Text"], nil) forState:UIControlStateNormal];
         [button setTitleColor:[UIColor blackColor] forState:UIControlStateNormal];
-        // TODO adjust button size with button text
-        if ([viewInfo.allKeys containsObject:@"Id"]) {
-            [views setObject:button forKey:[viewInfo objectForKey:@"Id"]];
-        }
-        [self addSubview:button];
-        return;
-    }
-    if ([widget isEqualToString:@"label"]) {
+        view = button;
+    } else if ([widget isEqualToString:@"label"]) {
         // UILabel
-        UILabel *label = [[UILabel alloc] initWithFrame:CGRectMake(margin + padding, maxY + margin + padding, self.frame.size.width - (margin + padding) * 2, 100/*FIXME This is temporary */)];
+        UILabel *label = [UILabel new];
         label.text = NSLocalizedString([viewInfo objectForKey:@"Text"], nil);
-        // TODO adjust label size with label text
-        [self addSubview:label];
-        if ([viewInfo.allKeys containsObject:@"Id"]) {
-            [views setObject:label forKey:[viewInfo objectForKey:@"Id"]];
+        if ([[viewInfo objectForKey:@"Gravity"] isEqualToString:@"center"]) {
+            label.textAlignment = NSTextAlignmentCenter;
         }
-        return;
-    }
-    if ([widget isEqualToString:@"input"]) {
+        view = label;
+    } else if ([widget isEqualToString:@"input"]) {
         // UITextField
-        UITextField *label = [[UITextField alloc] initWithFrame:CGRectMake(margin + padding, maxY + margin + padding, self.frame.size.width - (margin + padding) * 2, 100/*FIXME This is temporary */)];
+        UITextField *input = [UITextField new];
+        if ([viewInfo.allKeys containsObject:@"Hint"]) {
+            input.placeholder = NSLocalizedString([viewInfo objectForKey:@"Hint"], nil);
+        }
+        view = input;
+    } else if ([widget isEqualToString:@"image"]) {
+        // UIImageView
+        UIImageView *image = [[UIImageView alloc] initWithImage:[UIImage imageNamed:[viewInfo objectForKey:@"Image"]]];
+        NSString *scale = [viewInfo objectForKey:@"Scale"];
+        if ([scale isEqualToString:@"fill"]) {
+            image.contentMode = UIViewContentModeScaleAspectFill;
+            image.clipsToBounds = YES;
+        } else if ([scale isEqualToString:@"stretch"]) {
+            image.contentMode = UIViewContentModeScaleToFill;
+        } else if ([scale isEqualToString:@"center"]) {
+            image.contentMode = UIViewContentModeCenter;
+        } else {
+            image.contentMode = UIViewContentModeScaleAspectFit;
+        }
+        view = image;
+    } else if ([widget isEqualToString:@"switch"]) {
+        // UILabel and UISwitch in a row
+        UILabel *label = [UILabel new];
         label.text = NSLocalizedString([viewInfo objectForKey:@"Text"], nil);
-        // TODO adjust label size with label text
-        [self addSubview:label];
-        if ([viewInfo.allKeys containsObject:@"Id"]) {
-            [views setObject:label forKey:[viewInfo objectForKey:@"Id"]];
+        label.translatesAutoresizingMaskIntoConstraints = NO;
+        UISwitch *toggle = [UISwitch new];
+        toggle.on = [[viewInfo objectForKey:@"Checked"] boolValue];
+        toggle.translatesAutoresizingMaskIntoConstraints = NO;
+        view = [UIView new];
+        [view addSubview:label];
+        [view addSubview:toggle];
+        [view addConstraints:[NSLayoutConstraint constraintsWithVisualFormat:@"H:|[label]-[toggle]|" options:NSLayoutFormatAlignAllCenterY metrics:nil views:NSDictionaryOfVariableBindings(label, toggle)]];
+        [view addConstraints:[NSLayoutConstraint constraintsWithVisualFormat:@"V:|[toggle]|" options:0 metrics:nil views:NSDictionaryOfVariableBindings(toggle)]];
+        control = toggle;
+    } else if ([widget isEqualToString:@"checkbox"]) {
+        // UIButton which toggles the selected state
+        UIButton *checkBox = [UIButton new];
+        NSString *text = NSLocalizedString([viewInfo objectForKey:@"Text"], nil);
+        [checkBox setTitle:[@"\u2610 " stringByAppendingString:text] forState:UIControlStateNormal];
+        [checkBox setTitle:[@"\u2611 " stringByAppendingString:text] forState:UIControlStateSelected];
+        [checkBox setTitleColor:[UIColor blackColor] forState:UIControlStateNormal];
+        checkBox.contentHorizontalAlignment = UIControlContentHorizontalAlignmentLeft;
+        checkBox.selected = [[viewInfo objectForKey:@"Checked"] boolValue];
+        [checkBox addTarget:[UIView class] action:@selector(toggleCheckBox:) forControlEvents:UIControlEventTouchUpInside];
+        view = checkBox;
+    } else if ([widget isEqualToString:@"radio_group"]) {
+        // UISegmentedControl
+        NSMutableArray *items = [NSMutableArray new];
+        for (NSString *option in [viewInfo objectForKey:@"Options"]) {
+            [items addObject:NSLocalizedString(option, nil)];
         }
-        if ([viewInfo.allKeys containsObject:@"Hint"]) {
-            [label setPlaceholder:NSLocalizedString([viewInfo objectForKey:@"Hint"], nil)];
+        UISegmentedControl *segments = [[UISegmentedControl alloc] initWithItems:items];
+        if ([viewInfo.allKeys containsObject:@"Selected"]) {
+            segments.selectedSegmentIndex = [[viewInfo objectForKey:@"Selected"] integerValue];
+        }
+        view = segments;
+    } else if ([widget isEqualToString:@"picker"]) {
+        // UIButton which shows the items in the action sheet
+        NSMutableArray *items = [NSMutableArray new];
+        for (NSString *item in [viewInfo objectForKey:@"Items"]) {
+            [items addObject:NSLocalizedString(item, nil)];
+        }
+        UIButton *picker = [UIButton buttonWithType:UIButtonTypeSystem];
+        [picker setTitle:items.firstObject forState:UIControlStateNormal];
+        picker.contentHorizontalAlignment = UIControlContentHorizontalAlignmentLeft;
+        objc_setAssociatedObject(picker, @selector(showPicker:), items, OBJC_ASSOCIATION_RETAIN_NONATOMIC);
+        [picker addTarget:[UIView class] action:@selector(showPicker:) forControlEvents:UIControlEventTouchUpInside];
+        view = picker;`)
+	if hasWebViews(mock) {
+		buf.add(`    } else if ([widget isEqualToString:@"web"]) {
+        // WKWebView which loads the HTML file in the bundle or the page
+        WKWebView *web = [WKWebView new];
+        if ([viewInfo.allKeys containsObject:@"Html"]) {
+            // Pages can read the CSS, JavaScript and images copied with them
+            NSURL *resourceUrl = [NSBundle mainBundle].resourceURL;
+            NSURL *url = [resourceUrl URLByAppendingPathComponent:[viewInfo objectForKey:@"Html"]];
+            [web loadFileURL:url allowingReadAccessToURL:resourceUrl];
+        } else if ([viewInfo.allKeys containsObject:@"Url"]) {
+            [web loadRequest:[NSURLRequest requestWithURL:[NSURL URLWithString:[viewInfo objectForKey:@"Url"]]]];
+        }
+        view = web;`)
+	}
+	buf.add(`    } else if ([widget isEqualToString:@"progress"]) {
+        // UIProgressView
+        UIProgressView *progress = [[UIProgressView alloc] initWithProgressViewStyle:UIProgressViewStyleDefault];
+        progress.progress = [[viewInfo objectForKey:@"Value"] floatValue];
+        view = progress;
+    } else if ([widget isEqualToString:@"spinner_indicator"]) {
+        // UIActivityIndicatorView which keeps animating while it's shown
+        UIActivityIndicatorView *indicator = [[UIActivityIndicatorView alloc] initWithActivityIndicatorViewStyle:UIActivityIndicatorViewStyleGray];
+        indicator.hidesWhenStopped = NO;
+        [indicator startAnimating];
+        view = indicator;
+    } else if ([widget isEqualToString:@"list"]) {
+        // UITableView which creates the cells with the row info
+        UITableView *table = [UITableView new];
+        table.rowHeight = [[viewInfo objectForKey:@"RowHeight"] floatValue];
+        objc_setAssociatedObject(table, @selector(cellWithItem:), [viewInfo objectForKey:@"Row"], OBJC_ASSOCIATION_RETAIN_NONATOMIC);
+        view = table;
+    } else if ([widget isEqualToString:@"scroll"]) {
+        // UIScrollView whose content size follows the subview
+        UIScrollView *scroll = [UIScrollView new];
+        NSDictionary *contentInfo = [[viewInfo objectForKey:@"Subviews"] firstObject];
+        if (contentInfo) {
+            UIView *content = [UIView viewWithViewInfo:contentInfo views:views];
+            [scroll addSubview:content];
+            [scroll constrainContent:content withViewInfo:viewInfo contentInfo:contentInfo];
+        }
+        scroll.keyboardDismissMode = UIScrollViewKeyboardDismissModeInteractive;
+        view = scroll;
+    } else {
+        // Linear, relative, frame and grid layouts
+        view = [UIView new];
+        NSArray *subviewInfos = [viewInfo objectForKey:@"Subviews"];
+        NSMutableArray *subviews = [NSMutableArray new];
+        for (NSDictionary *subviewInfo in subviewInfos) {
+            UIView *subview = [UIView viewWithViewInfo:subviewInfo views:views];
+            [view addSubview:subview];
+            [subviews addObject:subview];
+        }
+        [view constrainSubviews:subviews withViewInfo:viewInfo subviewInfos:subviewInfos];
+
+        // Shrink to fit the subviews unless the size matches the parent
+        if (![[viewInfo objectForKey:@"MatchParentWidth"] boolValue]) {
+            NSLayoutConstraint *width = [NSLayoutConstraint constraintWithItem:view attribute:NSLayoutAttributeWidth relatedBy:NSLayoutRelationEqual toItem:nil attribute:NSLayoutAttributeNotAnAttribute multiplier:1 constant:0];
+            width.priority = UILayoutPriorityFittingSizeLevel;
+            [view addConstraint:width];
+        }
+        if (![[viewInfo objectForKey:@"MatchParentHeight"] boolValue]) {
+            NSLayoutConstraint *height = [NSLayoutConstraint constraintWithItem:view attribute:NSLayoutAttributeHeight relatedBy:NSLayoutRelationEqual toItem:nil attribute:NSLayoutAttributeNotAnAttribute multiplier:1 constant:0];
+            height.priority = UILayoutPriorityFittingSizeLevel;
+            [view addConstraint:height];
+        }
+    }
+
+    view.translatesAutoresizingMaskIntoConstraints = NO;
+    view.hidden = [[viewInfo objectForKey:@"Hidden"] boolValue];
+    if ([viewInfo.allKeys containsObject:@"Id"]) {
+        if (!control) {
+            control = view;
+        }
+        [views setObject:control forKey:[viewInfo objectForKey:@"Id"]];
+        control.accessibilityIdentifier = [viewInfo objectForKey:@"Id"];
+    }
+    return view;
+}
+
+/**
+ * Creates the cell of the list with the row info of this table view
+ * and sets the texts of the item to the views which have the keys as the IDs.
+ */
+- (UITableViewCell *)cellWithItem:(NSDictionary *)item
+{
+    UITableViewCell *cell = [UITableViewCell new];
+    NSMutableDictionary *views = [NSMutableDictionary new];
+    [cell.contentView createWithViewInfo:objc_getAssociatedObject(self, @selector(cellWithItem:)) views:views];
+    for (NSString *key in item) {
+        UIView *view = [views objectForKey:key];
+        if ([view isKindOfClass:[UILabel class]]) {
+            ((UILabel *) view).text = [item objectForKey:key];
+        } else if ([view isKindOfClass:[UIButton class]]) {
+            [(UIButton *) view setTitle:[item objectForKey:key] forState:UIControlStateNormal];
+        } else if ([view isKindOfClass:[UITextField class]]) {
+            ((UITextField *) view).text = [item objectForKey:key];
+        }
+    }
+    return cell;
+}
+
+/**
+ * Toggles the check box and notifies the change
+ * in the same way as the other controls.
+ */
++ (void)toggleCheckBox:(UIButton *)checkBox
+{
+    checkBox.selected = !checkBox.selected;
+    [checkBox sendActionsForControlEvents:UIControlEventValueChanged];
+}
+
+/**
+ * Shows the items of the picker and notifies the change when one is selected.
+ * Index of the selected item is set to the tag.
+ */
++ (void)showPicker:(UIButton *)picker
+{
+    NSArray *items = objc_getAssociatedObject(picker, @selector(showPicker:));
+    UIAlertController *sheet = [UIAlertController alertControllerWithTitle:nil message:nil preferredStyle:UIAlertControllerStyleActionSheet];
+    for (NSUInteger i = 0; i < items.count; i++) {
+        [sheet addAction:[UIAlertAction actionWithTitle:items[i] style:UIAlertActionStyleDefault handler:^(UIAlertAction *action) {
+            picker.tag = i;
+            [picker setTitle:items[i] forState:UIControlStateNormal];
+            [picker sendActionsForControlEvents:UIControlEventValueChanged];
+        }]];
+    }
+    [sheet addAction:[UIAlertAction actionWithTitle:NSLocalizedString(@"Cancel", nil) style:UIAlertActionStyleCancel handler:nil]];
+    sheet.popoverPresentationController.sourceView = picker;
+    sheet.popoverPresentationController.sourceRect = picker.bounds;
+    [picker.window.rootViewController presentViewController:sheet animated:YES completion:nil];
+}
+
+/**
+ * Adds constraints to lay out the subviews inside this view.
+ * Linear layout stacks the subviews vertically or horizontally,
+ * and relative layout places them with Below, AlignH and AlignV.
+ */
+- (void)constrainSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos
+{
+    CGFloat padding = [[viewInfo objectForKey:@"Padding"] floatValue];
+    NSString *gravity = [viewInfo objectForKey:@"Gravity"];
+    BOOL centerV = [gravity isEqualToString:@"center"] || [gravity isEqualToString:@"center_v"];
+    BOOL linear = [viewInfo.allKeys containsObject:@"Orientation"];
+    if ([[viewInfo objectForKey:@"Orientation"] isEqualToString:@"horizontal"]) {
+        [self constrainRowSubviews:subviews withViewInfo:viewInfo subviewInfos:subviewInfos];
+        return;
+    }
+    if ([viewInfo.allKeys containsObject:@"Columns"]) {
+        [self constrainGridSubviews:subviews withViewInfo:viewInfo subviewInfos:subviewInfos];
+        return;
+    }
+
+    NSMutableDictionary *siblings = [NSMutableDictionary new];
+    NSMutableDictionary *siblingMargins = [NSMutableDictionary new];
+    UIView *previous = nil;
+    CGFloat previousMargin = 0;
+    UIView *firstFill = nil;
+    CGFloat firstWeight = 0;
+    for (NSUInteger i = 0; i < subviews.count; i++) {
+        UIView *view = subviews[i];
+        NSDictionary *info = subviewInfos[i];
+        CGFloat margin = [[info objectForKey:@"Margin"] floatValue];
+        CGFloat inset = padding + margin;
+        BOOL matchParentHeight = [[info objectForKey:@"MatchParentHeight"] boolValue];
+
+        [self constrainSubviewHorizontally:view withViewInfo:info gravity:gravity inset:inset];
+
+        if (linear) {
+            // Stack vertically
+            if (previous) {
+                [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:previous attribute:NSLayoutAttributeBottom constant:previousMargin + margin];
+            } else if (!centerV) {
+                [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+            }
+            CGFloat weight = [UIView weightWithViewInfo:info matchParent:matchParentHeight];
+            if (0 < weight) {
+                // Views which have the weights share the rest of the height
+                if (firstFill) {
+                    [self constrainItem:view attribute:NSLayoutAttributeHeight toItem:firstFill multiplier:weight / firstWeight];
+                } else {
+                    firstFill = view;
+                    firstWeight = weight;
+                }
+            }
+            previous = view;
+            previousMargin = margin;
+            continue;
+        }
+
+        // Place relatively
+        UIView *anchor = nil;
+        if ([info.allKeys containsObject:@"Below"]) {
+            anchor = [siblings objectForKey:[info objectForKey:@"Below"]];
         }
+        NSString *alignV = [info objectForKey:@"AlignV"];
+        if (!alignV && centerV) {
+            alignV = @"center";
+        }
+        if (anchor) {
+            CGFloat anchorMargin = [[siblingMargins objectForKey:[info objectForKey:@"Below"]] floatValue];
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:anchor attribute:NSLayoutAttributeBottom constant:anchorMargin + margin];
+        } else if (!matchParentHeight && [alignV isEqualToString:@"bottom"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+        } else if (!matchParentHeight && [alignV isEqualToString:@"center"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeCenterY relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeCenterY constant:0];
+        } else {
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+        }
+        if (matchParentHeight) {
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+        } else {
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationLessThanOrEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+        }
+        if ([info.allKeys containsObject:@"Id"]) {
+            [siblings setObject:view forKey:[info objectForKey:@"Id"]];
+            [siblingMargins setObject:@(margin) forKey:[info objectForKey:@"Id"]];
+        }
+    }
+
+    if (!linear || !previous) {
         return;
     }
+    if (firstFill || !centerV) {
+        [self constrainItem:previous attribute:NSLayoutAttributeBottom relatedBy:(firstFill ? NSLayoutRelationEqual : NSLayoutRelationLessThanOrEqual) toItem:self attribute:NSLayoutAttributeBottom constant:-(padding + previousMargin)];
+        return;
+    }
+
+    // Center the stacked views with the spacers which have the same height
+    UIView *top = [UIView new];
+    UIView *bottom = [UIView new];
+    for (UIView *spacer in @[top, bottom]) {
+        spacer.translatesAutoresizingMaskIntoConstraints = NO;
+        spacer.hidden = YES;
+        [self addSubview:spacer];
+        [self constrainItem:spacer attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:0];
+        [self constrainItem:spacer attribute:NSLayoutAttributeWidth relatedBy:NSLayoutRelationEqual toItem:nil attribute:NSLayoutAttributeNotAnAttribute constant:0];
+    }
+    CGFloat firstMargin = [[subviewInfos.firstObject objectForKey:@"Margin"] floatValue];
+    [self constrainItem:top attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:padding];
+    [self constrainItem:subviews.firstObject attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:top attribute:NSLayoutAttributeBottom constant:firstMargin];
+    [self constrainItem:bottom attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:previous attribute:NSLayoutAttributeBottom constant:previousMargin];
+    [self constrainItem:bottom attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-padding];
+    [self constrainItem:top attribute:NSLayoutAttributeHeight relatedBy:NSLayoutRelationEqual toItem:bottom attribute:NSLayoutAttributeHeight constant:0];
+}
+
+/**
+ * Adds constraints to stack the subviews horizontally inside this view.
+ * Views are placed vertically with AlignV.
+ */
+- (void)constrainRowSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos
+{
+    CGFloat padding = [[viewInfo objectForKey:@"Padding"] floatValue];
+    NSString *gravity = [viewInfo objectForKey:@"Gravity"];
+    BOOL centerV = [gravity isEqualToString:@"center"] || [gravity isEqualToString:@"center_v"];
+
+    UIView *previous = nil;
+    CGFloat previousMargin = 0;
+    UIView *firstFill = nil;
+    CGFloat firstWeight = 0;
+    for (NSUInteger i = 0; i < subviews.count; i++) {
+        UIView *view = subviews[i];
+        NSDictionary *info = subviewInfos[i];
+        CGFloat margin = [[info objectForKey:@"Margin"] floatValue];
+        CGFloat inset = padding + margin;
+
+        // Place vertically
+        NSString *alignV = [info objectForKey:@"AlignV"];
+        if (!alignV && centerV) {
+            alignV = @"center";
+        }
+        if ([[info objectForKey:@"MatchParentHeight"] boolValue]) {
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+        } else {
+            if ([alignV isEqualToString:@"bottom"]) {
+                [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+            } else if ([alignV isEqualToString:@"center"]) {
+                [self constrainItem:view attribute:NSLayoutAttributeCenterY relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeCenterY constant:0];
+            } else {
+                [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+            }
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationLessThanOrEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+        }
 
-    // Determine size with layout_width/layout_height/padding/margin
-    CGFloat width = 0;
-    BOOL matchParentWidth = YES;
-    if ([viewInfo.allKeys containsObject:@"MatchParentWidth"]) {
-        matchParentWidth = [[viewInfo objectForKey:@"MatchParentWidth"] boolValue];
+        // Stack horizontally
+        if (previous) {
+            [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:previous attribute:NSLayoutAttributeTrailing constant:previousMargin + margin];
+        } else {
+            [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:inset];
+        }
+        CGFloat weight = [UIView weightWithViewInfo:info matchParent:[[info objectForKey:@"MatchParentWidth"] boolValue]];
+        if (0 < weight) {
+            // Views which have the weights share the rest of the width
+            if (firstFill) {
+                [self constrainItem:view attribute:NSLayoutAttributeWidth toItem:firstFill multiplier:weight / firstWeight];
+            } else {
+                firstFill = view;
+                firstWeight = weight;
+            }
+        }
+        previous = view;
+        previousMargin = margin;
     }
-    // FIXME Currently, wrap_content is ignored
-    //if (matchParentWidth) {
-    width = self.frame.size.width - margin * 2 - padding * 2;
-    //} else {
-    // Wrap content
-    // TODO Check gravity. Temporarily, it's left
-    //}
-
-    CGFloat height = 0;
-    BOOL matchParentHeight = YES;
-    if ([viewInfo.allKeys containsObject:@"MatchParentHeight"]) {
-        matchParentHeight = [[viewInfo objectForKey:@"MatchParentHeight"] boolValue];
+    if (previous) {
+        [self constrainItem:previous attribute:NSLayoutAttributeTrailing relatedBy:(firstFill ? NSLayoutRelationEqual : NSLayoutRelationLessThanOrEqual) toItem:self attribute:NSLayoutAttributeTrailing constant:-(padding + previousMargin)];
     }
-    // FIXME Currently, wrap_content is ignored
-    //if (matchParentHeight) {
-    height = self.frame.size.height - margin * 2 - padding * 2;
-    //} else {
-    // Wrap content
-    // TODO Check gravity. Temporarily, it's left
-    //}
-
-    // if ([widget isEqualToString:@"linear"] || [widget isEqualToString:@"relative"]) {
-    // LinearLayout and RelativeLayout
-    // TODO Separate each layout with each appropriate algorithms
-    UIView *view = [[UIView alloc] initWithFrame:CGRectMake(0, 0, width, height)];
-    if ([viewInfo.allKeys containsObject:@"Id"]) {
-        [views setObject:view forKey:[viewInfo objectForKey:@"Id"]];
+}
+
+/**
+ * Adds constraints to place the subviews in the cells of the grid.
+ * Cells are the hidden views which divide the width by the columns,
+ * and the rows are as tall as the tallest subviews unless the number of the rows is specified.
+ */
+- (void)constrainGridSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos
+{
+    CGFloat padding = [[viewInfo objectForKey:@"Padding"] floatValue];
+    NSString *gravity = [viewInfo objectForKey:@"Gravity"];
+    CGFloat columns = [[viewInfo objectForKey:@"Columns"] floatValue];
+    CGFloat rows = [[viewInfo objectForKey:@"Rows"] floatValue];
+
+    // First cells of the rows
+    NSMutableArray *rowCells = [NSMutableArray new];
+    for (NSUInteger i = 0; i < subviews.count; i++) {
+        NSDictionary *info = subviewInfos[i];
+        NSUInteger row = [[info objectForKey:@"Row"] unsignedIntegerValue];
+        CGFloat column = [[info objectForKey:@"Column"] floatValue];
+        CGFloat span = [[info objectForKey:@"Span"] floatValue];
+
+        UIView *cell = [UIView new];
+        cell.translatesAutoresizingMaskIntoConstraints = NO;
+        cell.hidden = YES;
+        [self addSubview:cell];
+
+        // Divide the width by the columns
+        if (column == 0) {
+            [self constrainItem:cell attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:padding];
+        } else {
+            [self addConstraint:[NSLayoutConstraint constraintWithItem:cell attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTrailing multiplier:column / columns constant:padding * (1 - 2 * column / columns)]];
+        }
+        [self addConstraint:[NSLayoutConstraint constraintWithItem:cell attribute:NSLayoutAttributeWidth relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeWidth multiplier:span / columns constant:-2 * padding * span / columns]];
+
+        // Stack the rows
+        if (row < rowCells.count) {
+            [self constrainItem:cell attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:rowCells[row] attribute:NSLayoutAttributeTop constant:0];
+            [self constrainItem:cell attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:rowCells[row] attribute:NSLayoutAttributeBottom constant:0];
+        } else {
+            UIView *previous = rowCells.lastObject;
+            if (previous) {
+                [self constrainItem:cell attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:previous attribute:NSLayoutAttributeBottom constant:0];
+            } else {
+                [self constrainItem:cell attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:padding];
+            }
+            if (0 < rows) {
+                [self addConstraint:[NSLayoutConstraint constraintWithItem:cell attribute:NSLayoutAttributeHeight relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeHeight multiplier:1 / rows constant:-2 * padding / rows]];
+            } else {
+                // Shrink to fit the tallest subview
+                NSLayoutConstraint *height = [NSLayoutConstraint constraintWithItem:cell attribute:NSLayoutAttributeHeight relatedBy:NSLayoutRelationEqual toItem:nil attribute:NSLayoutAttributeNotAnAttribute multiplier:1 constant:0];
+                height.priority = UILayoutPriorityFittingSizeLevel;
+                [self addConstraint:height];
+            }
+            [rowCells addObject:cell];
+        }
+
+        [self constrainSubview:subviews[i] inCell:cell withViewInfo:info gravity:gravity];
+    }
+    if (rows == 0 && rowCells.lastObject) {
+        [self constrainItem:rowCells.lastObject attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationLessThanOrEqual toItem:self attribute:NSLayoutAttributeBottom constant:-padding];
+    }
+}
+
+/**
+ * Adds constraints to place the subview in the cell with AlignH and AlignV.
+ */
+- (void)constrainSubview:(UIView *)view inCell:(UIView *)cell withViewInfo:(NSDictionary *)viewInfo gravity:(NSString *)gravity
+{
+    CGFloat margin = [[viewInfo objectForKey:@"Margin"] floatValue];
+    NSString *alignH = [viewInfo objectForKey:@"AlignH"];
+    if (!alignH && [gravity isEqualToString:@"center"]) {
+        alignH = @"center";
+    }
+    NSString *alignV = [viewInfo objectForKey:@"AlignV"];
+    if (!alignV && ([gravity isEqualToString:@"center"] || [gravity isEqualToString:@"center_v"])) {
+        alignV = @"center";
     }
 
-    // Process subviews
-    if ([viewInfo.allKeys containsObject:@"Subviews"]) {
-        // TODO Issue: cannot determine subview size with parent size because it's not also determined yet.
-        for (NSDictionary *subviewInfo in [viewInfo objectForKey:@"Subviews"]) {
-            [view createWithViewInfo:subviewInfo views:views];
+    if ([[viewInfo objectForKey:@"MatchParentWidth"] boolValue]) {
+        [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeLeading constant:margin];
+        [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeTrailing constant:-margin];
+    } else {
+        if ([alignH isEqualToString:@"right"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeTrailing constant:-margin];
+        } else if ([alignH isEqualToString:@"center"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeCenterX relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeCenterX constant:0];
+        } else {
+            [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeLeading constant:margin];
         }
+        [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:cell attribute:NSLayoutAttributeLeading constant:margin];
+        [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationLessThanOrEqual toItem:cell attribute:NSLayoutAttributeTrailing constant:-margin];
     }
 
-    // Add myself to parent finally
-    [self addSubview:view];
+    if ([[viewInfo objectForKey:@"MatchParentHeight"] boolValue]) {
+        [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeTop constant:margin];
+        [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeBottom constant:-margin];
+    } else {
+        if ([alignV isEqualToString:@"bottom"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeBottom constant:-margin];
+        } else if ([alignV isEqualToString:@"center"]) {
+            [self constrainItem:view attribute:NSLayoutAttributeCenterY relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeCenterY constant:0];
+        } else {
+            [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:cell attribute:NSLayoutAttributeTop constant:margin];
+        }
+        [self constrainItem:view attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:cell attribute:NSLayoutAttributeTop constant:margin];
+        [self constrainItem:view attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationLessThanOrEqual toItem:cell attribute:NSLayoutAttributeBottom constant:-margin];
+    }
+}
+
+/**
+ * Weight of the view in the linear layout.
+ * Views which match the parent without the weight share the rest equally.
+ */
++ (CGFloat)weightWithViewInfo:(NSDictionary *)viewInfo matchParent:(BOOL)matchParent
+{
+    if ([viewInfo.allKeys containsObject:@"Weight"]) {
+        return [[viewInfo objectForKey:@"Weight"] floatValue];
+    }
+    return matchParent ? 1 : 0;
+}
+
+/**
+ * Adds constraints to place the content inside this scroll view.
+ * Edges of the content determine the content size,
+ * and the content is as large as this view across the scrolling direction.
+ */
+- (void)constrainContent:(UIView *)content withViewInfo:(NSDictionary *)viewInfo contentInfo:(NSDictionary *)contentInfo
+{
+    CGFloat inset = [[viewInfo objectForKey:@"Padding"] floatValue] + [[contentInfo objectForKey:@"Margin"] floatValue];
+    BOOL horizontal = [[viewInfo objectForKey:@"Orientation"] isEqualToString:@"horizontal"];
+    NSLayoutAttribute across = horizontal ? NSLayoutAttributeHeight : NSLayoutAttributeWidth;
+    NSLayoutAttribute along = horizontal ? NSLayoutAttributeWidth : NSLayoutAttributeHeight;
+    BOOL matchAcross = [[contentInfo objectForKey:(horizontal ? @"MatchParentHeight" : @"MatchParentWidth")] boolValue];
+    BOOL matchAlong = [[contentInfo objectForKey:(horizontal ? @"MatchParentWidth" : @"MatchParentHeight")] boolValue];
+
+    [self constrainItem:content attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:inset];
+    [self constrainItem:content attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTrailing constant:-inset];
+    [self constrainItem:content attribute:NSLayoutAttributeTop relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTop constant:inset];
+    [self constrainItem:content attribute:NSLayoutAttributeBottom relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeBottom constant:-inset];
+    [self constrainItem:content attribute:across relatedBy:(matchAcross ? NSLayoutRelationEqual : NSLayoutRelationLessThanOrEqual) toItem:self attribute:across constant:-inset * 2];
+    if (matchAlong) {
+        [self constrainItem:content attribute:along relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:self attribute:along constant:-inset * 2];
+    }
+
+    // Shrink to fit the subviews in the scrolling direction
+    NSLayoutConstraint *size = [NSLayoutConstraint constraintWithItem:content attribute:along relatedBy:NSLayoutRelationEqual toItem:nil attribute:NSLayoutAttributeNotAnAttribute multiplier:1 constant:0];
+    size.priority = UILayoutPriorityFittingSizeLevel;
+    [self addConstraint:size];
+}
+
+/**
+ * Adds constraints to place the subview horizontally with AlignH.
+ * Views which don't match the parent width keep their intrinsic width.
+ */
+- (void)constrainSubviewHorizontally:(UIView *)view withViewInfo:(NSDictionary *)viewInfo gravity:(NSString *)gravity inset:(CGFloat)inset
+{
+    if ([[viewInfo objectForKey:@"MatchParentWidth"] boolValue]) {
+        [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:inset];
+        [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTrailing constant:-inset];
+        return;
+    }
+
+    NSString *alignH = [viewInfo objectForKey:@"AlignH"];
+    if (!alignH && [gravity isEqualToString:@"center"]) {
+        alignH = @"center";
+    }
+    if ([alignH isEqualToString:@"right"]) {
+        [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeTrailing constant:-inset];
+    } else if ([alignH isEqualToString:@"center"]) {
+        [self constrainItem:view attribute:NSLayoutAttributeCenterX relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeCenterX constant:0];
+    } else {
+        [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationEqual toItem:self attribute:NSLayoutAttributeLeading constant:inset];
+    }
+    [self constrainItem:view attribute:NSLayoutAttributeLeading relatedBy:NSLayoutRelationGreaterThanOrEqual toItem:self attribute:NSLayoutAttributeLeading constant:inset];
+    [self constrainItem:view attribute:NSLayoutAttributeTrailing relatedBy:NSLayoutRelationLessThanOrEqual toItem:self attribute:NSLayoutAttributeTrailing constant:-inset];
+}
+
+- (void)constrainItem:(id)item attribute:(NSLayoutAttribute)attribute relatedBy:(NSLayoutRelation)relation toItem:(id)toItem attribute:(NSLayoutAttribute)toAttribute constant:(CGFloat)constant
+{
+    [self addConstraint:[NSLayoutConstraint constraintWithItem:item attribute:attribute relatedBy:relation toItem:toItem attribute:toAttribute multiplier:1 constant:constant]];
+}
+
+- (void)constrainItem:(id)item attribute:(NSLayoutAttribute)attribute toItem:(id)toItem multiplier:(CGFloat)multiplier
+{
+    [self addConstraint:[NSLayoutConstraint constraintWithItem:item attribute:attribute relatedBy:NSLayoutRelationEqual toItem:toItem attribute:attribute multiplier:multiplier constant:0]];
 }
 
 @end`)
@@ -861,6 +1864,17 @@ func genCodeIosLocalizableStrings(s String, buf *CodeBuffer) {
 	for _, def := range s.Defs {
 		buf.add(`"%s" = "%s";`, def.Id, def.Value)
 	}
+	// Arrays are flattened since strings files have no arrays
+	for _, a := range s.Arrays {
+		for i, item := range a.Items {
+			buf.add(`"%s" = "%s";`, iosArrayItemKey(a.Id, i), item)
+		}
+	}
+}
+
+// Key of the item of the array in the strings files
+func iosArrayItemKey(id string, i int) string {
+	return id + "." + strconv.Itoa(i)
 }
 
 func genIosColors(mock *Mock, dir string) {
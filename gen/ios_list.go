@@ -0,0 +1,250 @@
+package gen
+
+import (
+	"sort"
+	"strings"
+)
+
+// View controllers show the sample items of the lists as the data sources,
+// and handle item_click as the delegates of the table views.
+func iosListViews(screen *Screen) (views []View) {
+	for _, view := range collectListViews(screen) {
+		if view.Id != "" {
+			views = append(views, *view)
+		}
+	}
+	return
+}
+
+func genCodeIosListDataSource(mock *Mock, screen Screen, storyboard bool, buf *CodeBuffer) {
+	lists := iosListViews(&screen)
+	if len(lists) == 0 {
+		return
+	}
+	buf.add(`
+#pragma mark - Table view data source
+
+- (NSInteger)tableView:(UITableView *)tableView numberOfRowsInSection:(NSInteger)section
+{
+    return [self itemsForTableView:tableView].count;
+}
+
+- (UITableViewCell *)tableView:(UITableView *)tableView cellForRowAtIndexPath:(NSIndexPath *)indexPath
+{
+    NSDictionary *item = [self itemsForTableView:tableView][indexPath.row];`)
+	if storyboard {
+		buf.add(`    // Prototype cell is registered with the ID of the list
+    UITableViewCell *cell = [tableView dequeueReusableCellWithIdentifier:tableView.accessibilityIdentifier forIndexPath:indexPath];
+    for (NSString *key in item) {
+        UIView *view = [self findViewWithIdentifier:key inView:cell.contentView];
+        if ([view isKindOfClass:[UILabel class]]) {
+            ((UILabel *) view).text = [item objectForKey:key];
+        } else if ([view isKindOfClass:[UIButton class]]) {
+            [(UIButton *) view setTitle:[item objectForKey:key] forState:UIControlStateNormal];
+        } else if ([view isKindOfClass:[UITextField class]]) {
+            ((UITextField *) view).text = [item objectForKey:key];
+        }
+    }
+    return cell;`)
+	} else {
+		buf.add(`    return [tableView cellWithItem:item];`)
+	}
+	buf.add(`}
+
+#pragma mark - Table view delegate
+
+- (void)tableView:(UITableView *)tableView didSelectRowAtIndexPath:(NSIndexPath *)indexPath
+{
+    [tableView deselectRowAtIndexPath:indexPath animated:YES];`)
+	for _, view := range lists {
+		screenIds := iosListTransits(mock, screen, view)
+		statements := iosVisibilityStatements(&screen, view.Id, "item_click", false, "")
+		if len(screenIds) == 0 && len(statements) == 0 {
+			continue
+		}
+		buf.add(`    if (tableView == self.%s) {`, view.Id)
+		for _, id := range screenIds {
+			if storyboard {
+				buf.add(`        UIViewController *vc = [self.storyboard instantiateViewControllerWithIdentifier:@"%s"];`, id)
+			} else {
+				buf.add(`        %s%sViewController *vc = [%s%sViewController new];`,
+					mock.Meta.Ios.ClassPrefix, strings.Title(id), mock.Meta.Ios.ClassPrefix, strings.Title(id))
+			}
+			buf.add(`        [self.navigationController pushViewController:vc animated:YES];`)
+		}
+		for _, s := range statements {
+			buf.add("        %s", s)
+		}
+		buf.add(`    }`)
+	}
+	buf.add(`}
+
+/**
+ * Sample items of the lists.
+ */
+- (NSArray *)itemsForTableView:(UITableView *)tableView
+{`)
+	for _, view := range lists {
+		buf.add(`    if (tableView == self.%s) {
+        return @[`, view.Id)
+		for _, item := range view.Items {
+			entries := []string{}
+			for _, key := range sortedKeys(item) {
+				entries = append(entries, "@"+quoteString(key)+": @"+quoteString(item[key]))
+			}
+			buf.add(`            @{%s},`, strings.Join(entries, ", "))
+		}
+		buf.add(`        ];
+    }`)
+	}
+	buf.add(`    return @[];
+}`)
+	if storyboard {
+		buf.add(`
+/**
+ * Finds the view in the cell by the accessibility identifier.
+ */
+- (UIView *)findViewWithIdentifier:(NSString *)identifier inView:(UIView *)view
+{
+    if ([view.accessibilityIdentifier isEqualToString:identifier]) {
+        return view;
+    }
+    for (UIView *subview in view.subviews) {
+        UIView *found = [self findViewWithIdentifier:identifier inView:subview];
+        if (found) {
+            return found;
+        }
+    }
+    return nil;
+}`)
+	}
+}
+
+func genCodeIosSwiftListDataSource(mock *Mock, screen Screen, storyboard bool, buf *CodeBuffer) {
+	lists := iosListViews(&screen)
+	if len(lists) == 0 {
+		return
+	}
+	buf.add(`
+    // MARK: - Table view data source
+
+    func tableView(_ tableView: UITableView, numberOfRowsInSection section: Int) -> Int {
+        return items(for: tableView).count
+    }
+
+    func tableView(_ tableView: UITableView, cellForRowAt indexPath: IndexPath) -> UITableViewCell {
+        let item = items(for: tableView)[indexPath.row]`)
+	if storyboard {
+		buf.add(`        // Prototype cell is registered with the ID of the list
+        let cell = tableView.dequeueReusableCell(withIdentifier: tableView.accessibilityIdentifier ?? "", for: indexPath)
+        for (key, text) in item {
+            switch findView(withIdentifier: key, in: cell.contentView) {
+            case let label as UILabel:
+                label.text = text
+            case let button as UIButton:
+                button.setTitle(text, for: .normal)
+            case let field as UITextField:
+                field.text = text
+            default:
+                break
+            }
+        }
+        return cell`)
+	} else {
+		buf.add(`        return tableView.cell(item: item)`)
+	}
+	buf.add(`    }
+
+    // MARK: - Table view delegate
+
+    func tableView(_ tableView: UITableView, didSelectRowAt indexPath: IndexPath) {
+        tableView.deselectRow(at: indexPath, animated: true)`)
+	for _, view := range lists {
+		screenIds := iosListTransits(mock, screen, view)
+		statements := iosVisibilityStatements(&screen, view.Id, "item_click", true, "")
+		if len(screenIds) == 0 && len(statements) == 0 {
+			continue
+		}
+		buf.add(`        if tableView == %s {`, swiftPropertyName(view))
+		for _, id := range screenIds {
+			if storyboard {
+				buf.add(`            if let vc = storyboard?.instantiateViewController(withIdentifier: "%s") {
+                navigationController?.pushViewController(vc, animated: true)
+            }`, id)
+			} else {
+				buf.add(`            navigationController?.pushViewController(%s%sViewController(), animated: true)`,
+					mock.Meta.Ios.ClassPrefix, strings.Title(id))
+			}
+		}
+		for _, s := range statements {
+			buf.add("            %s", s)
+		}
+		buf.add(`        }`)
+	}
+	buf.add(`    }
+
+    /// Sample items of the lists.
+    func items(for tableView: UITableView) -> [[String: String]] {`)
+	for _, view := range lists {
+		buf.add(`        if tableView == %s {
+            return [`, swiftPropertyName(view))
+		for _, item := range view.Items {
+			entries := []string{}
+			for _, key := range sortedKeys(item) {
+				entries = append(entries, quoteString(key)+": "+quoteString(item[key]))
+			}
+			if len(entries) == 0 {
+				entries = append(entries, ":")
+			}
+			buf.add(`                [%s],`, strings.Join(entries, ", "))
+		}
+		buf.add(`            ]
+        }`)
+	}
+	buf.add(`        return []
+    }`)
+	if storyboard {
+		buf.add(`
+    /// Finds the view in the cell by the accessibility identifier.
+    func findView(withIdentifier identifier: String, in view: UIView) -> UIView? {
+        if view.accessibilityIdentifier == identifier {
+            return view
+        }
+        for subview in view.subviews {
+            if let found = findView(withIdentifier: identifier, in: subview) {
+                return found
+            }
+        }
+        return nil
+    }`)
+	}
+}
+
+// Conformance of the view controller to handle the lists
+func iosListProtocols(screen *Screen, swift bool) string {
+	if len(iosListViews(screen)) == 0 {
+		return ""
+	}
+	if swift {
+		return ", UITableViewDataSource, UITableViewDelegate"
+	}
+	return " <UITableViewDataSource, UITableViewDelegate>"
+}
+
+// Finds the screens to transit when the item of the list is clicked.
+func iosListTransits(mock *Mock, screen Screen, view View) (screenIds []string) {
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Widget == view.Id && b.Trigger.Type == "item_click" && b.Action.Type == "transit_forward" && findScreen(mock, b.Action.Transit) != nil {
+			screenIds = append(screenIds, b.Action.Transit)
+		}
+	}
+	return
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
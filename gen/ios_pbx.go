@@ -7,9 +7,10 @@ import (
 )
 
 func genIosProjectPbxproj(mock *Mock, dir string) {
-	var buf CodeBuffer
-	genCodeIosProjectPbxproj(mock, &buf)
+	var buf, schemeBuf CodeBuffer
+	genCodeIosProjectPbxproj(mock, &buf, &schemeBuf)
 	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project+".xcodeproj", "project.pbxproj"))
+	genFile(&schemeBuf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project+".xcodeproj", "xcshareddata", "xcschemes", mock.Meta.Ios.Project+".xcscheme"))
 }
 
 type pbxObject struct {
@@ -30,11 +31,33 @@ type pbxObject struct {
 	Children               []pbxObject
 	ProductReference       string
 	BuildSettings          string
+	ProductType            string
+	Dependencies           []pbxObject
+	RemoteGlobalIdString   string
+	TargetProxy            string
 }
 
-func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
+func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer, schemeBuf *CodeBuffer) {
 	cp := mock.Meta.Ios.ClassPrefix
 	pj := mock.Meta.Ios.Project
+	swift := mock.Meta.Ios.Language == IosLanguageSwift
+	// Objective-C sources have headers, Swift sources don't
+	srcExt, srcType := ".m", "sourcecode.c.objc"
+	if swift {
+		srcExt, srcType = ".swift", "sourcecode.swift"
+	}
+	storyboard := mock.Meta.Ios.Layout == IosLayoutStoryboard
+	// WebKit is only linked when the web views are used
+	frameworks := []string{"Foundation.framework", "CoreGraphics.framework", "UIKit.framework"}
+	if hasWebViews(mock) {
+		frameworks = append(frameworks, "WebKit.framework")
+	}
+	webAssets := collectWebViewAssets(mock)
+	// Storyboard doesn't need the layout helper
+	extensions := []string{"UIView+Extension", "UIColor+Extension"}
+	if storyboard {
+		extensions = []string{"UIColor+Extension"}
+	}
 	fileId := 0xDE5E0B8D
 	pbxBuildFiles := map[string]pbxObject{}
 	pbxFileReferences := map[string]pbxObject{}
@@ -48,6 +71,15 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 	xcProjectBuildConfigurations := map[string]pbxObject{}
 	xcNativeTargetBuildConfigurations := map[string]pbxObject{}
 	xcConfigurationLists := map[string]pbxObject{}
+	xcTestTargetBuildConfigurations := map[string]pbxObject{}
+	pbxContainerItemProxies := map[string]pbxObject{}
+	pbxTargetDependencies := map[string]pbxObject{}
+	testScreens := []Screen{}
+	for _, screen := range mock.Screens {
+		if _, behaviors := findIosUITestBehaviors(mock, screen); 0 < len(behaviors) {
+			testScreens = append(testScreens, screen)
+		}
+	}
 	// PBXFileReference
 	pbxFileReferences[pj+".app"] = pbxObject{
 		Name:             pj + ".app",
@@ -57,42 +89,30 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		Path:             pj + ".app",
 		SourceTree:       "BUILT_PRODUCTS_DIR",
 	}
-	pbxFileReferences["Foundation.framework"] = pbxObject{
-		Name:              "Foundation.framework",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "wrapper.framework",
-		ShowNameInFileRef: true,
-		Path:              "System/Library/Frameworks/Foundation.framework",
-		SourceTree:        "SDKROOT",
-	}
-	pbxFileReferences["CoreGraphics.framework"] = pbxObject{
-		Name:              "CoreGraphics.framework",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "wrapper.framework",
-		ShowNameInFileRef: true,
-		Path:              "System/Library/Frameworks/CoreGraphics.framework",
-		SourceTree:        "SDKROOT",
-	}
-	pbxFileReferences["UIKit.framework"] = pbxObject{
-		Name:              "UIKit.framework",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "wrapper.framework",
-		ShowNameInFileRef: true,
-		Path:              "System/Library/Frameworks/UIKit.framework",
-		SourceTree:        "SDKROOT",
+	for _, name := range frameworks {
+		pbxFileReferences[name] = pbxObject{
+			Name:              name,
+			Id:                genIosFileId(&fileId),
+			LastKnownFileType: "wrapper.framework",
+			ShowNameInFileRef: true,
+			Path:              "System/Library/Frameworks/" + name,
+			SourceTree:        "SDKROOT",
+		}
 	}
-	pbxFileReferences[cp+"AppDelegate.h"] = pbxObject{
-		Name:              cp + "AppDelegate.h",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "sourcecode.c.h",
-		Path:              cp + "AppDelegate.h",
-		SourceTree:        "<group>",
+	if !swift {
+		pbxFileReferences[cp+"AppDelegate.h"] = pbxObject{
+			Name:              cp + "AppDelegate.h",
+			Id:                genIosFileId(&fileId),
+			LastKnownFileType: "sourcecode.c.h",
+			Path:              cp + "AppDelegate.h",
+			SourceTree:        "<group>",
+		}
 	}
-	pbxFileReferences[cp+"AppDelegate.m"] = pbxObject{
-		Name:              cp + "AppDelegate.m",
+	pbxFileReferences[cp+"AppDelegate"+srcExt] = pbxObject{
+		Name:              cp + "AppDelegate" + srcExt,
 		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "sourcecode.c.objc",
-		Path:              cp + "AppDelegate.m",
+		LastKnownFileType: srcType,
+		Path:              cp + "AppDelegate" + srcExt,
 		SourceTree:        "<group>",
 	}
 	pbxFileReferences["Images.xcassets"] = pbxObject{
@@ -102,6 +122,21 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		Path:              "Images.xcassets",
 		SourceTree:        "<group>",
 	}
+	// HTML files of the web views, and the directories of them
+	// as the folder references to keep the relative paths in the bundle
+	for _, asset := range webAssets {
+		fileType := "text.html"
+		if asset.Dir {
+			fileType = "folder"
+		}
+		pbxFileReferences["html|"+asset.Path] = pbxObject{
+			Name:              asset.Path,
+			Id:                genIosFileId(&fileId),
+			LastKnownFileType: fileType,
+			Path:              asset.Path,
+			SourceTree:        "<group>",
+		}
+	}
 	pbxFileReferences[pj+"-Info.plist"] = pbxObject{
 		Name:              pj + "-Info.plist",
 		Id:                genIosFileId(&fileId),
@@ -139,74 +174,114 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			SourceTree:        "<group>",
 		}
 	}
-	pbxFileReferences["main.m"] = pbxObject{
-		Name:              "main.m",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "sourcecode.c.objc",
-		Path:              "main.m",
-		SourceTree:        "<group>",
-	}
-	pbxFileReferences[pj+"-Prefix.pch"] = pbxObject{
-		Name:              pj + "-Prefix.pch",
-		Id:                genIosFileId(&fileId),
-		LastKnownFileType: "sourcecode.c.h",
-		Path:              pj + "-Prefix.pch",
-		SourceTree:        "<group>",
+	if storyboard {
+		for _, s := range mock.Strings {
+			lang := s.Lang
+			name := "Main.strings"
+			fileType := "text.plist.strings"
+			if strings.ToLower(lang) == "base" {
+				// base -> Base
+				lang = strings.Title(lang)
+				name = "Main.storyboard"
+				fileType = "file.storyboard"
+			}
+			pbxFileReferences[lang+"|Main.storyboard"] = pbxObject{
+				Name:              lang,
+				Id:                genIosFileId(&fileId),
+				LastKnownFileType: fileType,
+				ShowNameInFileRef: true,
+				Path:              lang + ".lproj/" + name,
+				SourceTree:        "<group>",
+			}
+		}
 	}
-	// ViewControllers for each Screens
-	for _, screen := range mock.Screens {
-		hname := cp + strings.Title(screen.Id) + "ViewController.h"
-		pbxFileReferences[hname] = pbxObject{
-			Name:              hname,
+	if !swift {
+		pbxFileReferences["main.m"] = pbxObject{
+			Name:              "main.m",
+			Id:                genIosFileId(&fileId),
+			LastKnownFileType: "sourcecode.c.objc",
+			Path:              "main.m",
+			SourceTree:        "<group>",
+		}
+		pbxFileReferences[pj+"-Prefix.pch"] = pbxObject{
+			Name:              pj + "-Prefix.pch",
 			Id:                genIosFileId(&fileId),
-			FileEncoding:      "4",
 			LastKnownFileType: "sourcecode.c.h",
-			Path:              hname,
+			Path:              pj + "-Prefix.pch",
 			SourceTree:        "<group>",
 		}
+	}
+	// ViewControllers for each Screens
+	for _, screen := range mock.Screens {
+		if !swift {
+			hname := cp + strings.Title(screen.Id) + "ViewController.h"
+			pbxFileReferences[hname] = pbxObject{
+				Name:              hname,
+				Id:                genIosFileId(&fileId),
+				FileEncoding:      "4",
+				LastKnownFileType: "sourcecode.c.h",
+				Path:              hname,
+				SourceTree:        "<group>",
+			}
+		}
 
-		mname := cp + strings.Title(screen.Id) + "ViewController.m"
+		mname := cp + strings.Title(screen.Id) + "ViewController" + srcExt
 		pbxFileReferences[mname] = pbxObject{
 			Name:              mname,
 			Id:                genIosFileId(&fileId),
 			FileEncoding:      "4",
-			LastKnownFileType: "sourcecode.c.objc",
+			LastKnownFileType: srcType,
 			Path:              mname,
 			SourceTree:        "<group>",
 		}
 	}
 	// Extension
-	pbxFileReferences["UIView+Extension.h"] = pbxObject{
-		Name:              "UIView+Extension.h",
-		Id:                genIosFileId(&fileId),
-		FileEncoding:      "4",
-		LastKnownFileType: "sourcecode.c.h",
-		Path:              "UIView+Extension.h",
-		SourceTree:        "<group>",
+	for _, name := range extensions {
+		if !swift {
+			pbxFileReferences[name+".h"] = pbxObject{
+				Name:              name + ".h",
+				Id:                genIosFileId(&fileId),
+				FileEncoding:      "4",
+				LastKnownFileType: "sourcecode.c.h",
+				Path:              name + ".h",
+				SourceTree:        "<group>",
+			}
+		}
+		pbxFileReferences[name+srcExt] = pbxObject{
+			Name:              name + srcExt,
+			Id:                genIosFileId(&fileId),
+			FileEncoding:      "4",
+			LastKnownFileType: srcType,
+			Path:              name + srcExt,
+			SourceTree:        "<group>",
+		}
 	}
-	pbxFileReferences["UIView+Extension.m"] = pbxObject{
-		Name:              "UIView+Extension.m",
-		Id:                genIosFileId(&fileId),
-		FileEncoding:      "4",
-		LastKnownFileType: "sourcecode.c.objc",
-		Path:              "UIView+Extension.m",
-		SourceTree:        "<group>",
+	// UI tests
+	pbxFileReferences[pj+"UITests.xctest"] = pbxObject{
+		Name:             pj + "UITests.xctest",
+		Id:               genIosFileId(&fileId),
+		ExplicitFileType: "wrapper.cfbundle",
+		IncludeInIndex:   "0",
+		Path:             pj + "UITests.xctest",
+		SourceTree:       "BUILT_PRODUCTS_DIR",
 	}
-	pbxFileReferences["UIColor+Extension.h"] = pbxObject{
-		Name:              "UIColor+Extension.h",
+	pbxFileReferences[pj+"UITests-Info.plist"] = pbxObject{
+		Name:              pj + "UITests-Info.plist",
 		Id:                genIosFileId(&fileId),
-		FileEncoding:      "4",
-		LastKnownFileType: "sourcecode.c.h",
-		Path:              "UIColor+Extension.h",
+		LastKnownFileType: "text.plist.xml",
+		Path:              pj + "UITests-Info.plist",
 		SourceTree:        "<group>",
 	}
-	pbxFileReferences["UIColor+Extension.m"] = pbxObject{
-		Name:              "UIColor+Extension.m",
-		Id:                genIosFileId(&fileId),
-		FileEncoding:      "4",
-		LastKnownFileType: "sourcecode.c.objc",
-		Path:              "UIColor+Extension.m",
-		SourceTree:        "<group>",
+	for _, screen := range testScreens {
+		name := cp + strings.Title(screen.Id) + "UITests.m"
+		pbxFileReferences[name] = pbxObject{
+			Name:              name,
+			Id:                genIosFileId(&fileId),
+			FileEncoding:      "4",
+			LastKnownFileType: "sourcecode.c.objc",
+			Path:              name,
+			SourceTree:        "<group>",
+		}
 	}
 	// PBXVariantGroup
 	fileRefsInfoPlist := []pbxObject{}
@@ -230,24 +305,30 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		Id:       genIosFileId(&fileId),
 		Children: fileRefsLocalizableStrings,
 	}
-	// PBXBuildFile
-	pbxBuildFiles["Foundation.framework"] = pbxObject{
-		Name:     "Foundation.framework",
-		Id:       genIosFileId(&fileId),
-		Location: "Frameworks",
-		FileRef:  pbxFileReferences["Foundation.framework"].Id,
-	}
-	pbxBuildFiles["CoreGraphics.framework"] = pbxObject{
-		Name:     "CoreGraphics.framework",
-		Id:       genIosFileId(&fileId),
-		Location: "Frameworks",
-		FileRef:  pbxFileReferences["CoreGraphics.framework"].Id,
+	if storyboard {
+		fileRefsStoryboard := []pbxObject{}
+		for _, s := range mock.Strings {
+			lang := s.Lang
+			if strings.ToLower(lang) == "base" {
+				// base -> Base
+				lang = strings.Title(lang)
+			}
+			fileRefsStoryboard = append(fileRefsStoryboard, pbxFileReferences[lang+"|Main.storyboard"])
+		}
+		pbxVariantGroups["Main.storyboard"] = pbxObject{
+			Name:     "Main.storyboard",
+			Id:       genIosFileId(&fileId),
+			Children: fileRefsStoryboard,
+		}
 	}
-	pbxBuildFiles["UIKit.framework"] = pbxObject{
-		Name:     "UIKit.framework",
-		Id:       genIosFileId(&fileId),
-		Location: "Frameworks",
-		FileRef:  pbxFileReferences["UIKit.framework"].Id,
+	// PBXBuildFile
+	for _, name := range frameworks {
+		pbxBuildFiles[name] = pbxObject{
+			Name:     name,
+			Id:       genIosFileId(&fileId),
+			Location: "Frameworks",
+			FileRef:  pbxFileReferences[name].Id,
+		}
 	}
 	pbxBuildFiles["InfoPlist.strings"] = pbxObject{
 		Name:     "InfoPlist.strings",
@@ -261,17 +342,27 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		Location: "Resources",
 		FileRef:  pbxVariantGroups["Localizable.strings"].Id,
 	}
-	pbxBuildFiles["main.m"] = pbxObject{
-		Name:     "main.m",
-		Id:       genIosFileId(&fileId),
-		Location: "Sources",
-		FileRef:  pbxFileReferences["main.m"].Id,
+	if storyboard {
+		pbxBuildFiles["Main.storyboard"] = pbxObject{
+			Name:     "Main.storyboard",
+			Id:       genIosFileId(&fileId),
+			Location: "Resources",
+			FileRef:  pbxVariantGroups["Main.storyboard"].Id,
+		}
 	}
-	pbxBuildFiles[cp+"AppDelegate.m"] = pbxObject{
-		Name:     cp + "AppDelegate.m",
+	if !swift {
+		pbxBuildFiles["main.m"] = pbxObject{
+			Name:     "main.m",
+			Id:       genIosFileId(&fileId),
+			Location: "Sources",
+			FileRef:  pbxFileReferences["main.m"].Id,
+		}
+	}
+	pbxBuildFiles[cp+"AppDelegate"+srcExt] = pbxObject{
+		Name:     cp + "AppDelegate" + srcExt,
 		Id:       genIosFileId(&fileId),
 		Location: "Sources",
-		FileRef:  pbxFileReferences[cp+"AppDelegate.m"].Id,
+		FileRef:  pbxFileReferences[cp+"AppDelegate"+srcExt].Id,
 	}
 	pbxBuildFiles["Images.xcassets"] = pbxObject{
 		Name:     "Images.xcassets",
@@ -279,9 +370,17 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		Location: "Resources",
 		FileRef:  pbxFileReferences["Images.xcassets"].Id,
 	}
+	for _, asset := range webAssets {
+		pbxBuildFiles["html|"+asset.Path] = pbxObject{
+			Name:     asset.Path,
+			Id:       genIosFileId(&fileId),
+			Location: "Resources",
+			FileRef:  pbxFileReferences["html|"+asset.Path].Id,
+		}
+	}
 	// ViewControllers for each Screens
 	for _, screen := range mock.Screens {
-		name := cp + strings.Title(screen.Id) + "ViewController.m"
+		name := cp + strings.Title(screen.Id) + "ViewController" + srcExt
 		pbxBuildFiles[name] = pbxObject{
 			Name:     name,
 			Id:       genIosFileId(&fileId),
@@ -290,85 +389,132 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		}
 	}
 	// Extensions
-	pbxBuildFiles["UIView+Extension.m"] = pbxObject{
-		Name:     "UIView+Extension.m",
-		Id:       genIosFileId(&fileId),
-		Location: "Sources",
-		FileRef:  pbxFileReferences["UIView+Extension.m"].Id,
+	for _, name := range extensions {
+		pbxBuildFiles[name+srcExt] = pbxObject{
+			Name:     name + srcExt,
+			Id:       genIosFileId(&fileId),
+			Location: "Sources",
+			FileRef:  pbxFileReferences[name+srcExt].Id,
+		}
 	}
-	pbxBuildFiles["UIColor+Extension.m"] = pbxObject{
-		Name:     "UIColor+Extension.m",
-		Id:       genIosFileId(&fileId),
-		Location: "Sources",
-		FileRef:  pbxFileReferences["UIColor+Extension.m"].Id,
+	for _, screen := range testScreens {
+		name := cp + strings.Title(screen.Id) + "UITests.m"
+		pbxBuildFiles[name] = pbxObject{
+			Name:     name,
+			Id:       genIosFileId(&fileId),
+			Location: "Sources",
+			FileRef:  pbxFileReferences[name].Id,
+		}
 	}
 	// PBXFrameworksBuildPhase
-	pbxFrameworksBuildPhases["Frameworks"] = pbxObject{Name: "Frameworks", Id: genIosFileId(&fileId), Children: []pbxObject{
-		pbxBuildFiles["Foundation.framework"],
-		pbxBuildFiles["CoreGraphics.framework"],
-		pbxBuildFiles["UIKit.framework"],
-	}}
+	frameworkBuildFiles := []pbxObject{}
+	for _, name := range frameworks {
+		frameworkBuildFiles = append(frameworkBuildFiles, pbxBuildFiles[name])
+	}
+	pbxFrameworksBuildPhases["Frameworks"] = pbxObject{Name: "Frameworks", Id: genIosFileId(&fileId), Children: frameworkBuildFiles}
+	pbxFrameworksBuildPhases[pj+"UITests"] = pbxObject{Name: "Frameworks", Id: genIosFileId(&fileId)}
 	// PBXGroup
-	pbxGroups["Supporting Files"] = pbxObject{Name: "Supporting Files", Id: genIosFileId(&fileId), Children: []pbxObject{
+	supportingFileRefs := []pbxObject{
 		pbxFileReferences[pj+"-Info.plist"],
 		pbxVariantGroups["InfoPlist.strings"],
 		pbxVariantGroups["Localizable.strings"],
-		pbxFileReferences["main.m"],
-		pbxFileReferences[pj+"-Prefix.pch"],
-	}}
-	vcFileRefs := []pbxObject{
-		pbxFileReferences[cp+"AppDelegate.h"],
-		pbxFileReferences[cp+"AppDelegate.m"],
 	}
+	if !swift {
+		supportingFileRefs = append(supportingFileRefs,
+			pbxFileReferences["main.m"],
+			pbxFileReferences[pj+"-Prefix.pch"])
+	}
+	pbxGroups["Supporting Files"] = pbxObject{Name: "Supporting Files", Id: genIosFileId(&fileId), Children: supportingFileRefs}
+	vcFileRefs := []pbxObject{}
+	if !swift {
+		vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+"AppDelegate.h"])
+	}
+	vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+"AppDelegate"+srcExt])
 	for _, screen := range mock.Screens {
-		vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+strings.Title(screen.Id)+"ViewController.h"])
-		vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+strings.Title(screen.Id)+"ViewController.m"])
+		if !swift {
+			vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+strings.Title(screen.Id)+"ViewController.h"])
+		}
+		vcFileRefs = append(vcFileRefs, pbxFileReferences[cp+strings.Title(screen.Id)+"ViewController"+srcExt])
+	}
+	for _, name := range extensions {
+		if !swift {
+			vcFileRefs = append(vcFileRefs, pbxFileReferences[name+".h"])
+		}
+		vcFileRefs = append(vcFileRefs, pbxFileReferences[name+srcExt])
+	}
+	if storyboard {
+		vcFileRefs = append(vcFileRefs, pbxVariantGroups["Main.storyboard"])
+	}
+	for _, asset := range webAssets {
+		vcFileRefs = append(vcFileRefs, pbxFileReferences["html|"+asset.Path])
 	}
-	vcFileRefs = append(vcFileRefs, pbxFileReferences["UIView+Extension.h"])
-	vcFileRefs = append(vcFileRefs, pbxFileReferences["UIView+Extension.m"])
-	vcFileRefs = append(vcFileRefs, pbxFileReferences["UIColor+Extension.h"])
-	vcFileRefs = append(vcFileRefs, pbxFileReferences["UIColor+Extension.m"])
 	vcFileRefs = append(vcFileRefs,
 		pbxFileReferences["Images.xcassets"],
 		pbxGroups["Supporting Files"])
 	pbxGroups[pj] = pbxObject{Name: pj, Id: genIosFileId(&fileId), Path: pj, Children: vcFileRefs}
-	pbxGroups["Frameworks"] = pbxObject{Name: "Frameworks", Id: genIosFileId(&fileId), Children: []pbxObject{
-		pbxFileReferences["Foundation.framework"],
-		pbxFileReferences["CoreGraphics.framework"],
-		pbxFileReferences["UIKit.framework"],
-	}}
+	frameworkFileRefs := []pbxObject{}
+	for _, name := range frameworks {
+		frameworkFileRefs = append(frameworkFileRefs, pbxFileReferences[name])
+	}
+	pbxGroups["Frameworks"] = pbxObject{Name: "Frameworks", Id: genIosFileId(&fileId), Children: frameworkFileRefs}
+	testFileRefs := []pbxObject{}
+	for _, screen := range testScreens {
+		testFileRefs = append(testFileRefs, pbxFileReferences[cp+strings.Title(screen.Id)+"UITests.m"])
+	}
+	testFileRefs = append(testFileRefs, pbxFileReferences[pj+"UITests-Info.plist"])
+	pbxGroups[pj+"UITests"] = pbxObject{Name: pj + "UITests", Id: genIosFileId(&fileId), Path: pj + "UITests", Children: testFileRefs}
 	pbxGroups["Products"] = pbxObject{Name: "Products", Id: genIosFileId(&fileId), Children: []pbxObject{
 		pbxFileReferences[pj+".app"],
+		pbxFileReferences[pj+"UITests.xctest"],
 	}}
 	pbxGroups["mainGroup"] = pbxObject{Id: genIosFileId(&fileId), Children: []pbxObject{
 		pbxGroups[pj],
+		pbxGroups[pj+"UITests"],
 		pbxGroups["Frameworks"],
 		pbxGroups["Products"],
 	}}
 	// PBXSourcesBuildPhase
-	vcBuildFiles := []pbxObject{
-		pbxBuildFiles["main.m"],
+	vcBuildFiles := []pbxObject{}
+	if !swift {
+		vcBuildFiles = append(vcBuildFiles, pbxBuildFiles["main.m"])
 	}
 	for _, screen := range mock.Screens {
-		vcBuildFiles = append(vcBuildFiles, pbxBuildFiles[cp+strings.Title(screen.Id)+"ViewController.m"])
+		vcBuildFiles = append(vcBuildFiles, pbxBuildFiles[cp+strings.Title(screen.Id)+"ViewController"+srcExt])
+	}
+	vcBuildFiles = append(vcBuildFiles, pbxBuildFiles[cp+"AppDelegate"+srcExt])
+	for _, name := range extensions {
+		vcBuildFiles = append(vcBuildFiles, pbxBuildFiles[name+srcExt])
 	}
-	vcBuildFiles = append(vcBuildFiles, pbxBuildFiles[cp+"AppDelegate.m"])
-	vcBuildFiles = append(vcBuildFiles, pbxBuildFiles["UIView+Extension.m"])
-	vcBuildFiles = append(vcBuildFiles, pbxBuildFiles["UIColor+Extension.m"])
 	pbxSourcesBuildPhases["Sources"] = pbxObject{
 		Name:     "Sources",
 		Id:       genIosFileId(&fileId),
 		Children: vcBuildFiles,
 	}
+	testBuildFiles := []pbxObject{}
+	for _, screen := range testScreens {
+		testBuildFiles = append(testBuildFiles, pbxBuildFiles[cp+strings.Title(screen.Id)+"UITests.m"])
+	}
+	pbxSourcesBuildPhases[pj+"UITests"] = pbxObject{
+		Name:     "Sources",
+		Id:       genIosFileId(&fileId),
+		Children: testBuildFiles,
+	}
 	// PBXResourcesBuildPhase
+	resourceBuildFiles := []pbxObject{
+		pbxBuildFiles["InfoPlist.strings"],
+		pbxBuildFiles["Localizable.strings"],
+		pbxBuildFiles["Images.xcassets"],
+	}
+	if storyboard {
+		resourceBuildFiles = append(resourceBuildFiles, pbxBuildFiles["Main.storyboard"])
+	}
+	for _, asset := range webAssets {
+		resourceBuildFiles = append(resourceBuildFiles, pbxBuildFiles["html|"+asset.Path])
+	}
 	pbxResourcesBuildPhases["Resources"] = pbxObject{
-		Name: "Resources",
-		Id:   genIosFileId(&fileId),
-		Children: []pbxObject{
-			pbxBuildFiles["InfoPlist.strings"],
-			pbxBuildFiles["Localizable.strings"],
-			pbxBuildFiles["Images.xcassets"],
-		},
+		Name:     "Resources",
+		Id:       genIosFileId(&fileId),
+		Children: resourceBuildFiles,
 	}
 	// XCConfiguration
 	xcProjectBuildConfigurations["Debug"] = pbxObject{
@@ -406,7 +552,7 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 				IPHONEOS_DEPLOYMENT_TARGET = %s;
 				ONLY_ACTIVE_ARCH = YES;
 				SDKROOT = iphoneos;`,
-			mock.Meta.Ios.DeploymentTarget),
+			iosDeploymentTarget(mock)),
 	}
 	xcProjectBuildConfigurations["Release"] = pbxObject{
 		Name: "Release",
@@ -437,29 +583,52 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 				IPHONEOS_DEPLOYMENT_TARGET = %s;
 				SDKROOT = iphoneos;
 				VALIDATE_PRODUCT = YES;`,
-			mock.Meta.Ios.DeploymentTarget),
+			iosDeploymentTarget(mock)),
+	}
+	languageSettings := `				GCC_PRECOMPILE_PREFIX_HEADER = YES;
+				GCC_PREFIX_HEADER = "MockerDemo/MockerDemo-Prefix.pch";`
+	if swift {
+		languageSettings = `				SWIFT_VERSION = 5.0;`
 	}
 	xcNativeTargetBuildConfigurations["Debug"] = pbxObject{
 		Name: "Debug",
 		Id:   genIosFileId(&fileId),
-		BuildSettings: `				ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon;
+		BuildSettings: fmt.Sprintf(`				ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon;
 				ASSETCATALOG_COMPILER_LAUNCHIMAGE_NAME = LaunchImage;
-				GCC_PRECOMPILE_PREFIX_HEADER = YES;
-				GCC_PREFIX_HEADER = "MockerDemo/MockerDemo-Prefix.pch";
+%s
 				INFOPLIST_FILE = "MockerDemo/MockerDemo-Info.plist";
 				PRODUCT_NAME = "$(TARGET_NAME)";
 				WRAPPER_EXTENSION = app;`,
+			languageSettings),
 	}
 	xcNativeTargetBuildConfigurations["Release"] = pbxObject{
 		Name: "Release",
 		Id:   genIosFileId(&fileId),
-		BuildSettings: `				ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon;
+		BuildSettings: fmt.Sprintf(`				ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon;
 				ASSETCATALOG_COMPILER_LAUNCHIMAGE_NAME = LaunchImage;
-				GCC_PRECOMPILE_PREFIX_HEADER = YES;
-				GCC_PREFIX_HEADER = "MockerDemo/MockerDemo-Prefix.pch";
+%s
 				INFOPLIST_FILE = "MockerDemo/MockerDemo-Info.plist";
 				PRODUCT_NAME = "$(TARGET_NAME)";
 				WRAPPER_EXTENSION = app;`,
+			languageSettings),
+	}
+	xcTestTargetBuildConfigurations["Debug"] = pbxObject{
+		Name: "Debug",
+		Id:   genIosFileId(&fileId),
+		BuildSettings: fmt.Sprintf(`				INFOPLIST_FILE = "%s";
+				PRODUCT_NAME = "$(TARGET_NAME)";
+				TEST_TARGET_NAME = %s;`,
+			pj+"UITests/"+pj+"UITests-Info.plist",
+			pj),
+	}
+	xcTestTargetBuildConfigurations["Release"] = pbxObject{
+		Name: "Release",
+		Id:   genIosFileId(&fileId),
+		BuildSettings: fmt.Sprintf(`				INFOPLIST_FILE = "%s";
+				PRODUCT_NAME = "$(TARGET_NAME)";
+				TEST_TARGET_NAME = %s;`,
+			pj+"UITests/"+pj+"UITests-Info.plist",
+			pj),
 	}
 	// XCConfigurationList
 	xcConfigurationLists["PBXProject \""+pj+"\""] = pbxObject{
@@ -478,10 +647,18 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			xcNativeTargetBuildConfigurations["Release"],
 		},
 	}
+	xcConfigurationLists["PBXNativeTarget \""+pj+"UITests\""] = pbxObject{
+		Name: "PBXNativeTarget \"" + pj + "UITests\"",
+		Id:   genIosFileId(&fileId),
+		Children: []pbxObject{
+			xcTestTargetBuildConfigurations["Debug"],
+			xcTestTargetBuildConfigurations["Release"],
+		},
+	}
 	// PBXNativeTarget
 	pbxNativeTargets[pj] = pbxObject{
-		Name: pj,
-		Id:   genIosFileId(&fileId),
+		Name:                   pj,
+		Id:                     genIosFileId(&fileId),
 		BuildConfigurationList: "PBXNativeTarget \"" + pj + "\"",
 		Children: []pbxObject{
 			pbxSourcesBuildPhases["Sources"],
@@ -489,16 +666,47 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			pbxResourcesBuildPhases["Resources"],
 		},
 		ProductReference: pj + ".app",
+		ProductType:      "com.apple.product-type.application",
+	}
+	// PBXContainerItemProxy
+	pbxContainerItemProxies[pj] = pbxObject{
+		Name:                 "PBXContainerItemProxy",
+		Id:                   genIosFileId(&fileId),
+		RemoteGlobalIdString: pbxNativeTargets[pj].Id,
+	}
+	// PBXTargetDependency
+	pbxTargetDependencies[pj] = pbxObject{
+		Name:        "PBXTargetDependency",
+		Id:          genIosFileId(&fileId),
+		TargetProxy: pbxContainerItemProxies[pj].Id,
+		Children: []pbxObject{
+			pbxNativeTargets[pj],
+		},
+	}
+	pbxNativeTargets[pj+"UITests"] = pbxObject{
+		Name:                   pj + "UITests",
+		Id:                     genIosFileId(&fileId),
+		BuildConfigurationList: "PBXNativeTarget \"" + pj + "UITests\"",
+		Children: []pbxObject{
+			pbxSourcesBuildPhases[pj+"UITests"],
+			pbxFrameworksBuildPhases[pj+"UITests"],
+		},
+		Dependencies: []pbxObject{
+			pbxTargetDependencies[pj],
+		},
+		ProductReference: pj + "UITests.xctest",
+		ProductType:      "com.apple.product-type.bundle.ui-testing",
 	}
 	// PBXProject
 	pbxProjects["Project object"] = pbxObject{
-		Name: "Project object",
-		Id:   genIosFileId(&fileId),
+		Name:                   "Project object",
+		Id:                     genIosFileId(&fileId),
 		BuildConfigurationList: "PBXProject \"" + pj + "\"",
 		MainGroup:              "mainGroup",
 		ProductRefGroup:        "Products",
 		Children: []pbxObject{
 			pbxNativeTargets[pj],
+			pbxNativeTargets[pj+"UITests"],
 		},
 	}
 
@@ -524,6 +732,26 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 	}
 	buf.add(`/* End PBXBuildFile section */`)
 
+	// PBXContainerItemProxy section
+	buf.add(`
+/* Begin PBXContainerItemProxy section */`)
+	for _, proxy := range pbxContainerItemProxies {
+		buf.add(`		%s /* %s */ = {
+			isa = PBXContainerItemProxy;
+			containerPortal = %s /* Project object */;
+			proxyType = 1;
+			remoteGlobalIDString = %s;
+			remoteInfo = %s;
+		};`,
+			proxy.Id,
+			proxy.Name,
+			pbxProjects["Project object"].Id,
+			proxy.RemoteGlobalIdString,
+			pj,
+		)
+	}
+	buf.add(`/* End PBXContainerItemProxy section */`)
+
 	// PBXFileReference section
 	buf.add(`
 /* Begin PBXFileReference section */`)
@@ -553,29 +781,31 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			sourceTree = "\"" + sourceTree + "\""
 		}
 		s += fmt.Sprintf(` sourceTree = %s; };`, sourceTree)
-		buf.add(s)
+		buf.add("%s", s)
 	}
 	buf.add(`/* End PBXFileReference section */`)
 
 	// PBXFrameworksBuildPhase section
 	buf.add(`
-/* Begin PBXFrameworksBuildPhase section */
-		%s /* %s */ = {
+/* Begin PBXFrameworksBuildPhase section */`)
+	for _, frameworksBuildPhase := range pbxFrameworksBuildPhases {
+		buf.add(`		%s /* %s */ = {
 			isa = PBXFrameworksBuildPhase;
 			buildActionMask = 2147483647;
 			files = (`,
-		pbxFrameworksBuildPhases["Frameworks"].Id,
-		pbxFrameworksBuildPhases["Frameworks"].Name,
-	)
-	for _, child := range pbxFrameworksBuildPhases["Frameworks"].Children {
-		buf.add(`				%s /* %s in %s */,`,
-			child.Id,
-			child.Name,
-			child.Location)
-	}
-	buf.add(`			);
+			frameworksBuildPhase.Id,
+			frameworksBuildPhase.Name,
+		)
+		for _, child := range frameworksBuildPhase.Children {
+			buf.add(`				%s /* %s in %s */,`,
+				child.Id,
+				child.Name,
+				child.Location)
+		}
+		buf.add(`			);
 			runOnlyForDeploymentPostprocessing = 0;
 		};`)
+	}
 	buf.add(`/* End PBXFrameworksBuildPhase section */`)
 
 	// PBXGroup section
@@ -634,17 +864,24 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		buf.add(`			);
 			buildRules = (
 			);
-			dependencies = (
-			);
+			dependencies = (`)
+		for _, dependency := range nativeTarget.Dependencies {
+			buf.add(`				%s /* %s */,`,
+				dependency.Id,
+				dependency.Name,
+			)
+		}
+		buf.add(`			);
 			name = %s;
 			productName = %s;
 			productReference = %s /* %s */;
-			productType = "com.apple.product-type.application";
+			productType = "%s";
 		};`,
 			nativeTarget.Name,
 			nativeTarget.Name,
 			pbxFileReferences[nativeTarget.ProductReference].Id,
 			pbxFileReferences[nativeTarget.ProductReference].Name,
+			nativeTarget.ProductType,
 		)
 	}
 	buf.add(`/* End PBXNativeTarget section */`)
@@ -660,8 +897,14 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			project.Name,
 		)
 		buf.add(`				CLASSPREFIX = %s;
-				LastUpgradeCheck = 0510;
+				LastUpgradeCheck = 0700;
 				ORGANIZATIONNAME = %s;
+				TargetAttributes = {
+					%s = {
+						CreatedOnToolsVersion = 7.0;
+						TestTargetID = %s;
+					};
+				};
 			};
 			buildConfigurationList = %s /* Build configuration list for %s */;
 			compatibilityVersion = "Xcode 3.2";
@@ -670,6 +913,8 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 			knownRegions = (`,
 			cp,
 			mock.Meta.Ios.OrganizationName,
+			pbxNativeTargets[pj+"UITests"].Id,
+			pbxNativeTargets[pj].Id,
 			xcConfigurationLists[project.BuildConfigurationList].Id,
 			xcConfigurationLists[project.BuildConfigurationList].Name,
 		)
@@ -702,9 +947,9 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 				child.Id,
 				child.Name,
 			)
-			buf.add(`			);`)
 		}
-		buf.add(`		};`)
+		buf.add(`			);
+		};`)
 	}
 	buf.add(`/* End PBXProject section */`)
 
@@ -756,6 +1001,25 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 	}
 	buf.add(`/* End PBXSourcesBuildPhase section */`)
 
+	// PBXTargetDependency section
+	buf.add(`
+/* Begin PBXTargetDependency section */`)
+	for _, dependency := range pbxTargetDependencies {
+		target := dependency.Children[0]
+		buf.add(`		%s /* %s */ = {
+			isa = PBXTargetDependency;
+			target = %s /* %s */;
+			targetProxy = %s /* PBXContainerItemProxy */;
+		};`,
+			dependency.Id,
+			dependency.Name,
+			target.Id,
+			target.Name,
+			dependency.TargetProxy,
+		)
+	}
+	buf.add(`/* End PBXTargetDependency section */`)
+
 	// PBXVariantGroup
 	buf.add(`
 /* Begin PBXVariantGroup section */`)
@@ -800,6 +1064,20 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 		buf.add(`		%s /* %s */ = {
 			isa = XCBuildConfiguration;
 			buildSettings = {
+%s
+			};
+			name = %s;
+		};`,
+			xcbc.Id,
+			xcbc.Name,
+			xcbc.BuildSettings,
+			xcbc.Name,
+		)
+	}
+	for _, xcbc := range xcTestTargetBuildConfigurations {
+		buf.add(`		%s /* %s */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
 %s
 			};
 			name = %s;
@@ -838,6 +1116,84 @@ func genCodeIosProjectPbxproj(mock *Mock, buf *CodeBuffer) {
 	buf.add(`	};
 	rootObject = %s /* Project object */;
 }`, pbxProjects["Project object"].Id)
+
+	genCodeIosScheme(mock, pbxNativeTargets[pj], pbxNativeTargets[pj+"UITests"], schemeBuf)
+}
+
+// Shared scheme to build the app and run the UI tests
+func genCodeIosScheme(mock *Mock, app, test pbxObject, buf *CodeBuffer) {
+	pj := mock.Meta.Ios.Project
+	appRef := func(t string) string {
+		return fmt.Sprintf(t+`<BuildableReference
+%s   BuildableIdentifier = "primary"
+%s   BlueprintIdentifier = "%s"
+%s   BuildableName = "%s.app"
+%s   BlueprintName = "%s"
+%s   ReferencedContainer = "container:%s.xcodeproj">
+%s</BuildableReference>`, t, t, app.Id, t, pj, t, pj, t, pj, t)
+	}
+	buf.add(`<?xml version="1.0" encoding="UTF-8"?>
+<Scheme
+   LastUpgradeVersion = "0700"
+   version = "1.3">
+   <BuildAction
+      parallelizeBuildables = "YES"
+      buildImplicitDependencies = "YES">
+      <BuildActionEntries>
+         <BuildActionEntry
+            buildForTesting = "YES"
+            buildForRunning = "YES"
+            buildForProfiling = "YES"
+            buildForArchiving = "YES"
+            buildForAnalyzing = "YES">
+%s
+         </BuildActionEntry>
+      </BuildActionEntries>
+   </BuildAction>
+   <TestAction
+      buildConfiguration = "Debug"
+      selectedDebuggerIdentifier = "Xcode.DebuggerFoundation.Debugger.LLDB"
+      selectedLauncherIdentifier = "Xcode.DebuggerFoundation.Launcher.LLDB"
+      shouldUseLaunchSchemeArgsEnv = "YES">
+      <Testables>
+         <TestableReference
+            skipped = "NO">
+            <BuildableReference
+               BuildableIdentifier = "primary"
+               BlueprintIdentifier = "%s"
+               BuildableName = "%sUITests.xctest"
+               BlueprintName = "%sUITests"
+               ReferencedContainer = "container:%s.xcodeproj">
+            </BuildableReference>
+         </TestableReference>
+      </Testables>
+      <MacroExpansion>
+%s
+      </MacroExpansion>
+   </TestAction>
+   <LaunchAction
+      buildConfiguration = "Debug"
+      selectedDebuggerIdentifier = "Xcode.DebuggerFoundation.Debugger.LLDB"
+      selectedLauncherIdentifier = "Xcode.DebuggerFoundation.Launcher.LLDB"
+      launchStyle = "0"
+      useCustomWorkingDirectory = "NO"
+      ignoresPersistentStateOnLaunch = "NO"
+      debugDocumentVersioning = "YES"
+      allowLocationSimulation = "YES">
+      <BuildableProductRunnable
+         runnableDebuggingMode = "0">
+%s
+      </BuildableProductRunnable>
+   </LaunchAction>
+   <ArchiveAction
+      buildConfiguration = "Release"
+      revealArchiveInOrganizer = "YES">
+   </ArchiveAction>
+</Scheme>`,
+		appRef("            "),
+		test.Id, pj, pj, pj,
+		appRef("         "),
+		appRef("         "))
 }
 
 func genIosFileId(i *int) string {
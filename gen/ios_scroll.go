@@ -0,0 +1,162 @@
+package gen
+
+// View controllers which have the scroll views inset them by the keyboard,
+// so that the inputs in them can be scrolled into the visible area.
+func genCodeIosScrollViews(screen Screen, storyboard bool, buf *CodeBuffer) {
+	if !hasScrollViews(&screen) {
+		return
+	}
+	buf.add(`
+#pragma mark - Scroll views
+
+- (void)viewWillAppear:(BOOL)animated
+{
+    [super viewWillAppear:animated];
+    [[NSNotificationCenter defaultCenter] addObserver:self selector:@selector(keyboardWillChangeFrame:) name:UIKeyboardWillChangeFrameNotification object:nil];
+}
+
+- (void)viewWillDisappear:(BOOL)animated
+{
+    [super viewWillDisappear:animated];
+    [[NSNotificationCenter defaultCenter] removeObserver:self name:UIKeyboardWillChangeFrameNotification object:nil];
+}`)
+	if storyboard {
+		buf.add(`
+/**
+ * Scroll views in the storyboard are laid out with the autoresizing masks,
+ * so the content size is updated with the frame of the content.
+ */
+- (void)viewDidLayoutSubviews
+{
+    [super viewDidLayoutSubviews];
+    for (UIScrollView *scrollView in [self scrollViewsInView:self.view]) {
+        UIView *content = scrollView.subviews.firstObject;
+        scrollView.contentSize = CGSizeMake(CGRectGetMaxX(content.frame) + CGRectGetMinX(content.frame),
+                                            CGRectGetMaxY(content.frame) + CGRectGetMinY(content.frame));
+    }
+}`)
+	}
+	buf.add(`
+/**
+ * Insets the scroll views by the keyboard over them
+ * and scrolls to the focused input to keep it visible.
+ */
+- (void)keyboardWillChangeFrame:(NSNotification *)notification
+{
+    CGRect keyboard = [[notification.userInfo objectForKey:UIKeyboardFrameEndUserInfoKey] CGRectValue];
+    for (UIScrollView *scrollView in [self scrollViewsInView:self.view]) {
+        CGRect frame = [scrollView convertRect:scrollView.bounds toView:nil];
+        UIEdgeInsets insets = scrollView.contentInset;
+        insets.bottom = MAX(CGRectGetMaxY(frame) - CGRectGetMinY(keyboard), 0);
+        scrollView.contentInset = insets;
+        scrollView.scrollIndicatorInsets = insets;
+        UIView *input = [self firstResponderInView:scrollView];
+        if (input) {
+            [scrollView scrollRectToVisible:[input convertRect:input.bounds toView:scrollView] animated:YES];
+        }
+    }
+}
+
+/**
+ * Finds the scroll views in the view.
+ * Table views are not included since they are the lists.
+ */
+- (NSArray *)scrollViewsInView:(UIView *)view
+{
+    NSMutableArray *scrollViews = [NSMutableArray new];
+    for (UIView *subview in view.subviews) {
+        if ([subview isKindOfClass:[UIScrollView class]] && ![subview isKindOfClass:[UITableView class]]) {
+            [scrollViews addObject:subview];
+        }
+        [scrollViews addObjectsFromArray:[self scrollViewsInView:subview]];
+    }
+    return scrollViews;
+}
+
+- (UIView *)firstResponderInView:(UIView *)view
+{
+    if (view.isFirstResponder) {
+        return view;
+    }
+    for (UIView *subview in view.subviews) {
+        UIView *found = [self firstResponderInView:subview];
+        if (found) {
+            return found;
+        }
+    }
+    return nil;
+}`)
+}
+
+func genCodeIosSwiftScrollViews(screen Screen, storyboard bool, buf *CodeBuffer) {
+	if !hasScrollViews(&screen) {
+		return
+	}
+	buf.add(`
+    // MARK: - Scroll views
+
+    override func viewWillAppear(_ animated: Bool) {
+        super.viewWillAppear(animated)
+        NotificationCenter.default.addObserver(self, selector: #selector(keyboardWillChangeFrame(_:)), name: UIResponder.keyboardWillChangeFrameNotification, object: nil)
+    }
+
+    override func viewWillDisappear(_ animated: Bool) {
+        super.viewWillDisappear(animated)
+        NotificationCenter.default.removeObserver(self, name: UIResponder.keyboardWillChangeFrameNotification, object: nil)
+    }`)
+	if storyboard {
+		buf.add(`
+    /// Scroll views in the storyboard are laid out with the autoresizing masks,
+    /// so the content size is updated with the frame of the content.
+    override func viewDidLayoutSubviews() {
+        super.viewDidLayoutSubviews()
+        for scrollView in scrollViews(in: view) {
+            if let content = scrollView.subviews.first {
+                scrollView.contentSize = CGSize(width: content.frame.maxX + content.frame.minX,
+                                                height: content.frame.maxY + content.frame.minY)
+            }
+        }
+    }`)
+	}
+	buf.add(`
+    /// Insets the scroll views by the keyboard over them
+    /// and scrolls to the focused input to keep it visible.
+    @objc func keyboardWillChangeFrame(_ notification: Notification) {
+        guard let keyboard = notification.userInfo?[UIResponder.keyboardFrameEndUserInfoKey] as? CGRect else {
+            return
+        }
+        for scrollView in scrollViews(in: view) {
+            let frame = scrollView.convert(scrollView.bounds, to: nil)
+            scrollView.contentInset.bottom = max(frame.maxY - keyboard.minY, 0)
+            scrollView.scrollIndicatorInsets = scrollView.contentInset
+            if let input = firstResponder(in: scrollView) {
+                scrollView.scrollRectToVisible(input.convert(input.bounds, to: scrollView), animated: true)
+            }
+        }
+    }
+
+    /// Finds the scroll views in the view.
+    /// Table views are not included since they are the lists.
+    func scrollViews(in view: UIView) -> [UIScrollView] {
+        var scrollViews = [UIScrollView]()
+        for subview in view.subviews {
+            if let scrollView = subview as? UIScrollView, !(subview is UITableView) {
+                scrollViews.append(scrollView)
+            }
+            scrollViews += self.scrollViews(in: subview)
+        }
+        return scrollViews
+    }
+
+    func firstResponder(in view: UIView) -> UIView? {
+        if view.isFirstResponder {
+            return view
+        }
+        for subview in view.subviews {
+            if let found = firstResponder(in: subview) {
+                return found
+            }
+        }
+        return nil
+    }`)
+}
@@ -0,0 +1,778 @@
+package gen
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+
+	"github.com/ksoichiro/mocker/layout"
+)
+
+// Size of the device shown in Interface Builder
+const (
+	iosStoryboardWidth  = 320
+	iosStoryboardHeight = 568
+	// Status bar and navigation bar
+	iosStoryboardTop = 64
+	// Size of UISwitch
+	iosSwitchWidth  = 51
+	iosSwitchHeight = 31
+)
+
+// Marks of the check box drawn with the title
+const (
+	iosCheckBoxOff = "\u2610"
+	iosCheckBoxOn  = "\u2611"
+)
+
+func genIosStoryboard(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeIosStoryboard(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, "Base.lproj", "Main.storyboard"))
+
+	// Texts in the storyboard are translated with strings files
+	for _, s := range mock.Strings {
+		if strings.ToLower(s.Lang) == "base" {
+			continue
+		}
+		buf = CodeBuffer{}
+		genCodeIosStoryboardStrings(mock, s, &buf)
+		genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, s.Lang+".lproj", "Main.strings"))
+	}
+}
+
+func genCodeIosStoryboard(mock *Mock, buf *CodeBuffer) {
+	buf.add(`<?xml version="1.0" encoding="UTF-8"?>
+<document type="com.apple.InterfaceBuilder3.CocoaTouch.Storyboard.XIB" version="3.0" toolsVersion="14460.31" targetRuntime="iOS.CocoaTouch" propertyAccessControl="none" useAutolayout="YES" initialViewController="nav-vc">
+    <device id="retina4_0" orientation="portrait">
+        <adaptation id="fullscreen"/>
+    </device>
+    <dependencies>
+        <plugIn identifier="com.apple.InterfaceBuilder.IBCocoaTouchPlugin" version="14460.20"/>
+    </dependencies>
+    <scenes>
+        <!--Navigation Controller-->
+        <scene sceneID="nav-scene">
+            <objects>
+                <navigationController id="nav-vc" sceneMemberID="viewController">
+                    <navigationBar key="navigationBar" contentMode="scaleToFill" id="nav-bar">
+                        <rect key="frame" x="0.0" y="20" width="%d" height="44"/>
+                        <autoresizingMask key="autoresizingMask"/>
+                    </navigationBar>
+                    <connections>
+                        <segue destination="%s" kind="relationship" relationship="rootViewController" id="nav-root"/>
+                    </connections>
+                </navigationController>
+                <placeholder placeholderIdentifier="IBFirstResponder" id="nav-fr" sceneMemberID="firstResponder"/>
+            </objects>
+        </scene>`,
+		iosStoryboardWidth,
+		iosStoryboardId(mock.Launch.Screen, "vc"))
+
+	for _, screen := range mock.Screens {
+		genCodeIosStoryboardScene(mock, screen, buf)
+	}
+
+	buf.add(`    </scenes>
+</document>`)
+}
+
+func genCodeIosStoryboardScene(mock *Mock, screen Screen, buf *CodeBuffer) {
+	cp := mock.Meta.Ios.ClassPrefix
+	vcId := iosStoryboardId(screen.Id, "vc")
+	customClass := fmt.Sprintf(`customClass="%s%sViewController"`, cp, strings.Title(screen.Id))
+	if mock.Meta.Ios.Language == IosLanguageSwift {
+		customClass += fmt.Sprintf(` customModule="%s" customModuleProvider="target"`, mock.Meta.Ios.Project)
+	}
+	buf.add(`        <!--%s-->
+        <scene sceneID="%s">
+            <objects>
+                <viewController storyboardIdentifier="%s" id="%s" %s sceneMemberID="viewController">
+                    <view key="view" contentMode="scaleToFill" id="%s">
+                        <rect key="frame" x="0.0" y="0.0" width="%d" height="%d"/>
+                        <autoresizingMask key="autoresizingMask" widthSizable="YES" heightSizable="YES"/>
+                        <subviews>`,
+		html.EscapeString(screen.Name),
+		iosStoryboardId(screen.Id, "scene"),
+		screen.Id,
+		vcId,
+		customClass,
+		iosStoryboardId(screen.Id, "root"),
+		iosStoryboardWidth,
+		iosStoryboardHeight)
+
+	// Only parse root view, which is placed under the navigation bar
+	if frame, ok := ResolveScreen(mock, &screen, "base", iosStoryboardWidth, iosStoryboardHeight-iosStoryboardTop); ok {
+		genIosStoryboardViewRecur(mock, &screen, nil, &screen.Layout[0], "0", frame, layout.Rect{Y: -iosStoryboardTop}, buf, 7)
+	}
+
+	buf.add(`                        </subviews>
+                        <color key="backgroundColor" white="1" alpha="1" colorSpace="calibratedWhite"/>
+                    </view>
+                    <navigationItem key="navigationItem" title="%s" id="%s"/>`,
+		html.EscapeString(screen.Name),
+		iosStoryboardId(screen.Id, "nav"))
+
+	views := []View{}
+	if 0 < len(screen.Layout) {
+		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
+	}
+	if 0 < len(views) {
+		buf.add(`                    <connections>`)
+		for _, view := range views {
+			property := view.Id
+			if mock.Meta.Ios.Language == IosLanguageSwift {
+				property = swiftPropertyName(view)
+			}
+			buf.add(`                        <outlet property="%s" destination="%s" id="%s"/>`,
+				property,
+				iosStoryboardId(screen.Id, "w", view.Id),
+				iosStoryboardId(screen.Id, "outlet", view.Id))
+		}
+		buf.add(`                    </connections>`)
+	}
+
+	buf.add(`                </viewController>
+                <placeholder placeholderIdentifier="IBFirstResponder" id="%s" sceneMemberID="firstResponder"/>
+            </objects>
+        </scene>`,
+		iosStoryboardId(screen.Id, "fr"))
+}
+
+// Frames in the storyboard are relative to the parent,
+// so the absolute frame is converted with the parent's origin.
+func genIosStoryboardViewRecur(mock *Mock, screen *Screen, container, view *View, path string, frame layout.Frame, parent layout.Rect, buf *CodeBuffer, indent int) {
+	if !iwd.Has(view.Type) {
+		return
+	}
+	widget := iwd.Get(view.Type)
+
+	t := tab(indent)
+	id := iosStoryboardViewId(screen, view, path)
+	hidden := ""
+	if view.Hidden {
+		hidden = ` hidden="YES"`
+	}
+	common := func() {
+		buf.add(`%s    <rect key="frame" x="%d" y="%d" width="%d" height="%d"/>`, t, frame.X-parent.X, frame.Y-parent.Y, frame.W, frame.H)
+		buf.add(`%s    %s`, t, iosStoryboardAutoresizing(container, view, widget))
+		if view.Id != "" {
+			buf.add(`%s    <accessibility key="accessibilityConfiguration" identifier="%s"/>`, t, view.Id)
+		}
+	}
+
+	switch view.Type {
+	case "label":
+		textAlignment := "natural"
+		if iosStoryboardGravity(view, widget) == GravityCenter {
+			textAlignment = "center"
+		}
+		buf.add(`%s<label opaque="NO" userInteractionEnabled="NO" contentMode="left" text="%s" textAlignment="%s" lineBreakMode="tailTruncation" id="%s"%s>`,
+			t, html.EscapeString(LocalizedString(mock, "base", view.Label)), textAlignment, id, hidden)
+		common()
+		buf.add(`%s    <fontDescription key="fontDescription" type="system" pointSize="17"/>
+%s    <nil key="highlightedColor"/>
+%s</label>`, t, t, t)
+	case "button":
+		buf.add(`%s<button opaque="NO" contentMode="scaleToFill" contentHorizontalAlignment="center" contentVerticalAlignment="center" buttonType="system" lineBreakMode="middleTruncation" id="%s"%s>`,
+			t, id, hidden)
+		common()
+		buf.add(`%s    <state key="normal" title="%s"/>`, t, html.EscapeString(LocalizedString(mock, "base", view.Label)))
+		if iosStoryboardConnects(screen, view) {
+			// Actions and segues need the widget ID
+			buf.add(`%s    <connections>`, t)
+			buf.add(`%s        <action selector="didPush%s:" destination="%s" eventType="touchUpInside" id="%s"/>`,
+				t, strings.Title(view.Id), iosStoryboardId(screen.Id, "vc"), iosStoryboardId(screen.Id, "action", view.Id))
+			for _, b := range findTransitBehaviors(mock, *screen) {
+				if b.Trigger.Widget != view.Id {
+					continue
+				}
+				buf.add(`%s        <segue destination="%s" kind="show" identifier="%s" id="%s"/>`,
+					t,
+					iosStoryboardId(b.Action.Transit, "vc"),
+					b.Trigger.Widget+"To"+strings.Title(b.Action.Transit),
+					iosStoryboardId(screen.Id, "segue", view.Id, b.Action.Transit))
+			}
+			buf.add(`%s    </connections>`, t)
+		}
+		buf.add(`%s</button>`, t)
+	case "input":
+		buf.add(`%s<textField opaque="NO" contentMode="scaleToFill" contentHorizontalAlignment="left" contentVerticalAlignment="center" borderStyle="roundedRect" placeholder="%s" textAlignment="natural" minimumFontSize="17" id="%s"%s>`,
+			t, html.EscapeString(LocalizedString(mock, "base", view.Hint)), id, hidden)
+		common()
+		buf.add(`%s    <fontDescription key="fontDescription" type="system" pointSize="14"/>
+%s    <textInputTraits key="textInputTraits"/>
+%s</textField>`, t, t, t)
+	case "switch":
+		// Label and switch in a row, and the switch has the ID
+		rowId := iosStoryboardId(screen.Id, "v"+path)
+		buf.add(`%s<view contentMode="scaleToFill" id="%s"%s>`, t, rowId, hidden)
+		buf.add(`%s    <rect key="frame" x="%d" y="%d" width="%d" height="%d"/>`, t, frame.X-parent.X, frame.Y-parent.Y, frame.W, frame.H)
+		buf.add(`%s    %s`, t, iosStoryboardAutoresizing(container, view, widget))
+		buf.add(`%s    <subviews>`, t)
+		buf.add(`%s        <label opaque="NO" userInteractionEnabled="NO" contentMode="left" text="%s" textAlignment="natural" lineBreakMode="tailTruncation" id="%s">
+%s            <rect key="frame" x="0.0" y="0.0" width="%d" height="%d"/>
+%s            <autoresizingMask key="autoresizingMask" widthSizable="YES" heightSizable="YES"/>
+%s            <fontDescription key="fontDescription" type="system" pointSize="17"/>
+%s            <nil key="highlightedColor"/>
+%s        </label>`,
+			t, html.EscapeString(LocalizedString(mock, "base", view.Label)), iosStoryboardId(rowId, "label"),
+			t, max(frame.W-iosSwitchWidth-8, 0), frame.H, t, t, t, t)
+		buf.add(`%s        <switch opaque="NO" contentMode="scaleToFill" contentHorizontalAlignment="center" contentVerticalAlignment="center" on="%s" id="%s">
+%s            <rect key="frame" x="%d" y="%d" width="%d" height="%d"/>
+%s            <autoresizingMask key="autoresizingMask" flexibleMinX="YES" flexibleMinY="YES" flexibleMaxY="YES"/>`,
+			t, iosStoryboardBool(view.Checked), id,
+			t, max(frame.W-iosSwitchWidth, 0), max(frame.H-iosSwitchHeight, 0)/2, iosSwitchWidth, iosSwitchHeight, t)
+		if view.Id != "" {
+			buf.add(`%s            <accessibility key="accessibilityConfiguration" identifier="%s"/>`, t, view.Id)
+		}
+		if iosStoryboardConnects(screen, view) {
+			iosStoryboardAction(screen, view, t+"        ", buf)
+		}
+		buf.add(`%s        </switch>`, t)
+		buf.add(`%s    </subviews>`, t)
+		buf.add(`%s</view>`, t)
+	case "checkbox":
+		text := html.EscapeString(LocalizedString(mock, "base", view.Label))
+		buf.add(`%s<button opaque="NO" contentMode="scaleToFill" selected="%s" contentHorizontalAlignment="left" contentVerticalAlignment="center" buttonType="custom" lineBreakMode="middleTruncation" id="%s"%s>`,
+			t, iosStoryboardBool(view.Checked), id, hidden)
+		common()
+		buf.add(`%s    <state key="normal" title="%s %s">
+%s        <color key="titleColor" white="0.0" alpha="1" colorSpace="calibratedWhite"/>
+%s    </state>
+%s    <state key="selected" title="%s %s"/>`, t, iosCheckBoxOff, text, t, t, t, iosCheckBoxOn, text)
+		if iosStoryboardConnects(screen, view) {
+			iosStoryboardAction(screen, view, t, buf)
+		}
+		buf.add(`%s</button>`, t)
+	case "radio_group":
+		selected := -1
+		for i, option := range view.Options {
+			if option == view.Selected {
+				selected = i
+			}
+		}
+		buf.add(`%s<segmentedControl opaque="NO" contentMode="scaleToFill" contentHorizontalAlignment="left" contentVerticalAlignment="top" segmentControlStyle="plain" selectedSegmentIndex="%d" id="%s"%s>`,
+			t, selected, id, hidden)
+		common()
+		buf.add(`%s    <segments>`, t)
+		for _, option := range view.Options {
+			buf.add(`%s        <segment title="%s"/>`, t, html.EscapeString(LocalizedString(mock, "base", option)))
+		}
+		buf.add(`%s    </segments>`, t)
+		if iosStoryboardConnects(screen, view) {
+			iosStoryboardAction(screen, view, t, buf)
+		}
+		buf.add(`%s</segmentedControl>`, t)
+	case "picker":
+		// Button titled with the selected item, which shows the items on tap
+		title := ""
+		if items := LocalizedArray(mock, "base", view.Array); 0 < len(items) {
+			title = items[0]
+		}
+		buf.add(`%s<button opaque="NO" contentMode="scaleToFill" contentHorizontalAlignment="left" contentVerticalAlignment="center" buttonType="system" lineBreakMode="middleTruncation" id="%s"%s>`,
+			t, id, hidden)
+		common()
+		buf.add(`%s    <state key="normal" title="%s"/>`, t, html.EscapeString(title))
+		if iosStoryboardConnects(screen, view) {
+			iosStoryboardAction(screen, view, t, buf)
+		}
+		buf.add(`%s</button>`, t)
+	case "list":
+		// Prototype cell has the row, which is found by the ID of the list
+		row := ListRow(view)
+		rowFrame := ResolveRow(mock, &row, "base", frame.W)
+		rowScreen := &Screen{Id: iosStoryboardId(screen.Id, view.Id, "row")}
+		cellId := iosStoryboardId(screen.Id, view.Id, "cell")
+		buf.add(`%s<tableView clipsSubviews="YES" contentMode="scaleToFill" alwaysBounceVertical="YES" dataMode="prototypes" style="plain" separatorStyle="default" rowHeight="%d" sectionHeaderHeight="28" sectionFooterHeight="28" id="%s"%s>`,
+			t, rowFrame.H, id, hidden)
+		common()
+		buf.add(`%s    <color key="backgroundColor" white="1" alpha="1" colorSpace="calibratedWhite"/>
+%s    <prototypes>
+%s        <tableViewCell clipsSubviews="YES" contentMode="scaleToFill" selectionStyle="default" indentationWidth="10" reuseIdentifier="%s" id="%s">
+%s            <rect key="frame" x="0.0" y="28" width="%d" height="%d"/>
+%s            <autoresizingMask key="autoresizingMask"/>
+%s            <tableViewCellContentView key="contentView" opaque="NO" clipsSubviews="YES" multipleTouchEnabled="YES" contentMode="center" tableViewCell="%s" id="%s">
+%s                <rect key="frame" x="0.0" y="0.0" width="%d" height="%d"/>
+%s                <autoresizingMask key="autoresizingMask"/>
+%s                <subviews>`,
+			t, t, t, view.Id, cellId, t, frame.W, rowFrame.H, t, t, cellId, iosStoryboardId(cellId, "content"), t, frame.W, rowFrame.H, t, t)
+		genIosStoryboardViewRecur(mock, rowScreen, nil, &row, "0", rowFrame, layout.Rect{}, buf, indent+5)
+		buf.add(`%s                </subviews>
+%s            </tableViewCellContentView>
+%s        </tableViewCell>
+%s    </prototypes>`, t, t, t, t)
+		if view.Id != "" {
+			buf.add(`%s    <connections>
+%s        <outlet property="dataSource" destination="%s" id="%s"/>
+%s        <outlet property="delegate" destination="%s" id="%s"/>
+%s    </connections>`,
+				t, t, iosStoryboardId(screen.Id, "vc"), iosStoryboardId(screen.Id, "datasource", view.Id),
+				t, iosStoryboardId(screen.Id, "vc"), iosStoryboardId(screen.Id, "delegate", view.Id), t)
+		}
+		buf.add(`%s</tableView>`, t)
+	case "image":
+		clips := ""
+		if view.Scale == ScaleFill {
+			clips = ` clipsSubviews="YES"`
+		}
+		buf.add(`%s<imageView%s userInteractionEnabled="NO" contentMode="%s" image="%s" id="%s"%s>`,
+			t, clips, iosStoryboardContentMode(view.Scale), imageResourceName(view), id, hidden)
+		common()
+		buf.add(`%s</imageView>`, t)
+	case "web":
+		// Pages are loaded by the view controller
+		buf.add(`%s<wkWebView contentMode="scaleToFill" id="%s"%s>`, t, id, hidden)
+		common()
+		buf.add(`%s    <wkWebViewConfiguration key="configuration">
+%s        <audiovisualMediaTypes key="mediaTypesRequiringUserActionForPlayback" none="YES"/>
+%s        <wkPreferences key="preferences"/>
+%s    </wkWebViewConfiguration>
+%s</wkWebView>`, t, t, t, t, t)
+	case "progress":
+		buf.add(`%s<progressView opaque="NO" contentMode="scaleToFill" verticalHuggingPriority="750" progress="%g" id="%s"%s>`,
+			t, view.Value, id, hidden)
+		common()
+		buf.add(`%s</progressView>`, t)
+	case "spinner_indicator":
+		buf.add(`%s<activityIndicatorView opaque="NO" contentMode="scaleToFill" horizontalHuggingPriority="750" verticalHuggingPriority="750" hidesWhenStopped="NO" animating="YES" style="gray" id="%s"%s>`,
+			t, id, hidden)
+		common()
+		buf.add(`%s</activityIndicatorView>`, t)
+	case "scroll":
+		// Content size is updated by the view controller with the frame of the content
+		buf.add(`%s<scrollView clipsSubviews="YES" multipleTouchEnabled="YES" contentMode="scaleToFill" keyboardDismissMode="interactive" id="%s"%s>`, t, id, hidden)
+		common()
+		if 0 < len(view.Sub) {
+			buf.add(`%s    <subviews>`, t)
+			genIosStoryboardViewRecur(mock, screen, view, &view.Sub[0], path+"-0", frame.Sub[0], frame.Rect, buf, indent+2)
+			buf.add(`%s    </subviews>`, t)
+		}
+		buf.add(`%s</scrollView>`, t)
+	default:
+		// Linear, relative, frame and grid layouts
+		buf.add(`%s<view contentMode="scaleToFill" id="%s"%s>`, t, id, hidden)
+		common()
+		if 0 < len(view.Sub) {
+			buf.add(`%s    <subviews>`, t)
+			for i := range view.Sub {
+				genIosStoryboardViewRecur(mock, screen, view, &view.Sub[i], fmt.Sprintf("%s-%d", path, i), frame.Sub[i], frame.Rect, buf, indent+2)
+			}
+			buf.add(`%s    </subviews>`, t)
+		}
+		buf.add(`%s</view>`, t)
+	}
+}
+
+// Rows of the lists are not in the layout of the screen,
+// so the controls in them are not connected to the view controller.
+func iosStoryboardConnects(screen *Screen, view *View) bool {
+	return view.Id != "" && findView(screen, view.Id) != nil
+}
+
+// Connects the control to the event handler of the view controller
+func iosStoryboardAction(screen *Screen, view *View, t string, buf *CodeBuffer) {
+	e, _ := iosEvent(*view)
+	buf.add(`%s    <connections>`, t)
+	buf.add(`%s        <action selector="%s%s:" destination="%s" eventType="%s" id="%s"/>`,
+		t, e.Handler, strings.Title(view.Id), iosStoryboardId(screen.Id, "vc"), e.StoryboardEvent, iosStoryboardId(screen.Id, "action", view.Id))
+	buf.add(`%s    </connections>`, t)
+}
+
+func iosStoryboardBool(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+func iosStoryboardContentMode(scale string) string {
+	switch scale {
+	case ScaleFill:
+		return "scaleAspectFill"
+	case ScaleStretch:
+		return "scaleToFill"
+	case ScaleCenter:
+		return "center"
+	}
+	return "scaleAspectFit"
+}
+
+func iosStoryboardFill(size, defaultSize string) bool {
+	if size == "" {
+		size = defaultSize
+	}
+	return size == SizeFill
+}
+
+func iosStoryboardGravity(view *View, widget Widget) string {
+	if view.Gravity != "" {
+		return view.Gravity
+	}
+	return widget.Gravity
+}
+
+func iosStoryboardAutoresizing(container, view *View, widget Widget) string {
+	attrs := ""
+	if container != nil && container.Type == "linear" && viewOrientation(container) == OrientationHorizontal {
+		attrs += iosStoryboardRowAutoresizing(container, view, widget)
+	} else if container != nil && container.Type == "grid" {
+		// Cells are resized in proportion to the grid
+		if iosStoryboardFill(view.SizeW, widget.SizeW) {
+			attrs += ` widthSizable="YES"`
+		}
+		attrs += ` flexibleMinX="YES" flexibleMaxX="YES"`
+	} else if iosStoryboardFill(view.SizeW, widget.SizeW) {
+		attrs += ` widthSizable="YES"`
+	} else {
+		switch view.AlignH {
+		case AlignCenter:
+			attrs += ` flexibleMinX="YES" flexibleMaxX="YES"`
+		case AlignRight:
+			attrs += ` flexibleMinX="YES"`
+		default:
+			attrs += ` flexibleMaxX="YES"`
+		}
+	}
+	if iosStoryboardFill(view.SizeH, widget.SizeH) {
+		attrs += ` heightSizable="YES"`
+	} else if view.AlignV == AlignBottom {
+		attrs += ` flexibleMinY="YES"`
+	} else {
+		attrs += ` flexibleMaxY="YES"`
+	}
+	return `<autoresizingMask key="autoresizingMask"` + attrs + `/>`
+}
+
+// Views in the horizontal linear layout keep their positions
+// beside the views which share the rest of the width.
+func iosStoryboardRowAutoresizing(container, view *View, widget Widget) string {
+	before, after := false, false
+	found := false
+	for i := range container.Sub {
+		sv := &container.Sub[i]
+		if sv == view {
+			found = true
+			continue
+		}
+		if !iwd.Has(sv.Type) || linearWeight(container, sv, iwd.Get(sv.Type)) == 0 {
+			continue
+		}
+		if found {
+			after = true
+		} else {
+			before = true
+		}
+	}
+	attrs := ""
+	if 0 < linearWeight(container, view, widget) {
+		attrs += ` widthSizable="YES"`
+	} else if !before && !after {
+		if iosStoryboardGravity(container, iwd.Get(container.Type)) == GravityCenter {
+			return ` flexibleMinX="YES" flexibleMaxX="YES"`
+		}
+		return ` flexibleMaxX="YES"`
+	}
+	if before {
+		attrs += ` flexibleMinX="YES"`
+	}
+	if after {
+		attrs += ` flexibleMaxX="YES"`
+	}
+	return attrs
+}
+
+// Object IDs in the storyboard.
+// They are derived from the screen and the view IDs to keep the output stable.
+func iosStoryboardId(parts ...string) string {
+	return strings.Join(parts, "-")
+}
+
+func iosStoryboardViewId(screen *Screen, view *View, path string) string {
+	if view.Id != "" {
+		return iosStoryboardId(screen.Id, "w", view.Id)
+	}
+	return iosStoryboardId(screen.Id, "v"+path)
+}
+
+func genCodeIosStoryboardStrings(mock *Mock, s String, buf *CodeBuffer) {
+	for _, screen := range mock.Screens {
+		if 0 < len(screen.Layout) {
+			genIosStoryboardStringsRecur(mock, s, &screen, &screen.Layout[0], "0", buf)
+		}
+	}
+}
+
+func genIosStoryboardStringsRecur(mock *Mock, s String, screen *Screen, view *View, path string, buf *CodeBuffer) {
+	if !iwd.Has(view.Type) {
+		return
+	}
+	id := iosStoryboardViewId(screen, view, path)
+	entryWithId := func(id, class, key, prefix, text string) {
+		buf.add(`
+/* Class = "%s"; %s = "%s"; ObjectID = "%s"; */
+"%s.%s" = "%s";`,
+			class, key, prefix+LocalizedString(mock, "base", text), id,
+			id, key, prefix+LocalizedString(mock, s.Lang, text))
+	}
+	entry := func(class, key, text string) {
+		entryWithId(id, class, key, "", text)
+	}
+	switch view.Type {
+	case "label":
+		entry("UILabel", "text", view.Label)
+	case "button":
+		entry("UIButton", "normalTitle", view.Label)
+	case "input":
+		entry("UITextField", "placeholder", view.Hint)
+	case "switch":
+		entryWithId(iosStoryboardId(screen.Id, "v"+path, "label"), "UILabel", "text", "", view.Label)
+	case "checkbox":
+		entryWithId(id, "UIButton", "normalTitle", iosCheckBoxOff+" ", view.Label)
+		entryWithId(id, "UIButton", "selectedTitle", iosCheckBoxOn+" ", view.Label)
+	case "radio_group":
+		for i, option := range view.Options {
+			entry("UISegmentedControl", fmt.Sprintf("segmentTitles[%d]", i), option)
+		}
+	case "picker":
+		base := LocalizedArray(mock, "base", view.Array)
+		items := LocalizedArray(mock, s.Lang, view.Array)
+		if 0 < len(base) && 0 < len(items) {
+			buf.add(`
+/* Class = "UIButton"; normalTitle = "%s"; ObjectID = "%s"; */
+"%s.normalTitle" = "%s";`, base[0], id, id, items[0])
+		}
+	}
+	for i := range view.Sub {
+		genIosStoryboardStringsRecur(mock, s, screen, &view.Sub[i], fmt.Sprintf("%s-%d", path, i), buf)
+	}
+	if view.Type == "list" {
+		row := ListRow(view)
+		genIosStoryboardStringsRecur(mock, s, &Screen{Id: iosStoryboardId(screen.Id, view.Id, "row")}, &row, "0", buf)
+	}
+}
+
+func genIosStoryboardViewController(mock *Mock, dir string, screen Screen) {
+	name := mock.Meta.Ios.ClassPrefix + strings.Title(screen.Id) + "ViewController"
+	var buf CodeBuffer
+	if mock.Meta.Ios.Language == IosLanguageSwift {
+		genCodeIosSwiftStoryboardViewController(mock, screen, &buf)
+		genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, name+".swift"))
+		return
+	}
+	genCodeIosStoryboardViewControllerHeader(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, name+".h"))
+	buf = CodeBuffer{}
+	genCodeIosStoryboardViewControllerImplementation(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, name+".m"))
+}
+
+func genCodeIosStoryboardViewControllerHeader(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`#import <UIKit/UIKit.h>`)
+	if 0 < len(collectWebViews(&screen)) {
+		buf.add(`#import <WebKit/WebKit.h>`)
+	}
+	buf.add(`
+@interface %s%sViewController : UIViewController
+`,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id))
+
+	views := []View{}
+	if 0 < len(screen.Layout) {
+		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
+	}
+	for _, view := range views {
+		buf.add(`@property (weak, nonatomic) IBOutlet %s *%s;`, iosWidgetClass(view), view.Id)
+	}
+	for _, view := range views {
+		if e, ok := iosEvent(view); ok {
+			buf.add(`
+- (IBAction)%s%s:(id)sender;`, e.Handler, strings.Title(view.Id))
+		}
+	}
+
+	buf.add(`
+@end`)
+}
+
+func genCodeIosStoryboardViewControllerImplementation(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`#import "%s%sViewController.h"
+
+@interface %s%sViewController ()%s
+
+@end
+
+@implementation %s%sViewController`,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id),
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id),
+		iosListProtocols(&screen, false),
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id))
+
+	views := []View{}
+	if 0 < len(screen.Layout) {
+		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
+	}
+	if 0 < len(views) {
+		buf.add(`
+#pragma mark - Widget event handlers`)
+	}
+	for _, view := range views {
+		e, ok := iosEvent(view)
+		if !ok {
+			continue
+		}
+		buf.add(`
+- (IBAction)%s%s:(id)sender
+{`, e.Handler, strings.Title(view.Id))
+		if view.Type == "checkbox" {
+			buf.add(`    UIButton *checkBox = sender;
+    checkBox.selected = !checkBox.selected;`)
+		}
+		if iosStoryboardHasSegue(mock, screen, view) {
+			buf.add(`    // Transition to the next screen is performed by the segue`)
+		}
+		t := "    "
+		if view.Type == "picker" {
+			// Transitions are performed when the item is selected
+			buf.add(`    UIButton *picker = sender;
+    UIAlertController *sheet = [UIAlertController alertControllerWithTitle:nil message:nil preferredStyle:UIAlertControllerStyleActionSheet];
+    for (NSInteger i = 0; i < %d; i++) {
+        NSString *item = NSLocalizedString(([NSString stringWithFormat:@"%s.%%ld", (long) i]), nil);
+        [sheet addAction:[UIAlertAction actionWithTitle:item style:UIAlertActionStyleDefault handler:^(UIAlertAction *action) {
+            picker.tag = i;
+            [picker setTitle:item forState:UIControlStateNormal];`, len(LocalizedArray(mock, "base", view.Array)), view.Array)
+			t = "            "
+		}
+		for _, next := range iosStoryboardTransitsInCode(mock, screen, view) {
+			buf.add(`%sUIViewController *vc = [self.storyboard instantiateViewControllerWithIdentifier:@"%s"];
+%s[self.navigationController pushViewController:vc animated:YES];`, t, next, t)
+		}
+		for _, s := range iosVisibilityStatements(&screen, view.Id, e.Trigger, false, "") {
+			buf.add("%s%s", t, s)
+		}
+		if view.Type == "picker" {
+			buf.add(`        }]];
+    }
+    [sheet addAction:[UIAlertAction actionWithTitle:NSLocalizedString(@"Cancel", nil) style:UIAlertActionStyleCancel handler:nil]];
+    sheet.popoverPresentationController.sourceView = picker;
+    sheet.popoverPresentationController.sourceRect = picker.bounds;
+    [self presentViewController:sheet animated:YES completion:nil];`)
+		}
+		buf.add(`}`)
+	}
+
+	genCodeIosListDataSource(mock, screen, true, buf)
+	genCodeIosScrollViews(screen, true, buf)
+	genCodeIosWebViews(screen, buf)
+
+	buf.add(`
+@end`)
+}
+
+func genCodeIosSwiftStoryboardViewController(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`import UIKit`)
+	if 0 < len(collectWebViews(&screen)) {
+		buf.add(`import WebKit`)
+	}
+	buf.add(`
+class %s%sViewController: UIViewController%s {`,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id),
+		iosListProtocols(&screen, true))
+
+	views := []View{}
+	if 0 < len(screen.Layout) {
+		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
+	}
+	if 0 < len(views) {
+		buf.add(``)
+	}
+	for _, view := range views {
+		buf.add(`    @IBOutlet weak var %s: %s!`, swiftPropertyName(view), iosWidgetClass(view))
+	}
+	if 0 < len(views) {
+		buf.add(`
+    // MARK: - Widget event handlers`)
+	}
+	for _, view := range views {
+		e, ok := iosEvent(view)
+		if !ok {
+			continue
+		}
+		buf.add(`
+    @IBAction func %s%s(_ sender: Any) {`, e.Handler, strings.Title(view.Id))
+		if view.Type == "checkbox" {
+			buf.add(`        if let checkBox = sender as? UIButton {
+            checkBox.isSelected = !checkBox.isSelected
+        }`)
+		}
+		if iosStoryboardHasSegue(mock, screen, view) {
+			buf.add(`        // Transition to the next screen is performed by the segue`)
+		}
+		t, self := "        ", ""
+		if view.Type == "picker" {
+			// Transitions are performed when the item is selected
+			buf.add(`        guard let picker = sender as? UIButton else { return }
+        let sheet = UIAlertController(title: nil, message: nil, preferredStyle: .actionSheet)
+        for i in 0..<%d {
+            let item = NSLocalizedString("%s.\(i)", comment: "")
+            sheet.addAction(UIAlertAction(title: item, style: .default) { _ in
+                picker.tag = i
+                picker.setTitle(item, for: .normal)`, len(LocalizedArray(mock, "base", view.Array)), view.Array)
+			// Closure needs the explicit self
+			t, self = "                ", "self."
+		}
+		for _, next := range iosStoryboardTransitsInCode(mock, screen, view) {
+			buf.add(`%sif let vc = %sstoryboard?.instantiateViewController(withIdentifier: "%s") {
+%s    %snavigationController?.pushViewController(vc, animated: true)
+%s}`, t, self, next, t, self, t)
+		}
+		for _, s := range iosVisibilityStatements(&screen, view.Id, e.Trigger, true, self) {
+			buf.add("%s%s", t, s)
+		}
+		if view.Type == "picker" {
+			buf.add(`            })
+        }
+        sheet.addAction(UIAlertAction(title: NSLocalizedString("Cancel", comment: ""), style: .cancel))
+        sheet.popoverPresentationController?.sourceView = picker
+        sheet.popoverPresentationController?.sourceRect = picker.bounds
+        present(sheet, animated: true)`)
+		}
+		buf.add(`    }`)
+	}
+
+	genCodeIosSwiftListDataSource(mock, screen, true, buf)
+	genCodeIosSwiftScrollViews(screen, true, buf)
+	genCodeIosSwiftWebViews(screen, buf)
+
+	buf.add(`}`)
+}
+
+// Segues are only triggered by buttons,
+// so the transitions triggered by the other controls are performed in the code.
+func iosStoryboardTransitsInCode(mock *Mock, screen Screen, view View) (screenIds []string) {
+	e, ok := iosEvent(view)
+	if !ok || e.Trigger == "click" {
+		return nil
+	}
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Widget == view.Id && b.Trigger.Type == e.Trigger && b.Action.Type == "transit_forward" && findScreen(mock, b.Action.Transit) != nil {
+			screenIds = append(screenIds, b.Action.Transit)
+		}
+	}
+	return
+}
+
+func iosStoryboardHasSegue(mock *Mock, screen Screen, view View) bool {
+	for _, b := range findTransitBehaviors(mock, screen) {
+		if b.Trigger.Widget == view.Id {
+			return true
+		}
+	}
+	return false
+}
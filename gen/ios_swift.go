@@ -0,0 +1,820 @@
+package gen
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Minimum deployment target of the Swift output.
+// The layout helper uses the layout anchors and UILayoutGuide of iOS 9,
+// and safeAreaLayoutGuide and the layout guides of UIScrollView need iOS 11.
+const iosSwiftDeploymentTarget = "11.0"
+
+// Returns the deployment target of the project.
+// The target older than the APIs used by the Swift output is raised.
+func iosDeploymentTarget(mock *Mock) string {
+	target := mock.Meta.Ios.DeploymentTarget
+	if mock.Meta.Ios.Language == IosLanguageSwift && versionLess(target, iosSwiftDeploymentTarget) {
+		return iosSwiftDeploymentTarget
+	}
+	return target
+}
+
+// Compares the versions like "7.0" and "11.0" by each number.
+func versionLess(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+func genIosSwiftAppDelegate(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeIosSwiftAppDelegate(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.Project, mock.Meta.Ios.ClassPrefix+"AppDelegate.swift"))
+}
+
+func genCodeIosSwiftAppDelegate(mock *Mock, buf *CodeBuffer) {
+	if mock.Meta.Ios.Layout == IosLayoutStoryboard {
+		// Window and the root view controller are loaded from the storyboard
+		buf.add(`import UIKit
+
+@main
+class %sAppDelegate: UIResponder, UIApplicationDelegate {
+
+    var window: UIWindow?
+
+    func application(_ application: UIApplication, didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        return true
+    }
+}`,
+			mock.Meta.Ios.ClassPrefix)
+		return
+	}
+	buf.add(`import UIKit
+
+@main
+class %sAppDelegate: UIResponder, UIApplicationDelegate {
+
+    var window: UIWindow?
+
+    func application(_ application: UIApplication, didFinishLaunchingWithOptions launchOptions: [UIApplication.LaunchOptionsKey: Any]?) -> Bool {
+        window = UIWindow(frame: UIScreen.main.bounds)
+        window?.backgroundColor = .white
+        window?.rootViewController = UINavigationController(rootViewController: %s%sViewController())
+        window?.makeKeyAndVisible()
+        return true
+    }
+}`,
+		mock.Meta.Ios.ClassPrefix,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(mock.Launch.Screen))
+}
+
+func genIosSwiftViewController(mock *Mock, dir string, screen Screen) {
+	var buf CodeBuffer
+	genCodeIosSwiftViewController(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, mock.Meta.Ios.ClassPrefix+strings.Title(screen.Id)+"ViewController.swift"))
+}
+
+func genCodeIosSwiftViewController(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`import UIKit`)
+	if 0 < len(collectWebViews(&screen)) {
+		buf.add(`import WebKit`)
+	}
+	buf.add(`
+class %s%sViewController: UIViewController%s {
+`,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id),
+		iosListProtocols(&screen, true))
+
+	views := []View{}
+	if 0 < len(screen.Layout) {
+		genCodeIosAggregateWidgets(&screen.Layout[0], &views)
+	}
+	for _, view := range views {
+		buf.add(`    var %s: %s?`, swiftPropertyName(view), iosWidgetClass(view))
+	}
+
+	buf.add(`
+    override func viewDidLoad() {
+        super.viewDidLoad()
+        title = %s
+        view.backgroundColor = .white
+        // Root view is placed under the navigation bar
+        let root = UIView()
+        root.translatesAutoresizingMaskIntoConstraints = false
+        view.addSubview(root)
+        NSLayoutConstraint.activate([
+            root.topAnchor.constraint(equalTo: view.safeAreaLayoutGuide.topAnchor),
+            root.leadingAnchor.constraint(equalTo: view.leadingAnchor),
+            root.trailingAnchor.constraint(equalTo: view.trailingAnchor),
+            root.bottomAnchor.constraint(equalTo: view.bottomAnchor),
+        ])
+        var views = [String: UIView]()
+        root.createWithViewInfo(viewInfo(), views: &views)`, quoteString(screen.Name))
+
+	for _, view := range views {
+		buf.add(`        %s = views["%s"] as? %s`, swiftPropertyName(view), view.Id, iosWidgetClass(view))
+		if e, ok := iosEvent(view); ok {
+			buf.add(`        %s?.addTarget(self, action: #selector(%s%s), for: %s)`,
+				swiftPropertyName(view), e.Handler, strings.Title(view.Id), e.SwiftEvent)
+		}
+		if view.Type == "list" {
+			buf.add(`        %s?.dataSource = self
+        %s?.delegate = self`, swiftPropertyName(view), swiftPropertyName(view))
+		}
+	}
+	buf.add(`    }`)
+
+	if 0 < len(views) {
+		buf.add(`
+    // MARK: - Widget event handlers`)
+	}
+
+	for _, view := range views {
+		e, ok := iosEvent(view)
+		if !ok {
+			continue
+		}
+		buf.add(`
+    @objc func %s%s() {`, e.Handler, strings.Title(view.Id))
+		for _, b := range screen.Behaviors {
+			if b.Trigger.Widget != view.Id || b.Trigger.Type != e.Trigger {
+				continue
+			}
+			if b.Action.Type == "transit_forward" {
+				if next := findScreen(mock, b.Action.Transit); next != nil {
+					buf.add(`        navigationController?.pushViewController(%s%sViewController(), animated: true)`,
+						mock.Meta.Ios.ClassPrefix,
+						strings.Title(next.Id))
+				}
+			}
+		}
+		for _, s := range iosVisibilityStatements(&screen, view.Id, e.Trigger, true, "") {
+			buf.add("        %s", s)
+		}
+		buf.add(`    }`)
+	}
+
+	genCodeIosSwiftListDataSource(mock, screen, false, buf)
+	genCodeIosSwiftScrollViews(screen, false, buf)
+
+	buf.add(`
+    // MARK: - Generated layout methods
+
+    /// Creates view layout information as a dictionary.
+    /// Layout is not determined by generator, it's up to Swift.
+    /// Generator just passes the structure of the views.
+    func viewInfo() -> [String: Any] {
+        let info: [String: Any] =`)
+	if 0 < len(screen.Layout) {
+		genIosLayoutRecur(mock, &screen.Layout[0], nil, buf, 2, "", swiftLiteral)
+	} else {
+		buf.add(`        [:]`)
+	}
+	buf.add(`        return info
+    }
+}`)
+}
+
+// Converts the view ID to the property name.
+// Widget name is appended to avoid conflicts with the properties
+// of UIViewController such as "view" or "next".
+func swiftPropertyName(view View) string {
+	name := swiftIdentifier(view.Id)
+	switch view.Type {
+	case "button":
+		return name + "Button"
+	case "label":
+		return name + "Label"
+	case "input":
+		return name + "Field"
+	case "image":
+		return name + "Image"
+	case "switch":
+		return name + "Switch"
+	case "checkbox":
+		return name + "CheckBox"
+	case "radio_group":
+		return name + "Control"
+	case "picker":
+		return name + "Picker"
+	case "list":
+		return name + "TableView"
+	case "scroll":
+		return name + "ScrollView"
+	case "web":
+		return name + "WebView"
+	case "progress":
+		return name + "ProgressView"
+	case "spinner_indicator":
+		return name + "Indicator"
+	}
+	return name + "View"
+}
+
+// Converts snake_case ID to lowerCamelCase identifier
+func swiftIdentifier(id string) string {
+	words := strings.Split(id, "_")
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.Title(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+func genIosSwiftViewHelper(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeIosSwiftViewHelper(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, "UIView+Extension.swift"))
+}
+
+// The constraints are created with the layout anchors
+// because the project targets iosSwiftDeploymentTarget or later.
+func genCodeIosSwiftViewHelper(mock *Mock, buf *CodeBuffer) {
+	buf.add(`import UIKit`)
+	if hasWebViews(mock) {
+		buf.add(`import WebKit`)
+	}
+	buf.add(`
+extension UIView {
+
+    /// Creates the view described by the view info and adds it to this view.
+    /// This view is treated as a relative layout without padding.
+    func createWithViewInfo(_ viewInfo: [String: Any], views: inout [String: UIView]) {
+        let view = UIView.makeView(viewInfo: viewInfo, views: &views)
+        addSubview(view)
+        constrainSubviews([view], viewInfo: [:], subviewInfos: [viewInfo])
+    }
+
+    /// Creates the view and its subviews recursively.
+    /// Views are laid out with Auto Layout,
+    /// so they follow the device size and the rotation.
+    static func makeView(viewInfo: [String: Any], views: inout [String: UIView]) -> UIView {
+        let text = NSLocalizedString(viewInfo["Text"] as? String ?? "", comment: "")
+        let view: UIView
+        // Control registered with the ID when it's wrapped in the view
+        var control: UIView?
+        switch viewInfo["Widget"] as? String {
+        case "button":
+            let button = UIButton()
+            button.setTitle(text, for: .normal)
+            button.setTitleColor(.black, for: .normal)
+            view = button
+        case "label":
+            let label = UILabel()
+            label.text = text
+            if viewInfo["Gravity"] as? String == "center" {
+                label.textAlignment = .center
+            }
+            view = label
+        case "input":
+            let input = UITextField()
+            if let hint = viewInfo["Hint"] as? String {
+                input.placeholder = NSLocalizedString(hint, comment: "")
+            }
+            view = input
+        case "image":
+            let image = UIImageView(image: UIImage(named: viewInfo["Image"] as? String ?? ""))
+            switch viewInfo["Scale"] as? String {
+            case "fill":
+                image.contentMode = .scaleAspectFill
+                image.clipsToBounds = true
+            case "stretch":
+                image.contentMode = .scaleToFill
+            case "center":
+                image.contentMode = .center
+            default:
+                image.contentMode = .scaleAspectFit
+            }
+            view = image
+        case "switch":
+            // UILabel and UISwitch in a row
+            let label = UILabel()
+            label.text = text
+            label.translatesAutoresizingMaskIntoConstraints = false
+            let toggle = UISwitch()
+            toggle.isOn = viewInfo["Checked"] as? Bool ?? false
+            toggle.translatesAutoresizingMaskIntoConstraints = false
+            view = UIView()
+            view.addSubview(label)
+            view.addSubview(toggle)
+            NSLayoutConstraint.activate([
+                label.leadingAnchor.constraint(equalTo: view.leadingAnchor),
+                label.centerYAnchor.constraint(equalTo: toggle.centerYAnchor),
+                toggle.leadingAnchor.constraint(equalTo: label.trailingAnchor, constant: 8),
+                toggle.trailingAnchor.constraint(equalTo: view.trailingAnchor),
+                toggle.topAnchor.constraint(equalTo: view.topAnchor),
+                toggle.bottomAnchor.constraint(equalTo: view.bottomAnchor),
+            ])
+            control = toggle
+        case "checkbox":
+            // UIButton which toggles the selected state
+            let checkBox = UIButton()
+            checkBox.setTitle("\u{2610} " + text, for: .normal)
+            checkBox.setTitle("\u{2611} " + text, for: .selected)
+            checkBox.setTitleColor(.black, for: .normal)
+            checkBox.contentHorizontalAlignment = .left
+            checkBox.isSelected = viewInfo["Checked"] as? Bool ?? false
+            checkBox.addTarget(UIView.self, action: #selector(UIView.toggleCheckBox(_:)), for: .touchUpInside)
+            view = checkBox
+        case "radio_group":
+            // UISegmentedControl
+            let options = viewInfo["Options"] as? [String] ?? []
+            let segments = UISegmentedControl(items: options.map { NSLocalizedString($0, comment: "") })
+            if let selected = viewInfo["Selected"] as? Int {
+                segments.selectedSegmentIndex = selected
+            }
+            view = segments
+        case "picker":
+            // UIButton which shows the items in the action sheet
+            let items = (viewInfo["Items"] as? [String] ?? []).map { NSLocalizedString($0, comment: "") }
+            let picker = UIButton(type: .system)
+            picker.setTitle(items.first, for: .normal)
+            picker.contentHorizontalAlignment = .left
+            objc_setAssociatedObject(picker, &UIView.pickerItemsKey, items, .OBJC_ASSOCIATION_RETAIN_NONATOMIC)
+            picker.addTarget(UIView.self, action: #selector(UIView.showPicker(_:)), for: .touchUpInside)
+            view = picker`)
+	if hasWebViews(mock) {
+		buf.add(`        case "web":
+            // WKWebView which loads the HTML file in the bundle or the page
+            let web = WKWebView()
+            // Pages can read the CSS, JavaScript and images copied with them
+            if let html = viewInfo["Html"] as? String, let resourceUrl = Bundle.main.resourceURL {
+                web.loadFileURL(resourceUrl.appendingPathComponent(html), allowingReadAccessTo: resourceUrl)
+            } else if let string = viewInfo["Url"] as? String, let url = URL(string: string) {
+                web.load(URLRequest(url: url))
+            }
+            view = web`)
+	}
+	buf.add(`        case "progress":
+            // UIProgressView
+            let progress = UIProgressView(progressViewStyle: .default)
+            progress.progress = Float(viewInfo["Value"] as? Double ?? 0)
+            view = progress
+        case "spinner_indicator":
+            // UIActivityIndicatorView which keeps animating while it's shown
+            let indicator = UIActivityIndicatorView(style: .gray)
+            indicator.hidesWhenStopped = false
+            indicator.startAnimating()
+            view = indicator
+        case "list":
+            // UITableView which creates the cells with the row info
+            let table = UITableView()
+            table.rowHeight = CGFloat(viewInfo["RowHeight"] as? Int ?? 44)
+            objc_setAssociatedObject(table, &UIView.rowInfoKey, viewInfo["Row"], .OBJC_ASSOCIATION_RETAIN_NONATOMIC)
+            view = table
+        case "scroll":
+            // UIScrollView whose content size follows the subview
+            let scroll = UIScrollView()
+            if let contentInfo = (viewInfo["Subviews"] as? [[String: Any]])?.first {
+                let content = UIView.makeView(viewInfo: contentInfo, views: &views)
+                scroll.addSubview(content)
+                scroll.constrainContent(content, viewInfo: viewInfo, contentInfo: contentInfo)
+            }
+            scroll.keyboardDismissMode = .interactive
+            view = scroll
+        default:
+            // Linear, relative, frame and grid layouts
+            view = UIView()
+            let subviewInfos = viewInfo["Subviews"] as? [[String: Any]] ?? []
+            var subviews = [UIView]()
+            for subviewInfo in subviewInfos {
+                let subview = UIView.makeView(viewInfo: subviewInfo, views: &views)
+                view.addSubview(subview)
+                subviews.append(subview)
+            }
+            view.constrainSubviews(subviews, viewInfo: viewInfo, subviewInfos: subviewInfos)
+
+            // Shrink to fit the subviews unless the size matches the parent
+            if !(viewInfo["MatchParentWidth"] as? Bool ?? true) {
+                let width = view.widthAnchor.constraint(equalToConstant: 0)
+                width.priority = .fittingSizeLevel
+                width.isActive = true
+            }
+            if !(viewInfo["MatchParentHeight"] as? Bool ?? true) {
+                let height = view.heightAnchor.constraint(equalToConstant: 0)
+                height.priority = .fittingSizeLevel
+                height.isActive = true
+            }
+        }
+
+        view.translatesAutoresizingMaskIntoConstraints = false
+        view.isHidden = viewInfo["Hidden"] as? Bool ?? false
+        if let id = viewInfo["Id"] as? String {
+            let control = control ?? view
+            views[id] = control
+            control.accessibilityIdentifier = id
+        }
+        return view
+    }
+
+    private static var rowInfoKey = 0
+
+    /// Creates the cell of the list with the row info of this table view
+    /// and sets the texts of the item to the views which have the keys as the IDs.
+    func cell(item: [String: String]) -> UITableViewCell {
+        let cell = UITableViewCell()
+        var views = [String: UIView]()
+        cell.contentView.createWithViewInfo(objc_getAssociatedObject(self, &UIView.rowInfoKey) as? [String: Any] ?? [:], views: &views)
+        for (key, text) in item {
+            switch views[key] {
+            case let label as UILabel:
+                label.text = text
+            case let button as UIButton:
+                button.setTitle(text, for: .normal)
+            case let field as UITextField:
+                field.text = text
+            default:
+                break
+            }
+        }
+        return cell
+    }
+
+    /// Toggles the check box and notifies the change
+    /// in the same way as the other controls.
+    @objc static func toggleCheckBox(_ checkBox: UIButton) {
+        checkBox.isSelected = !checkBox.isSelected
+        checkBox.sendActions(for: .valueChanged)
+    }
+
+    private static var pickerItemsKey = 0
+
+    /// Shows the items of the picker and notifies the change when one is selected.
+    /// Index of the selected item is set to the tag.
+    @objc static func showPicker(_ picker: UIButton) {
+        let items = objc_getAssociatedObject(picker, &pickerItemsKey) as? [String] ?? []
+        let sheet = UIAlertController(title: nil, message: nil, preferredStyle: .actionSheet)
+        for (i, item) in items.enumerated() {
+            sheet.addAction(UIAlertAction(title: item, style: .default) { _ in
+                picker.tag = i
+                picker.setTitle(item, for: .normal)
+                picker.sendActions(for: .valueChanged)
+            })
+        }
+        sheet.addAction(UIAlertAction(title: NSLocalizedString("Cancel", comment: ""), style: .cancel))
+        sheet.popoverPresentationController?.sourceView = picker
+        sheet.popoverPresentationController?.sourceRect = picker.bounds
+        picker.window?.rootViewController?.present(sheet, animated: true)
+    }
+
+    /// Adds constraints to lay out the subviews inside this view.
+    /// Linear layout stacks the subviews vertically or horizontally,
+    /// and relative layout places them with Below, AlignH and AlignV.
+    func constrainSubviews(_ subviews: [UIView], viewInfo: [String: Any], subviewInfos: [[String: Any]]) {
+        if viewInfo["Orientation"] as? String == "horizontal" {
+            constrainRowSubviews(subviews, viewInfo: viewInfo, subviewInfos: subviewInfos)
+            return
+        }
+        if viewInfo["Columns"] != nil {
+            constrainGridSubviews(subviews, viewInfo: viewInfo, subviewInfos: subviewInfos)
+            return
+        }
+        let padding = CGFloat(viewInfo["Padding"] as? Int ?? 0)
+        let gravity = viewInfo["Gravity"] as? String
+        let centerV = gravity == "center" || gravity == "center_v"
+        let linear = viewInfo["Orientation"] != nil
+
+        var constraints = [NSLayoutConstraint]()
+        var siblings = [String: (view: UIView, margin: CGFloat)]()
+        var previous: (view: UIView, margin: CGFloat)?
+        var firstFill: (view: UIView, weight: CGFloat)?
+        for (view, info) in zip(subviews, subviewInfos) {
+            let margin = CGFloat(info["Margin"] as? Int ?? 0)
+            let inset = padding + margin
+            let matchParentHeight = info["MatchParentHeight"] as? Bool ?? true
+
+            constraints += horizontalConstraints(for: view, viewInfo: info, gravity: gravity, inset: inset)
+
+            if linear {
+                // Stack vertically
+                if let previous = previous {
+                    constraints.append(view.topAnchor.constraint(equalTo: previous.view.bottomAnchor, constant: previous.margin + margin))
+                } else if !centerV {
+                    constraints.append(view.topAnchor.constraint(equalTo: topAnchor, constant: inset))
+                }
+                let weight = UIView.weight(viewInfo: info, matchParent: matchParentHeight)
+                if 0 < weight {
+                    // Views which have the weights share the rest of the height
+                    if let firstFill = firstFill {
+                        constraints.append(view.heightAnchor.constraint(equalTo: firstFill.view.heightAnchor, multiplier: weight / firstFill.weight))
+                    } else {
+                        firstFill = (view, weight)
+                    }
+                }
+                previous = (view, margin)
+                continue
+            }
+
+            // Place relatively
+            let alignV = info["AlignV"] as? String ?? (centerV ? "center" : "top")
+            if let below = info["Below"] as? String, let anchor = siblings[below] {
+                constraints.append(view.topAnchor.constraint(equalTo: anchor.view.bottomAnchor, constant: anchor.margin + margin))
+            } else if !matchParentHeight && alignV == "bottom" {
+                constraints.append(view.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -inset))
+            } else if !matchParentHeight && alignV == "center" {
+                constraints.append(view.centerYAnchor.constraint(equalTo: centerYAnchor))
+            } else {
+                constraints.append(view.topAnchor.constraint(equalTo: topAnchor, constant: inset))
+            }
+            if matchParentHeight {
+                constraints.append(view.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -inset))
+            } else {
+                constraints.append(view.topAnchor.constraint(greaterThanOrEqualTo: topAnchor, constant: inset))
+                constraints.append(view.bottomAnchor.constraint(lessThanOrEqualTo: bottomAnchor, constant: -inset))
+            }
+            if let id = info["Id"] as? String {
+                siblings[id] = (view, margin)
+            }
+        }
+
+        if linear, let first = subviews.first, let last = previous {
+            if firstFill != nil {
+                constraints.append(last.view.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -(padding + last.margin)))
+            } else if !centerV {
+                constraints.append(last.view.bottomAnchor.constraint(lessThanOrEqualTo: bottomAnchor, constant: -(padding + last.margin)))
+            } else {
+                // Center the stacked views with the spacers which have the same height
+                let top = UILayoutGuide()
+                let bottom = UILayoutGuide()
+                addLayoutGuide(top)
+                addLayoutGuide(bottom)
+                let firstMargin = CGFloat(subviewInfos[0]["Margin"] as? Int ?? 0)
+                constraints += [
+                    top.topAnchor.constraint(equalTo: topAnchor, constant: padding),
+                    first.topAnchor.constraint(equalTo: top.bottomAnchor, constant: firstMargin),
+                    bottom.topAnchor.constraint(equalTo: last.view.bottomAnchor, constant: last.margin),
+                    bottom.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -padding),
+                    top.heightAnchor.constraint(equalTo: bottom.heightAnchor),
+                ]
+            }
+        }
+        NSLayoutConstraint.activate(constraints)
+    }
+
+    /// Adds constraints to stack the subviews horizontally inside this view.
+    /// Views are placed vertically with AlignV.
+    func constrainRowSubviews(_ subviews: [UIView], viewInfo: [String: Any], subviewInfos: [[String: Any]]) {
+        let padding = CGFloat(viewInfo["Padding"] as? Int ?? 0)
+        let gravity = viewInfo["Gravity"] as? String
+        let centerV = gravity == "center" || gravity == "center_v"
+
+        var constraints = [NSLayoutConstraint]()
+        var previous: (view: UIView, margin: CGFloat)?
+        var firstFill: (view: UIView, weight: CGFloat)?
+        for (view, info) in zip(subviews, subviewInfos) {
+            let margin = CGFloat(info["Margin"] as? Int ?? 0)
+            let inset = padding + margin
+
+            // Place vertically
+            if info["MatchParentHeight"] as? Bool ?? true {
+                constraints += [
+                    view.topAnchor.constraint(equalTo: topAnchor, constant: inset),
+                    view.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -inset),
+                ]
+            } else {
+                switch info["AlignV"] as? String ?? (centerV ? "center" : "top") {
+                case "bottom":
+                    constraints.append(view.bottomAnchor.constraint(equalTo: bottomAnchor, constant: -inset))
+                case "center":
+                    constraints.append(view.centerYAnchor.constraint(equalTo: centerYAnchor))
+                default:
+                    constraints.append(view.topAnchor.constraint(equalTo: topAnchor, constant: inset))
+                }
+                constraints += [
+                    view.topAnchor.constraint(greaterThanOrEqualTo: topAnchor, constant: inset),
+                    view.bottomAnchor.constraint(lessThanOrEqualTo: bottomAnchor, constant: -inset),
+                ]
+            }
+
+            // Stack horizontally
+            if let previous = previous {
+                constraints.append(view.leadingAnchor.constraint(equalTo: previous.view.trailingAnchor, constant: previous.margin + margin))
+            } else {
+                constraints.append(view.leadingAnchor.constraint(equalTo: leadingAnchor, constant: inset))
+            }
+            let weight = UIView.weight(viewInfo: info, matchParent: info["MatchParentWidth"] as? Bool ?? true)
+            if 0 < weight {
+                // Views which have the weights share the rest of the width
+                if let firstFill = firstFill {
+                    constraints.append(view.widthAnchor.constraint(equalTo: firstFill.view.widthAnchor, multiplier: weight / firstFill.weight))
+                } else {
+                    firstFill = (view, weight)
+                }
+            }
+            previous = (view, margin)
+        }
+
+        if let last = previous {
+            constraints.append(firstFill != nil
+                ? last.view.trailingAnchor.constraint(equalTo: trailingAnchor, constant: -(padding + last.margin))
+                : last.view.trailingAnchor.constraint(lessThanOrEqualTo: trailingAnchor, constant: -(padding + last.margin)))
+        }
+        NSLayoutConstraint.activate(constraints)
+    }
+
+    /// Adds constraints to place the subviews in the cells of the grid.
+    /// Cells are the layout guides which divide the width by the columns,
+    /// and the rows are as tall as the tallest subviews unless the number of the rows is specified.
+    func constrainGridSubviews(_ subviews: [UIView], viewInfo: [String: Any], subviewInfos: [[String: Any]]) {
+        let padding = CGFloat(viewInfo["Padding"] as? Int ?? 0)
+        let gravity = viewInfo["Gravity"] as? String
+        let columns = CGFloat(viewInfo["Columns"] as? Int ?? 1)
+        let rows = CGFloat(viewInfo["Rows"] as? Int ?? 0)
+
+        var constraints = [NSLayoutConstraint]()
+        // First cells of the rows
+        var rowCells = [UILayoutGuide]()
+        for (view, info) in zip(subviews, subviewInfos) {
+            let row = info["Row"] as? Int ?? 0
+            let column = CGFloat(info["Column"] as? Int ?? 0)
+            let span = CGFloat(info["Span"] as? Int ?? 1)
+
+            let cell = UILayoutGuide()
+            addLayoutGuide(cell)
+
+            // Divide the width by the columns
+            if column == 0 {
+                constraints.append(cell.leadingAnchor.constraint(equalTo: leadingAnchor, constant: padding))
+            } else {
+                constraints.append(NSLayoutConstraint(item: cell, attribute: .leading, relatedBy: .equal, toItem: self, attribute: .trailing, multiplier: column / columns, constant: padding * (1 - 2 * column / columns)))
+            }
+            constraints.append(cell.widthAnchor.constraint(equalTo: widthAnchor, multiplier: span / columns, constant: -2 * padding * span / columns))
+
+            // Stack the rows
+            if row < rowCells.count {
+                constraints += [
+                    cell.topAnchor.constraint(equalTo: rowCells[row].topAnchor),
+                    cell.bottomAnchor.constraint(equalTo: rowCells[row].bottomAnchor),
+                ]
+            } else {
+                constraints.append(cell.topAnchor.constraint(equalTo: rowCells.last?.bottomAnchor ?? topAnchor, constant: rowCells.isEmpty ? padding : 0))
+                if 0 < rows {
+                    constraints.append(cell.heightAnchor.constraint(equalTo: heightAnchor, multiplier: 1 / rows, constant: -2 * padding / rows))
+                } else {
+                    // Shrink to fit the tallest subview
+                    let height = cell.heightAnchor.constraint(equalToConstant: 0)
+                    height.priority = .fittingSizeLevel
+                    constraints.append(height)
+                }
+                rowCells.append(cell)
+            }
+
+            constraints += cellConstraints(for: view, in: cell, viewInfo: info, gravity: gravity)
+        }
+        if rows == 0, let last = rowCells.last {
+            constraints.append(last.bottomAnchor.constraint(lessThanOrEqualTo: bottomAnchor, constant: -padding))
+        }
+        NSLayoutConstraint.activate(constraints)
+    }
+
+    /// Creates constraints to place the subview in the cell with AlignH and AlignV.
+    func cellConstraints(for view: UIView, in cell: UILayoutGuide, viewInfo: [String: Any], gravity: String?) -> [NSLayoutConstraint] {
+        let margin = CGFloat(viewInfo["Margin"] as? Int ?? 0)
+        var constraints = [NSLayoutConstraint]()
+
+        if viewInfo["MatchParentWidth"] as? Bool ?? true {
+            constraints += [
+                view.leadingAnchor.constraint(equalTo: cell.leadingAnchor, constant: margin),
+                view.trailingAnchor.constraint(equalTo: cell.trailingAnchor, constant: -margin),
+            ]
+        } else {
+            switch viewInfo["AlignH"] as? String ?? (gravity == "center" ? "center" : "left") {
+            case "right":
+                constraints.append(view.trailingAnchor.constraint(equalTo: cell.trailingAnchor, constant: -margin))
+            case "center":
+                constraints.append(view.centerXAnchor.constraint(equalTo: cell.centerXAnchor))
+            default:
+                constraints.append(view.leadingAnchor.constraint(equalTo: cell.leadingAnchor, constant: margin))
+            }
+            constraints += [
+                view.leadingAnchor.constraint(greaterThanOrEqualTo: cell.leadingAnchor, constant: margin),
+                view.trailingAnchor.constraint(lessThanOrEqualTo: cell.trailingAnchor, constant: -margin),
+            ]
+        }
+
+        if viewInfo["MatchParentHeight"] as? Bool ?? true {
+            constraints += [
+                view.topAnchor.constraint(equalTo: cell.topAnchor, constant: margin),
+                view.bottomAnchor.constraint(equalTo: cell.bottomAnchor, constant: -margin),
+            ]
+        } else {
+            let centerV = gravity == "center" || gravity == "center_v"
+            switch viewInfo["AlignV"] as? String ?? (centerV ? "center" : "top") {
+            case "bottom":
+                constraints.append(view.bottomAnchor.constraint(equalTo: cell.bottomAnchor, constant: -margin))
+            case "center":
+                constraints.append(view.centerYAnchor.constraint(equalTo: cell.centerYAnchor))
+            default:
+                constraints.append(view.topAnchor.constraint(equalTo: cell.topAnchor, constant: margin))
+            }
+            constraints += [
+                view.topAnchor.constraint(greaterThanOrEqualTo: cell.topAnchor, constant: margin),
+                view.bottomAnchor.constraint(lessThanOrEqualTo: cell.bottomAnchor, constant: -margin),
+            ]
+        }
+        return constraints
+    }
+
+    /// Weight of the view in the linear layout.
+    /// Views which match the parent without the weight share the rest equally.
+    static func weight(viewInfo: [String: Any], matchParent: Bool) -> CGFloat {
+        if let weight = viewInfo["Weight"] as? Double {
+            return CGFloat(weight)
+        }
+        return matchParent ? 1 : 0
+    }
+
+    /// Creates constraints to place the subview horizontally with AlignH.
+    /// Views which don't match the parent width keep their intrinsic width.
+    func horizontalConstraints(for view: UIView, viewInfo: [String: Any], gravity: String?, inset: CGFloat) -> [NSLayoutConstraint] {
+        if viewInfo["MatchParentWidth"] as? Bool ?? true {
+            return [
+                view.leadingAnchor.constraint(equalTo: leadingAnchor, constant: inset),
+                view.trailingAnchor.constraint(equalTo: trailingAnchor, constant: -inset),
+            ]
+        }
+
+        var constraints = [
+            view.leadingAnchor.constraint(greaterThanOrEqualTo: leadingAnchor, constant: inset),
+            view.trailingAnchor.constraint(lessThanOrEqualTo: trailingAnchor, constant: -inset),
+        ]
+        switch viewInfo["AlignH"] as? String ?? (gravity == "center" ? "center" : "left") {
+        case "right":
+            constraints.append(view.trailingAnchor.constraint(equalTo: trailingAnchor, constant: -inset))
+        case "center":
+            constraints.append(view.centerXAnchor.constraint(equalTo: centerXAnchor))
+        default:
+            constraints.append(view.leadingAnchor.constraint(equalTo: leadingAnchor, constant: inset))
+        }
+        return constraints
+    }
+}
+
+extension UIScrollView {
+
+    /// Adds constraints to place the content inside this scroll view.
+    /// Edges of the content determine the content size,
+    /// and the content is as large as this view across the scrolling direction.
+    func constrainContent(_ content: UIView, viewInfo: [String: Any], contentInfo: [String: Any]) {
+        let inset = CGFloat((viewInfo["Padding"] as? Int ?? 0) + (contentInfo["Margin"] as? Int ?? 0))
+        let horizontal = viewInfo["Orientation"] as? String == "horizontal"
+        let matchAcross = contentInfo[horizontal ? "MatchParentHeight" : "MatchParentWidth"] as? Bool ?? true
+        let matchAlong = contentInfo[horizontal ? "MatchParentWidth" : "MatchParentHeight"] as? Bool ?? true
+        let across = horizontal ? (content.heightAnchor, frameLayoutGuide.heightAnchor) : (content.widthAnchor, frameLayoutGuide.widthAnchor)
+        let along = horizontal ? (content.widthAnchor, frameLayoutGuide.widthAnchor) : (content.heightAnchor, frameLayoutGuide.heightAnchor)
+
+        var constraints = [
+            content.leadingAnchor.constraint(equalTo: contentLayoutGuide.leadingAnchor, constant: inset),
+            content.trailingAnchor.constraint(equalTo: contentLayoutGuide.trailingAnchor, constant: -inset),
+            content.topAnchor.constraint(equalTo: contentLayoutGuide.topAnchor, constant: inset),
+            content.bottomAnchor.constraint(equalTo: contentLayoutGuide.bottomAnchor, constant: -inset),
+            matchAcross
+                ? across.0.constraint(equalTo: across.1, constant: -inset * 2)
+                : across.0.constraint(lessThanOrEqualTo: across.1, constant: -inset * 2),
+        ]
+        if matchAlong {
+            constraints.append(along.0.constraint(greaterThanOrEqualTo: along.1, constant: -inset * 2))
+        }
+
+        // Shrink to fit the subviews in the scrolling direction
+        let size = along.0.constraint(equalToConstant: 0)
+        size.priority = .fittingSizeLevel
+        constraints.append(size)
+        NSLayoutConstraint.activate(constraints)
+    }
+}`)
+}
+
+func genIosSwiftColors(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeIosSwiftColors(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.Project, "UIColor+Extension.swift"))
+}
+
+func genCodeIosSwiftColors(mock *Mock, buf *CodeBuffer) {
+	buf.add(`import UIKit
+
+extension UIColor {
+`)
+
+	for _, c := range mock.Colors {
+		a, r, g, b := hexToInt(c.Value)
+		buf.add(`    static var %sColor: UIColor { return UIColor(red: %d/255.0, green: %d/255.0, blue: %d/255.0, alpha: %d/255.0) }`, c.Id, r, g, b, a)
+	}
+
+	buf.add(`}`)
+}
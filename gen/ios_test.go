@@ -0,0 +1,278 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenCodeIosUITest(t *testing.T) {
+	mock := Mock{
+		Meta:   Meta{Ios: Ios{Project: "Sample", ClassPrefix: "SC"}},
+		Launch: Launch{Screen: "top"},
+		Screens: []Screen{
+			{Id: "top", Name: "Top", Layout: []View{
+				{Type: "relative", Sub: []View{{Id: "next", Type: "button"}}},
+			}, Behaviors: []Behavior{
+				{Trigger: Trigger{Type: "click", Widget: "next"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+			}},
+			{Id: "second", Name: `Say "Hello"`, Layout: []View{
+				{Type: "relative", Sub: []View{{Id: "back", Type: "button"}, {Id: "again", Type: "button"}}},
+			}, Behaviors: []Behavior{
+				{Trigger: Trigger{Type: "click", Widget: "back"}, Action: Action{Type: "transit_forward", Transit: "top"}},
+				// Transit to the same screen cannot be tested with the navigation bar
+				{Trigger: Trigger{Type: "click", Widget: "again"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+			}},
+		},
+	}
+
+	var buf CodeBuffer
+	genCodeIosUITest(&mock, mock.Screens[1], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"@interface SCSecondUITests : XCTestCase",
+		"- (void)testTapBackShowsTop",
+		`    [self.app.buttons[@"next"] tap];
+    [self.app.buttons[@"back"] tap];`,
+		`evaluatedWithObject:self.app.navigationBars[@"Top"]`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+	// The API of Xcode 9 is not available in the declared tools version,
+	// and the transit to the same screen is not tested
+	for _, unexpected := range []string{"waitForExistence", "testTapAgain"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Unexpected %q in\n%s", unexpected, code)
+		}
+	}
+
+	buf = CodeBuffer{}
+	genCodeIosUITest(&mock, mock.Screens[0], &buf)
+	if code := strings.Join(buf, "\n"); !strings.Contains(code, `navigationBars[@"Say \"Hello\""]`) {
+		t.Errorf("Expected the escaped screen name in\n%s", code)
+	}
+
+	buf = CodeBuffer{}
+	genCodeIosViewControllerImplementation(&mock, mock.Screens[1], &buf, &CodeBuffer{})
+	if code := strings.Join(buf, "\n"); !strings.Contains(code, `self.title = @"Say \"Hello\"";`) {
+		t.Errorf("Expected the escaped title in\n%s", code)
+	}
+}
+
+func TestIosDeploymentTarget(t *testing.T) {
+	var testcases = []struct {
+		language string
+		target   string
+		expect   string
+	}{
+		{IosLanguageObjC, "7.0", "7.0"},
+		{"", "7.0", "7.0"},
+		{IosLanguageSwift, "7.0", "11.0"},
+		{IosLanguageSwift, "9.3", "11.0"},
+		{IosLanguageSwift, "11.0", "11.0"},
+		{IosLanguageSwift, "11.4", "11.4"},
+		{IosLanguageSwift, "13", "13"},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Meta: Meta{Ios: Ios{Language: tc.language, DeploymentTarget: tc.target}}}
+		if actual := iosDeploymentTarget(&mock); actual != tc.expect {
+			t.Errorf("Expected %s but %s: language=%s, target=%s", tc.expect, actual, tc.language, tc.target)
+		}
+	}
+}
+
+func TestGenCodeIosSwiftCodeLayout(t *testing.T) {
+	defineIosWidgets()
+	mock := loadSampleMock(t)
+	mock.Meta.Ios.Language = IosLanguageSwift
+	mock.Meta.Ios.Layout = IosLayoutCode
+
+	var buf CodeBuffer
+	genCodeIosSwiftViewHelper(&mock, &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"extension UIView {",
+		"func createWithViewInfo(_ viewInfo: [String: Any], views: inout [String: UIView]) {",
+		"UILayoutGuide()",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	// The anchors used by the helper need the minimum target
+	var pbx, scheme CodeBuffer
+	genCodeIosProjectPbxproj(&mock, &pbx, &scheme)
+	project := strings.Join(pbx, "\n")
+	if n := strings.Count(project, "IPHONEOS_DEPLOYMENT_TARGET = "+iosSwiftDeploymentTarget+";"); n != 2 {
+		t.Errorf("Expected 2 deployment targets of %s but %d in\n%s", iosSwiftDeploymentTarget, n, project)
+	}
+	for _, expect := range []string{"UIView+Extension.swift", "SWIFT_VERSION = 5.0;"} {
+		if !strings.Contains(project, expect) {
+			t.Errorf("Expected %q in the project", expect)
+		}
+	}
+}
+
+func TestGenIosLayoutWidgets(t *testing.T) {
+	defineIosWidgets()
+	var testcases = []struct {
+		view    View
+		expects []string
+	}{
+		{View{Id: "agree", Type: "checkbox", Label: "agree", Checked: true},
+			[]string{`@"Widget": @"checkbox",`, `@"Text": @"agree",`, `@"Checked": @YES,`}},
+		{View{Id: "notify", Type: "switch", Label: "notify"},
+			[]string{`@"Widget": @"switch",`, `@"Text": @"notify",`}},
+		{View{Id: "gender", Type: "radio_group", Options: []string{"male", "female"}, Selected: "female"},
+			[]string{`@"Widget": @"radio_group",`, `@"Options": @[@"male", @"female"],`, `@"Selected": @1,`}},
+		{View{Id: "fruit", Type: "picker", Array: "fruits"},
+			[]string{`@"Widget": @"picker",`, `@"Items": @[@"fruits.0", @"fruits.1"],`}},
+		{View{Id: "users", Type: "list", Row: &View{Id: "name", Type: "label"}},
+			[]string{`@"Widget": @"list",`, `@"RowHeight": @`, `@"Row":`, `@"Id": @"name",`}},
+		{View{Id: "form", Type: "scroll", Sub: []View{{Type: "linear", Sub: []View{{Id: "name", Type: "input"}}}}},
+			[]string{`@"Widget": @"scroll",`, `@"Widget": @"input",`}},
+		{View{Id: "buttons", Type: "linear", Orientation: "horizontal", Sub: []View{{Id: "ok", Type: "button", Weight: 2}}},
+			[]string{`@"Orientation": @"horizontal",`, `@"Weight": @2.0,`}},
+		{View{Id: "frame", Type: "frame", Sub: []View{{Id: "a", Type: "label", AlignH: "right"}}},
+			[]string{`@"Id": @"frame",`, `@"AlignH": @"right",`}},
+		{View{Id: "grid", Type: "grid", Columns: 2, Sub: []View{{Id: "a", Type: "label", Span: 2}}},
+			[]string{`@"Columns": @2,`, `@"Row": @0,`, `@"Column": @0,`, `@"Span": @2,`}},
+		{View{Id: "bar", Type: "progress", Value: 0.5},
+			[]string{`@"Widget": @"progress",`, `@"Value": @0.5,`}},
+		{View{Id: "spinner", Type: "spinner_indicator", Hidden: true},
+			[]string{`@"Widget": @"spinner_indicator",`, `@"Hidden": @YES,`}},
+		{View{Id: "help", Type: "web", Html: "help/index.html"},
+			[]string{`@"Widget": @"web",`, `@"Html": @"help/index.html",`}},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(tc.view)
+		mock.Strings = []String{{Lang: "base", Arrays: []Array{{Id: "fruits", Items: []string{"Apple", "Banana"}}}}}
+		var buf CodeBuffer
+		genCodeIosViewControllerLayout(&mock, mock.Screens[0], &buf)
+		code := strings.Join(buf, "\n")
+		for _, expect := range tc.expects {
+			if !strings.Contains(code, expect) {
+				t.Errorf("Expected %q in\n%s", expect, code)
+			}
+		}
+	}
+}
+
+func TestGenCodeIosSwift(t *testing.T) {
+	defineIosWidgets()
+	mock := loadSampleMock(t)
+	mock.Meta.Ios.Language = IosLanguageSwift
+
+	var buf CodeBuffer
+	genCodeIosSwiftAppDelegate(&mock, &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"@main\nclass MDAppDelegate: UIResponder, UIApplicationDelegate {",
+		"window?.rootViewController = UINavigationController(rootViewController: MDTopViewController())",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	buf = CodeBuffer{}
+	genCodeIosSwiftViewController(&mock, mock.Screens[0], &buf)
+	code = strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"class MDTopViewController: UIViewController {",
+		`        title = "Mocker Demo"`,
+		"    var userIdField: UITextField?",
+		`        nextButton = views["next"] as? UIButton`,
+		"        nextButton?.addTarget(self, action: #selector(didPushNext), for: .touchUpInside)",
+		`    @objc func didPushNext() {
+        navigationController?.pushViewController(MDSecondViewController(), animated: true)
+    }`,
+		`"Below": "label_demo",`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenCodeIosStoryboard(t *testing.T) {
+	defineIosWidgets()
+	mock := loadSampleMock(t)
+	mock.Meta.Ios.Layout = IosLayoutStoryboard
+
+	var buf CodeBuffer
+	genCodeIosStoryboard(&mock, &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`initialViewController="nav-vc"`,
+		`<segue destination="top-vc" kind="relationship" relationship="rootViewController" id="nav-root"/>`,
+		`<viewController storyboardIdentifier="top" id="top-vc" customClass="MDTopViewController"`,
+		`text="Welcome to Mocker Demo!"`,
+		`placeholder="Input your ID"`,
+		`<state key="normal" title="Next"/>`,
+		`<segue destination="second-vc" kind="show" identifier="nextToSecond" id="top-segue-next-second"/>`,
+		`<outlet property="user_id" destination="top-w-user_id" id="top-outlet-user_id"/>`,
+		`<navigationItem key="navigationItem" title="Next" id="second-nav"/>`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	// Swift controllers only have the outlets and the actions
+	mock.Meta.Ios.Language = IosLanguageSwift
+	buf = CodeBuffer{}
+	genCodeIosSwiftStoryboardViewController(&mock, mock.Screens[0], &buf)
+	code = strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"    @IBOutlet weak var userIdField: UITextField!",
+		"    @IBAction func didPushNext(_ sender: Any) {",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenCodeIosCodeLayout(t *testing.T) {
+	defineIosWidgets()
+	mock := loadSampleMock(t)
+
+	var buf CodeBuffer
+	genCodeIosViewControllerImplementation(&mock, mock.Screens[0], &buf, &CodeBuffer{})
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"        id topGuide = self.topLayoutGuide;",
+		`[NSLayoutConstraint constraintsWithVisualFormat:@"V:[topGuide][root]|" options:0 metrics:nil views:NSDictionaryOfVariableBindings(topGuide, root)]`,
+		"        [root createWithViewInfo:[self viewInfo] views:views];",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	// Views are constrained by the helper instead of the frames
+	buf = CodeBuffer{}
+	genCodeIosViewHelperImplementation(&mock, &buf)
+	code = strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"- (void)constrainSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos",
+		"- (void)constrainRowSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos",
+		"- (void)constrainGridSubviews:(NSArray *)subviews withViewInfo:(NSDictionary *)viewInfo subviewInfos:(NSArray *)subviewInfos",
+		"translatesAutoresizingMaskIntoConstraints = NO;",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in the helper", expect)
+		}
+	}
+	if strings.Contains(code, "setFrame:") || strings.Contains(code, ".frame = ") {
+		t.Errorf("Unexpected frame in the helper")
+	}
+
+	// Objective-C helper works with the top layout guide of iOS 7
+	if target := iosDeploymentTarget(&mock); target != "7.0" {
+		t.Errorf("Expected 7.0 but %s", target)
+	}
+}
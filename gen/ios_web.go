@@ -0,0 +1,62 @@
+package gen
+
+// Web views in the storyboard are loaded by the view controller,
+// since the storyboard only has the configurations.
+// Local HTML files are preferred to the URLs, and they can read
+// the other files copied with them in the bundle.
+func genCodeIosWebViews(screen Screen, buf *CodeBuffer) {
+	views := iosLoadedWebViews(&screen)
+	if len(views) == 0 {
+		return
+	}
+	buf.add(`
+#pragma mark - Web views
+
+- (void)viewDidLoad
+{
+    [super viewDidLoad];`)
+	for _, view := range views {
+		if view.Html != "" {
+			buf.add(`    NSURL *%sUrl = [[NSBundle mainBundle].resourceURL URLByAppendingPathComponent:@%s];
+    [self.%s loadFileURL:%sUrl allowingReadAccessToURL:[NSBundle mainBundle].resourceURL];`,
+				view.Id, quoteString(webViewHtmlPath(view.Html)), view.Id, view.Id)
+		} else {
+			buf.add(`    [self.%s loadRequest:[NSURLRequest requestWithURL:[NSURL URLWithString:@%s]]];`, view.Id, quoteString(view.Url))
+		}
+	}
+	buf.add(`}`)
+}
+
+func genCodeIosSwiftWebViews(screen Screen, buf *CodeBuffer) {
+	views := iosLoadedWebViews(&screen)
+	if len(views) == 0 {
+		return
+	}
+	buf.add(`
+    // MARK: - Web views
+
+    override func viewDidLoad() {
+        super.viewDidLoad()`)
+	for _, view := range views {
+		if view.Html != "" {
+			buf.add(`        if let resourceUrl = Bundle.main.resourceURL {
+            %s.loadFileURL(resourceUrl.appendingPathComponent(%s), allowingReadAccessTo: resourceUrl)
+        }`, swiftPropertyName(*view), quoteString(webViewHtmlPath(view.Html)))
+		} else {
+			buf.add(`        if let url = URL(string: %s) {
+            %s.load(URLRequest(url: url))
+        }`, quoteString(view.Url), swiftPropertyName(*view))
+		}
+	}
+	buf.add(`    }`)
+}
+
+// Web views which have the outlets and the pages to be loaded.
+func iosLoadedWebViews(screen *Screen) (views []*View) {
+	for _, view := range collectWebViews(screen) {
+		if view.Id != "" && (view.Html != "" || view.Url != "") {
+			views = append(views, view)
+		}
+	}
+	return
+}
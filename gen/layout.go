@@ -0,0 +1,282 @@
+package gen
+
+import (
+	"strconv"
+
+	"github.com/ksoichiro/mocker/layout"
+)
+
+// Default layout params to resolve the frames statically
+var lwd = defineLayoutWidgets()
+
+func defineLayoutWidgets() (wd WidgetsDef) {
+	wd.Add("button", Widget{
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wd.Add("label", Widget{
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wd.Add("input", Widget{
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wd.Add("image", Widget{
+		SizeW: SizeWrap,
+		SizeH: SizeWrap,
+	})
+	wd.Add("switch", Widget{
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wd.Add("checkbox", Widget{
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wd.Add("radio_group", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeWrap,
+	})
+	wd.Add("picker", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeWrap,
+	})
+	wd.Add("web", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	wd.Add("progress", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeWrap,
+	})
+	wd.Add("spinner_indicator", Widget{
+		SizeW: SizeWrap,
+		SizeH: SizeWrap,
+	})
+	wd.Add("list", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	wd.Add("linear", Widget{
+		Orientation: OrientationVertical,
+		SizeW:       SizeFill,
+		SizeH:       SizeFill,
+	})
+	wd.Add("relative", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	wd.Add("scroll", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	wd.Add("frame", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	wd.Add("grid", Widget{
+		SizeW: SizeFill,
+		SizeH: SizeFill,
+	})
+	return
+}
+
+// ResolveScreen lays out the root view of the screen in the given size.
+// Sizes of the texts are estimated with the strings of the language.
+func ResolveScreen(mock *Mock, screen *Screen, lang string, width, height int) (f layout.Frame, ok bool) {
+	if len(screen.Layout) == 0 {
+		return f, false
+	}
+	node := newLayoutNode(mock, &screen.Layout[0], lang)
+	return layout.Resolve(&node, width, height), true
+}
+
+// Converts the view tree into the layout nodes.
+// Sub nodes are kept even if the type is unknown
+// so that the frames can be walked with the views in parallel.
+func newLayoutNode(mock *Mock, view *View, lang string) layout.Node {
+	widget := lwd.Get(view.Type)
+	node := layout.Node{
+		Id:      view.Id,
+		Type:    view.Type,
+		SizeW:   view.SizeW,
+		SizeH:   view.SizeH,
+		AlignH:  view.AlignH,
+		AlignV:  view.AlignV,
+		Gravity: view.Gravity,
+		Below:   view.Below,
+		Margin:  convertLayoutDimension(view.Margin),
+		Padding: convertLayoutDimension(view.Padding),
+		Weight:  view.Weight,
+		Columns: view.Columns,
+		Rows:    view.Rows,
+		Span:    view.Span,
+	}
+	if view.Type == "linear" || view.Type == "scroll" {
+		node.Orientation = viewOrientation(view)
+	}
+	if node.SizeW == "" {
+		node.SizeW = widget.SizeW
+	}
+	if node.SizeH == "" {
+		node.SizeH = widget.SizeH
+	}
+	if node.Gravity == "" {
+		node.Gravity = widget.Gravity
+	}
+	switch view.Type {
+	case "label":
+		node.ContentW, node.ContentH = estimateTextWidth(LocalizedString(mock, lang, view.Label)), 21
+	case "button":
+		node.ContentW, node.ContentH = estimateTextWidth(LocalizedString(mock, lang, view.Label))+16, 30
+	case "input":
+		node.ContentW, node.ContentH = estimateTextWidth(LocalizedString(mock, lang, view.Hint))+16, 30
+	case "switch":
+		node.ContentW, node.ContentH = estimateTextWidth(LocalizedString(mock, lang, view.Label))+59, 31
+	case "checkbox":
+		node.ContentW, node.ContentH = estimateTextWidth(LocalizedString(mock, lang, view.Label))+26, 30
+	case "radio_group":
+		// Options are shown as the segments
+		for _, option := range view.Options {
+			node.ContentW += estimateTextWidth(LocalizedString(mock, lang, option)) + 16
+		}
+		node.ContentH = 29
+	case "picker":
+		// Wide enough for the longest item and the arrow
+		items := LocalizedArray(mock, lang, view.Array)
+		if items == nil {
+			items = LocalizedArray(mock, "base", view.Array)
+		}
+		for _, item := range items {
+			node.ContentW = max(node.ContentW, estimateTextWidth(item)+32)
+		}
+		node.ContentH = 30
+	case "progress":
+		node.ContentH = 2
+	case "spinner_indicator":
+		node.ContentW, node.ContentH = 20, 20
+	case "image":
+		// Image files are not read here, so the size is fixed unless it's a placeholder
+		node.ContentW, node.ContentH = imageDefaultSize, imageDefaultSize
+		if w, h, ok := imagePlaceholderSize(view); ok {
+			node.ContentW, node.ContentH = w, h
+		}
+	}
+	for i := range view.Sub {
+		node.Sub = append(node.Sub, newLayoutNode(mock, &view.Sub[i], lang))
+	}
+	return node
+}
+
+// Linear layouts stack the sub views and scroll views scroll the content
+// vertically unless the orientation is specified.
+func viewOrientation(view *View) string {
+	if view.Orientation == "" {
+		return OrientationVertical
+	}
+	return view.Orientation
+}
+
+// Weight of the view in the linear layout parent.
+// Views which fill the horizontal linear layout share the width equally,
+// and views which fill the vertical one share the height with the weighted siblings.
+func linearWeight(parent, view *View, widget Widget) float64 {
+	if parent == nil || parent.Type != "linear" {
+		return 0
+	}
+	if 0 < view.Weight {
+		return view.Weight
+	}
+	horizontal := viewOrientation(parent) == OrientationHorizontal
+	size, defaultSize := view.SizeH, widget.SizeH
+	if horizontal {
+		size, defaultSize = view.SizeW, widget.SizeW
+	}
+	if size == "" {
+		size = defaultSize
+	}
+	if size != SizeFill {
+		return 0
+	}
+	for _, sv := range parent.Sub {
+		if horizontal || 0 < sv.Weight {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Cell of the view in the grid parent.
+// The view must be one of the sub views of the parent.
+func gridCell(parent, view *View) layout.Cell {
+	spans := make([]int, len(parent.Sub))
+	index := 0
+	for i := range parent.Sub {
+		spans[i] = parent.Sub[i].Span
+		if &parent.Sub[i] == view {
+			index = i
+		}
+	}
+	return layout.GridCells(parent.Columns, spans)[index]
+}
+
+// Converts the dimension to points. "normal" is the standard spacing.
+func convertLayoutDimension(value string) int {
+	if value == "normal" {
+		return 16
+	}
+	d, _ := strconv.Atoi(value)
+	return d
+}
+
+// Texts are not rendered here, so the width is estimated.
+// Wide characters such as CJK take about twice the width of the others.
+func estimateTextWidth(s string) (w int) {
+	for _, r := range s {
+		if 0x1100 <= r {
+			w += 17
+		} else {
+			w += 9
+		}
+	}
+	return
+}
+
+// LocalizedString finds the string for the language, or returns the ID if not defined.
+func LocalizedString(mock *Mock, lang, id string) string {
+	for _, s := range mock.Strings {
+		if s.Lang != lang {
+			continue
+		}
+		for _, def := range s.Defs {
+			if def.Id == id {
+				return def.Value
+			}
+		}
+	}
+	return id
+}
+
+// LocalizedArray finds the items of the array for the language, or returns nil if not defined.
+func LocalizedArray(mock *Mock, lang, id string) []string {
+	for _, s := range mock.Strings {
+		if s.Lang != lang {
+			continue
+		}
+		for _, a := range s.Arrays {
+			if a.Id == id {
+				return a.Items
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ksoichiro/mocker/layout"
+)
+
+// Types of the views in the row which show the texts of the items
+var listTextTypes = []string{"label", "button", "input", "switch", "checkbox"}
+
+// LoadFixtures reads the items of the lists from the fixture files
+// in inDir. Fixture is a JSON array of the objects like the inline items.
+func LoadFixtures(mock *Mock, inDir string) (errs []error) {
+	for i := range mock.Screens {
+		screen := &mock.Screens[i]
+		for _, view := range collectListViews(screen) {
+			if view.Fixture == "" {
+				continue
+			}
+			if view.Items != nil {
+				errs = append(errs, fmt.Errorf("screen %s: view %s: items and fixture cannot be used together", screen.Id, view.Id))
+				continue
+			}
+			b, err := ioutil.ReadFile(filepath.Join(inDir, filepath.FromSlash(view.Fixture)))
+			if err == nil {
+				err = json.Unmarshal(b, &view.Items)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("screen %s: view %s: cannot load fixture: %v", screen.Id, view.Id, err))
+			}
+		}
+	}
+	return
+}
+
+// Collects the lists in the screen.
+func collectListViews(screen *Screen) (views []*View) {
+	walkViews(screen, func(view *View) {
+		if view.Type == "list" {
+			views = append(views, view)
+		}
+	})
+	return
+}
+
+func hasListViews(mock *Mock) bool {
+	for i := range mock.Screens {
+		if 0 < len(collectListViews(&mock.Screens[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRow returns the row template of the list.
+// Height of the row wraps the contents unless it's specified.
+func ListRow(view *View) View {
+	var row View
+	if view.Row != nil {
+		row = *view.Row
+	}
+	if row.SizeH == "" {
+		row.SizeH = SizeWrap
+	}
+	return row
+}
+
+// RowWithItem copies the row with the texts of the item.
+// Texts are set to the labels and shown as they are,
+// since they are not defined as the strings.
+func RowWithItem(row View, item map[string]string) View {
+	if text, ok := item[row.Id]; ok && row.Id != "" {
+		row.Label = text
+	}
+	sub := make([]View, len(row.Sub))
+	for i := range row.Sub {
+		sub[i] = RowWithItem(row.Sub[i], item)
+	}
+	row.Sub = sub
+	return row
+}
+
+// ResolveRow lays out the row of the list in the given width.
+// Height of the row is measured from the contents.
+func ResolveRow(mock *Mock, row *View, lang string, width int) layout.Frame {
+	node := newLayoutNode(mock, row, lang)
+	_, h := layout.Measure(&node, width)
+	return layout.Resolve(&node, width, h)
+}
+
+// Finds the IDs of the views in the row which show the texts of the items
+// in the order of the views.
+func listColumns(view *View) (ids []string) {
+	var collect func(v *View)
+	collect = func(v *View) {
+		if v.Id != "" && contains(listTextTypes, v.Type) {
+			ids = append(ids, v.Id)
+		}
+		for i := range v.Sub {
+			collect(&v.Sub[i])
+		}
+	}
+	row := ListRow(view)
+	collect(&row)
+	return
+}
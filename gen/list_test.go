@@ -0,0 +1,73 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mocker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "fixtures"), 0777)
+	ioutil.WriteFile(filepath.Join(dir, "fixtures", "users.json"), []byte(`[{"name": "Alice"}, {"name": "Bob"}]`), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "fixtures", "broken.json"), []byte(`{"name": "Alice"}`), 0666)
+
+	var testcases = []struct {
+		inline  []map[string]string
+		fixture string
+		items   int
+		errors  int
+	}{
+		{nil, "", 0, 0},
+		{[]map[string]string{{"name": "Carol"}}, "", 1, 0},
+		{nil, "fixtures/users.json", 2, 0},
+		{[]map[string]string{{"name": "Carol"}}, "fixtures/users.json", 1, 1},
+		{nil, "fixtures/missing.json", 0, 1},
+		{nil, "fixtures/broken.json", 0, 1},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "users", Type: "list", Items: tc.inline, Fixture: tc.fixture},
+				}},
+			}},
+		}}
+		if errs := LoadFixtures(&mock, dir); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: fixture=%s", tc.errors, len(errs), tc.fixture)
+		}
+		if items := mock.Screens[0].Layout[0].Sub[0].Items; len(items) != tc.items {
+			t.Errorf("Expected %d items but %d: fixture=%s", tc.items, len(items), tc.fixture)
+		}
+	}
+}
+
+func TestListColumns(t *testing.T) {
+	view := View{Id: "users", Type: "list", Row: &View{Type: "linear", Sub: []View{
+		{Id: "icon", Type: "image"},
+		{Id: "name", Type: "label"},
+		{Type: "relative", Sub: []View{
+			{Id: "email", Type: "label"},
+			{Type: "label"},
+			{Id: "follow", Type: "button"},
+		}},
+	}}}
+	expect := []string{"name", "email", "follow"}
+	columns := listColumns(&view)
+	if len(columns) != len(expect) {
+		t.Fatalf("Expected %v but %v", expect, columns)
+	}
+	for i := range expect {
+		if columns[i] != expect[i] {
+			t.Errorf("Expected %v but %v", expect, columns)
+		}
+	}
+	if row := ListRow(&view); row.SizeH != SizeWrap {
+		t.Errorf("Expected the row to wrap but %s", row.SizeH)
+	}
+}
@@ -36,8 +36,20 @@ type Ios struct {
 	CompanyIdentifier string `json:"company_identifier"`
 	OrganizationName  string `json:"organization_name"`
 	DeploymentTarget  string `json:"deployment_target"`
+	Language          string
+	Layout            string
 }
 
+const (
+	IosLanguageObjC  = "objc"
+	IosLanguageSwift = "swift"
+)
+
+const (
+	IosLayoutCode       = "code"
+	IosLayoutStoryboard = "storyboard"
+)
+
 type Screen struct {
 	Id        string
 	Name      string
@@ -51,19 +63,38 @@ type LayoutOptions struct {
 }
 
 type View struct {
-	Id      string
-	Type    string
-	Sub     []View
-	Label   string
-	Hint    string
-	Gravity string
-	Below   string
-	SizeW   string `json:"size_w"`
-	SizeH   string `json:"size_h"`
-	AlignH  string `json:"align_h"`
-	AlignV  string `json:"align_v"`
-	Margin  string
-	Padding string
+	Id          string
+	Type        string
+	Sub         []View
+	Label       string
+	Hint        string
+	Gravity     string
+	Below       string
+	SizeW       string `json:"size_w"`
+	SizeH       string `json:"size_h"`
+	AlignH      string `json:"align_h"`
+	AlignV      string `json:"align_v"`
+	Margin      string
+	Padding     string
+	Orientation string
+	Weight      float64
+	Columns     int
+	Rows        int
+	Span        int
+	Value       float64
+	Hidden      bool
+	Src         string
+	Scale       string
+	Placeholder string
+	Url         string
+	Html        string
+	Options     []string
+	Checked     bool
+	Selected    string
+	Array       string
+	Row         *View
+	Items       []map[string]string
+	Fixture     string
 }
 
 type Behavior struct {
@@ -79,6 +110,8 @@ type Trigger struct {
 type Action struct {
 	Type    string
 	Transit string
+	// View to be shown or hidden
+	Widget string
 }
 
 type Launch struct {
@@ -91,11 +124,17 @@ type Color struct {
 }
 
 type String struct {
-	Lang string
-	Defs []Def
+	Lang   string
+	Defs   []Def
+	Arrays []Array
 }
 
 type Def struct {
 	Id    string
 	Value string
 }
+
+type Array struct {
+	Id    string
+	Items []string
+}
@@ -0,0 +1,25 @@
+package gen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksoichiro/mocker/encoding/mockerfile"
+)
+
+// Loads the sample Mockerfile in the root of the repository.
+func loadSampleMock(t *testing.T) Mock {
+	b, err := ioutil.ReadFile(filepath.Join("..", "Mockerfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mock Mock
+	if err := mockerfile.Unmarshal(b, &mock); err != nil {
+		t.Fatal(err)
+	}
+	if errs := Validate(&mock); 0 < len(errs) {
+		t.Fatalf("Invalid sample: %v", errs)
+	}
+	return mock
+}
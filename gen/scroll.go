@@ -0,0 +1,24 @@
+package gen
+
+// Finds whether the screen has the scroll views,
+// which need to care about the keyboard.
+func hasScrollViews(screen *Screen) bool {
+	var find func(view *View) bool
+	find = func(view *View) bool {
+		if view.Type == "scroll" {
+			return true
+		}
+		for i := range view.Sub {
+			if find(&view.Sub[i]) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range screen.Layout {
+		if find(&screen.Layout[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+package gen
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type SpecGenerator struct {
+	opt  *Options
+	mock *Mock
+}
+
+// Line of the view tree
+type specView struct {
+	depth int
+	view  *View
+	props []string
+}
+
+// String used in the screen with the values in each language.
+// Value is empty if it's not translated.
+type specString struct {
+	id     string
+	values []string
+}
+
+func (g *SpecGenerator) Generate() {
+	outDir := g.opt.OutDir
+
+	var wg sync.WaitGroup
+
+	// Generate index
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genSpecIndex(mock, dir)
+	}(g.mock, outDir)
+
+	// Generate pages for screens
+	for _, screen := range g.mock.Screens {
+		wg.Add(1)
+		go func(mock *Mock, dir string, screen Screen) {
+			defer wg.Done()
+			genSpecScreen(mock, dir, screen)
+		}(g.mock, outDir, screen)
+	}
+
+	// Generate color swatches to be shown in Markdown
+	for _, c := range g.mock.Colors {
+		wg.Add(1)
+		go func(dir string, c Color) {
+			defer wg.Done()
+			var buf CodeBuffer
+			genCodeSpecSwatch(c, &buf)
+			genFile(&buf, filepath.Join(dir, "colors", c.Id+".svg"))
+		}(outDir, c)
+	}
+
+	wg.Wait()
+}
+
+func genSpecIndex(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeSpecIndexMarkdown(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "index.md"))
+	buf = CodeBuffer{}
+	genCodeSpecIndexHtml(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "index.html"))
+}
+
+func genSpecScreen(mock *Mock, dir string, screen Screen) {
+	var buf CodeBuffer
+	genCodeSpecScreenMarkdown(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, screen.Id+".md"))
+	buf = CodeBuffer{}
+	genCodeSpecScreenHtml(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, screen.Id+".html"))
+}
+
+// Metadata of the app as pairs of the key and the value.
+func specMeta(mock *Mock) [][2]string {
+	a := mock.Meta.Android
+	i := mock.Meta.Ios
+	return [][2]string{
+		{"Name", mock.Name},
+		{"Launch screen", mock.Launch.Screen},
+		{"Android package", a.Package},
+		{"Android gradle plugin version", a.GradlePluginVersion},
+		{"Android build tools version", a.BuildToolsVersion},
+		{"Android min SDK version", strconv.Itoa(a.MinSdkVersion)},
+		{"Android target SDK version", strconv.Itoa(a.TargetSdkVersion)},
+		{"Android compile SDK version", a.CompileSdkVersion},
+		{"Android version code", strconv.Itoa(a.VersionCode)},
+		{"Android version name", a.VersionName},
+		{"iOS project", i.Project},
+		{"iOS company identifier", i.CompanyIdentifier},
+		{"iOS organization name", i.OrganizationName},
+		{"iOS class prefix", i.ClassPrefix},
+		{"iOS deployment target", iosDeploymentTarget(mock)},
+		{"iOS language", i.Language},
+		{"iOS layout", i.Layout},
+	}
+}
+
+func specLanguages(mock *Mock) (langs []string) {
+	for _, s := range mock.Strings {
+		langs = append(langs, s.Lang)
+	}
+	return
+}
+
+// Flattens the view tree with the layout params.
+// Sizes are shown with the default values if not defined.
+func specViews(screen *Screen) (views []specView) {
+	var walk func(view *View, depth int)
+	walk = func(view *View, depth int) {
+		widget := lwd.Get(view.Type)
+		sizeW, sizeH := view.SizeW, view.SizeH
+		if sizeW == "" {
+			sizeW = widget.SizeW
+		}
+		if sizeH == "" {
+			sizeH = widget.SizeH
+		}
+		props := []string{fmt.Sprintf("size: %s x %s", sizeW, sizeH)}
+		for _, p := range [][2]string{
+			{"margin", view.Margin},
+			{"padding", view.Padding},
+			{"gravity", view.Gravity},
+			{"align_h", view.AlignH},
+			{"align_v", view.AlignV},
+			{"below", view.Below},
+			{"label", view.Label},
+			{"hint", view.Hint},
+			{"options", strings.Join(view.Options, ", ")},
+			{"array", view.Array},
+		} {
+			if p[1] != "" {
+				props = append(props, p[0]+": "+p[1])
+			}
+		}
+		views = append(views, specView{depth, view, props})
+		for i := range view.Sub {
+			walk(&view.Sub[i], depth+1)
+		}
+		if view.Row != nil {
+			row := ListRow(view)
+			walk(&row, depth+1)
+		}
+	}
+	for i := range screen.Layout {
+		walk(&screen.Layout[i], 0)
+	}
+	return
+}
+
+// Collects the strings used by the views in the screen.
+// String arrays are shown as the IDs with "[]" and the items joined with commas.
+func specStrings(mock *Mock, screen *Screen) (strs []specString) {
+	found := map[string]bool{}
+	foundArrays := map[string]bool{}
+	for _, v := range specViews(screen) {
+		for _, id := range append([]string{v.view.Label, v.view.Hint}, v.view.Options...) {
+			if id == "" || found[id] {
+				continue
+			}
+			found[id] = true
+			s := specString{id: id}
+			for _, lang := range specLanguages(mock) {
+				value := ""
+				if specDefined(mock, lang, id) {
+					value = LocalizedString(mock, lang, id)
+				}
+				s.values = append(s.values, value)
+			}
+			strs = append(strs, s)
+		}
+		if id := v.view.Array; id != "" && !foundArrays[id] {
+			foundArrays[id] = true
+			s := specString{id: id + "[]"}
+			for _, lang := range specLanguages(mock) {
+				s.values = append(s.values, strings.Join(LocalizedArray(mock, lang, id), ", "))
+			}
+			strs = append(strs, s)
+		}
+	}
+	return
+}
+
+// LocalizedString cannot tell if the string is defined
+// because it returns the ID instead.
+func specDefined(mock *Mock, lang, id string) bool {
+	for _, s := range mock.Strings {
+		if s.Lang != lang {
+			continue
+		}
+		for _, def := range s.Defs {
+			if def.Id == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func specColor(c Color) string {
+	a, r, g, b := hexToInt(c.Value)
+	return fmt.Sprintf("rgba(%d, %d, %d, %.3f)", r, g, b, float64(a)/255.0)
+}
+
+var specMarkdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `|`, `\|`,
+	`[`, `\[`, `]`, `\]`, `<`, `&lt;`, `>`, `&gt;`, "\n", " ")
+
+func specMarkdownEscape(s string) string {
+	return specMarkdownReplacer.Replace(s)
+}
+
+func genCodeSpecIndexMarkdown(mock *Mock, buf *CodeBuffer) {
+	buf.add("# %s\n", specMarkdownEscape(mock.Name))
+	buf.add("## Metadata\n")
+	buf.add("| Key | Value |")
+	buf.add("| --- | --- |")
+	for _, m := range specMeta(mock) {
+		buf.add("| %s | %s |", m[0], specMarkdownEscape(m[1]))
+	}
+	buf.add("\n## Colors\n")
+	buf.add("| ID | Value | Swatch |")
+	buf.add("| --- | --- | --- |")
+	for _, c := range mock.Colors {
+		buf.add("| %s | %s | ![%s](colors/%s.svg) |",
+			specMarkdownEscape(c.Id), specColor(c), specMarkdownEscape(c.Id), c.Id)
+	}
+	buf.add("\n## Screens\n")
+	for _, screen := range mock.Screens {
+		buf.add("- [%s](%s.md) (%s)", specMarkdownEscape(screen.Name), screen.Id, specMarkdownEscape(screen.Id))
+	}
+}
+
+func genCodeSpecScreenMarkdown(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add("# %s\n", specMarkdownEscape(screen.Name))
+	buf.add("ID: %s\n", specMarkdownEscape(screen.Id))
+	buf.add("## Views\n")
+	for _, v := range specViews(&screen) {
+		id := ""
+		if v.view.Id != "" {
+			id = fmt.Sprintf(" `%s`", v.view.Id)
+		}
+		buf.add("%s- **%s**%s: %s", strings.Repeat("    ", v.depth), v.view.Type, id, specMarkdownEscape(strings.Join(v.props, ", ")))
+	}
+
+	buf.add("\n## Behaviors\n")
+	buf.add("| Trigger | Widget | Action | Transit |")
+	buf.add("| --- | --- | --- | --- |")
+	for _, b := range screen.Behaviors {
+		transit := specMarkdownEscape(b.Action.Transit)
+		if findScreen(mock, b.Action.Transit) != nil {
+			transit = fmt.Sprintf("[%s](%s.md)", transit, b.Action.Transit)
+		}
+		buf.add("| %s | %s | %s | %s |",
+			specMarkdownEscape(b.Trigger.Type), specMarkdownEscape(b.Trigger.Widget), specMarkdownEscape(b.Action.Type), transit)
+	}
+
+	buf.add("\n## Strings\n")
+	langs := specLanguages(mock)
+	buf.add("| ID | %s |", strings.Join(langs, " | "))
+	buf.add("| --- |%s", strings.Repeat(" --- |", len(langs)))
+	for _, s := range specStrings(mock, &screen) {
+		var values []string
+		for _, v := range s.values {
+			if v == "" {
+				// Missing translations are highlighted
+				values = append(values, "**MISSING**")
+			} else {
+				values = append(values, specMarkdownEscape(v))
+			}
+		}
+		buf.add("| %s | %s |", specMarkdownEscape(s.id), strings.Join(values, " | "))
+	}
+}
+
+func genCodeSpecHtmlHeader(title string, buf *CodeBuffer) {
+	buf.add(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 32px; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #cccccc; padding: 4px 8px; text-align: left; }
+ul.views { font-family: monospace; }
+td.missing { background: #ffcccc; color: #cc0000; font-weight: bold; }
+.swatch { display: inline-block; width: 48px; height: 24px; border: 1px solid #cccccc; }
+</style>
+</head>
+<body>`, html.EscapeString(title))
+}
+
+func genCodeSpecIndexHtml(mock *Mock, buf *CodeBuffer) {
+	genCodeSpecHtmlHeader(mock.Name, buf)
+	buf.add(`<h1>%s</h1>
+<h2>Metadata</h2>
+<table>
+<tr><th>Key</th><th>Value</th></tr>`, html.EscapeString(mock.Name))
+	for _, m := range specMeta(mock) {
+		buf.add(`<tr><td>%s</td><td>%s</td></tr>`, m[0], html.EscapeString(m[1]))
+	}
+	buf.add(`</table>
+<h2>Colors</h2>
+<table>
+<tr><th>ID</th><th>Value</th><th>Swatch</th></tr>`)
+	for _, c := range mock.Colors {
+		buf.add(`<tr><td>%s</td><td>%s</td><td><span class="swatch" style="background: %s"></span></td></tr>`,
+			html.EscapeString(c.Id), specColor(c), specColor(c))
+	}
+	buf.add(`</table>
+<h2>Screens</h2>
+<ul>`)
+	for _, screen := range mock.Screens {
+		buf.add(`<li><a href="%s.html">%s</a> (%s)</li>`,
+			html.EscapeString(screen.Id), html.EscapeString(screen.Name), html.EscapeString(screen.Id))
+	}
+	buf.add(`</ul>
+</body>
+</html>`)
+}
+
+func genCodeSpecScreenHtml(mock *Mock, screen Screen, buf *CodeBuffer) {
+	genCodeSpecHtmlHeader(screen.Name, buf)
+	buf.add(`<p><a href="index.html">%s</a></p>
+<h1>%s</h1>
+<p>ID: %s</p>
+<h2>Views</h2>`, html.EscapeString(mock.Name), html.EscapeString(screen.Name), html.EscapeString(screen.Id))
+	depth := -1
+	for _, v := range specViews(&screen) {
+		for ; depth < v.depth; depth++ {
+			buf.add(`<ul class="views">`)
+		}
+		for ; v.depth < depth; depth-- {
+			buf.add(`</ul>`)
+		}
+		id := ""
+		if v.view.Id != "" {
+			id = fmt.Sprintf(" <code>%s</code>", html.EscapeString(v.view.Id))
+		}
+		buf.add(`<li><b>%s</b>%s: %s</li>`, html.EscapeString(v.view.Type), id, html.EscapeString(strings.Join(v.props, ", ")))
+	}
+	for ; 0 <= depth; depth-- {
+		buf.add(`</ul>`)
+	}
+
+	buf.add(`<h2>Behaviors</h2>
+<table>
+<tr><th>Trigger</th><th>Widget</th><th>Action</th><th>Transit</th></tr>`)
+	for _, b := range screen.Behaviors {
+		transit := html.EscapeString(b.Action.Transit)
+		if findScreen(mock, b.Action.Transit) != nil {
+			transit = fmt.Sprintf(`<a href="%s.html">%s</a>`, transit, transit)
+		}
+		buf.add(`<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			html.EscapeString(b.Trigger.Type), html.EscapeString(b.Trigger.Widget), html.EscapeString(b.Action.Type), transit)
+	}
+
+	buf.add(`</table>
+<h2>Strings</h2>
+<table>`)
+	header := ""
+	for _, lang := range specLanguages(mock) {
+		header += "<th>" + html.EscapeString(lang) + "</th>"
+	}
+	buf.add(`<tr><th>ID</th>%s</tr>`, header)
+	for _, s := range specStrings(mock, &screen) {
+		row := ""
+		for _, v := range s.values {
+			if v == "" {
+				// Missing translations are highlighted
+				row += `<td class="missing">MISSING</td>`
+			} else {
+				row += "<td>" + html.EscapeString(v) + "</td>"
+			}
+		}
+		buf.add(`<tr><td>%s</td>%s</tr>`, html.EscapeString(s.id), row)
+	}
+	buf.add(`</table>
+</body>
+</html>`)
+}
+
+func genCodeSpecSwatch(c Color, buf *CodeBuffer) {
+	a, r, g, b := hexToInt(c.Value)
+	buf.add(`<svg xmlns="http://www.w3.org/2000/svg" width="48" height="24">
+    <rect x="0.5" y="0.5" width="47" height="23" fill="#%02x%02x%02x" fill-opacity="%.3f" stroke="#cccccc"/>
+</svg>`, r, g, b, float64(a)/255.0)
+}
@@ -0,0 +1,95 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecStrings(t *testing.T) {
+	mock := Mock{
+		Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "title", Type: "label", Label: "title"},
+					{Id: "name", Type: "input", Hint: "hint_name"},
+					{Id: "again", Type: "label", Label: "title"},
+				}},
+			}},
+		},
+		Strings: []String{
+			{Lang: "base", Defs: []Def{{Id: "title", Value: "Title"}, {Id: "hint_name", Value: "hint_name"}}},
+			{Lang: "ja", Defs: []Def{{Id: "title", Value: "タイトル"}}},
+		},
+	}
+	strs := specStrings(&mock, &mock.Screens[0])
+	if len(strs) != 2 {
+		t.Fatalf("Expected 2 strings but %d", len(strs))
+	}
+	// The value same as the ID is defined, and the missing one is empty
+	if got := strings.Join(strs[1].values, ","); got != "hint_name," {
+		t.Errorf("Expected missing translation but %q", got)
+	}
+
+	var buf CodeBuffer
+	genCodeSpecScreenMarkdown(&mock, mock.Screens[0], &buf)
+	md := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"    - **label** `title`: size: fill x wrap, label: title",
+		"| hint\\_name | hint\\_name | **MISSING** |",
+	} {
+		if !strings.Contains(md, expect) {
+			t.Errorf("Expected %q in\n%s", expect, md)
+		}
+	}
+}
+
+func TestSpecWidgetStrings(t *testing.T) {
+	mock := Mock{
+		Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "gender", Type: "radio_group", Options: []string{"male", "female"}},
+					{Id: "fruit", Type: "picker", Array: "fruits"},
+					{Id: "users", Type: "list", Row: &View{Type: "linear", Sub: []View{
+						{Id: "name", Type: "label", Label: "user_name"},
+					}}},
+				}},
+			}},
+		},
+		Strings: []String{
+			{Lang: "base", Defs: []Def{{Id: "male", Value: "Male"}, {Id: "female", Value: "Female"}, {Id: "user_name", Value: "Name"}},
+				Arrays: []Array{{Id: "fruits", Items: []string{"Apple", "Banana"}}}},
+			{Lang: "ja", Defs: []Def{{Id: "male", Value: "男性"}}},
+		},
+	}
+	var ids []string
+	for _, s := range specStrings(&mock, &mock.Screens[0]) {
+		ids = append(ids, s.id+"="+strings.Join(s.values, ","))
+	}
+	if got, expect := strings.Join(ids, " "), "male=Male,男性 female=Female, fruits[]=Apple, Banana, user_name=Name,"; got != expect {
+		t.Errorf("Expected %q but %q", expect, got)
+	}
+
+	var buf CodeBuffer
+	genCodeSpecScreenMarkdown(&mock, mock.Screens[0], &buf)
+	md := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"    - **radio_group** `gender`: size: fill x wrap, options: male, female",
+		"    - **picker** `fruit`: size: fill x wrap, array: fruits",
+		// Row is nested in the list and wraps the contents
+		"        - **linear**: size: fill x wrap",
+		"            - **label** `name`: size: fill x wrap, label: user\\_name",
+		"| fruits\\[\\] | Apple, Banana | **MISSING** |",
+		"| female | Female | **MISSING** |",
+	} {
+		if !strings.Contains(md, expect) {
+			t.Errorf("Expected %q in\n%s", expect, md)
+		}
+	}
+
+	buf = CodeBuffer{}
+	genCodeSpecScreenHtml(&mock, mock.Screens[0], &buf)
+	if code := strings.Join(buf, "\n"); !strings.Contains(code, `<tr><td>fruits[]</td><td>Apple, Banana</td><td class="missing">MISSING</td></tr>`) {
+		t.Errorf("Expected the missing array in\n%s", code)
+	}
+}
@@ -0,0 +1,736 @@
+package gen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type SwiftUIGenerator struct {
+	opt  *Options
+	mock *Mock
+}
+
+var swd WidgetsDef
+
+func defineSwiftUIWidgets() {
+	swd = WidgetsDef{}
+	swd.Add("button", Widget{
+		Name:     "Button",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("label", Widget{
+		Name:     "Text",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("input", Widget{
+		Name:     "TextField",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("image", Widget{
+		Name:     "Image",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("switch", Widget{
+		Name:     "Toggle",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("checkbox", Widget{
+		Name:     "Toggle",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("radio_group", Widget{
+		Name:     "Picker",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("picker", Widget{
+		Name:     "Picker",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("list", Widget{
+		Name:     "List",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("web", Widget{
+		Name:     "WebPage",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("linear", Widget{
+		Name:        "VStack",
+		Textable:    false,
+		Orientation: OrientationVertical,
+		SizeW:       SizeFill,
+		SizeH:       SizeFill,
+	})
+	swd.Add("relative", Widget{
+		Name:     "ZStack",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("scroll", Widget{
+		Name:     "ScrollView",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("frame", Widget{
+		Name:     "ZStack",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("grid", Widget{
+		Name:     "Grid",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	swd.Add("progress", Widget{
+		Name:     "ProgressView",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	swd.Add("spinner_indicator", Widget{
+		Name:     "ProgressView",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+}
+
+func (g *SwiftUIGenerator) Generate() {
+	defineSwiftUIWidgets()
+
+	outDir := g.opt.OutDir
+	projectDir := filepath.Join(outDir, g.mock.Meta.Ios.Project)
+
+	var wg sync.WaitGroup
+
+	// Generate App
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genSwiftUIApp(mock, dir)
+	}(g.mock, projectDir)
+
+	// Generate navigation
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genSwiftUINavigation(mock, dir)
+	}(g.mock, projectDir)
+
+	// Generate Views
+	for _, screen := range g.mock.Screens {
+		wg.Add(1)
+		go func(mock *Mock, dir string, screen Screen) {
+			defer wg.Done()
+			genSwiftUIView(mock, dir, screen)
+		}(g.mock, projectDir, screen)
+	}
+
+	// Generate resources
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genIosLocalizableStrings(mock, dir)
+	}(g.mock, outDir)
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genSwiftUIColorSets(mock, dir)
+	}(g.mock, projectDir)
+	wg.Add(1)
+	go func(mock *Mock, inDir, dir string) {
+		defer wg.Done()
+		genXcAssetsImageSets(mock, inDir, filepath.Join(dir, "Assets.xcassets"))
+	}(g.mock, g.opt.InDir, projectDir)
+	if hasWebViews(g.mock) {
+		wg.Add(1)
+		go func(mock *Mock, inDir, dir string) {
+			defer wg.Done()
+			genSwiftUIWebView(mock, inDir, dir)
+		}(g.mock, g.opt.InDir, projectDir)
+	}
+
+	wg.Wait()
+}
+
+func genSwiftUIApp(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeSwiftUIApp(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.ClassPrefix+"App.swift"))
+}
+
+func genCodeSwiftUIApp(mock *Mock, buf *CodeBuffer) {
+	buf.add(`import SwiftUI
+
+@main
+struct %sApp: App {
+    var body: some Scene {
+        WindowGroup {
+            %sRootView()
+        }
+    }
+}`,
+		mock.Meta.Ios.ClassPrefix,
+		mock.Meta.Ios.ClassPrefix)
+}
+
+// Web views are wrapped with the representable view,
+// and the assets are copied to be added as the folder references.
+// The view is not suffixed with "View" not to conflict with the screens.
+func genSwiftUIWebView(mock *Mock, inDir, dir string) {
+	var buf CodeBuffer
+	genCodeSwiftUIWebView(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.ClassPrefix+"WebPage.swift"))
+	for _, asset := range collectWebViewAssets(mock) {
+		src := filepath.Join(inDir, AssetsDir, asset.Path)
+		if err := copyTree(src, filepath.Join(dir, asset.Path)); err != nil {
+			fmt.Println("Error copying file", err)
+		}
+	}
+}
+
+func genCodeSwiftUIWebView(mock *Mock, buf *CodeBuffer) {
+	buf.add(`import SwiftUI
+import WebKit
+
+// WKWebView which loads the HTML file in the bundle or the page
+struct %sWebPage: UIViewRepresentable {
+    var html: String?
+    var url: String?
+
+    init(html: String) {
+        self.html = html
+    }
+
+    init(url: String) {
+        self.url = url
+    }
+
+    func makeUIView(context: Context) -> WKWebView {
+        let web = WKWebView()
+        // Pages can read the CSS, JavaScript and images copied with them
+        if let html = html, let resourceUrl = Bundle.main.resourceURL {
+            web.loadFileURL(resourceUrl.appendingPathComponent(html), allowingReadAccessTo: resourceUrl)
+        } else if let string = url, let url = URL(string: string) {
+            web.load(URLRequest(url: url))
+        }
+        return web
+    }
+
+    func updateUIView(_ web: WKWebView, context: Context) {
+    }
+}`, mock.Meta.Ios.ClassPrefix)
+}
+
+func genSwiftUINavigation(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeSwiftUINavigation(mock, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.ClassPrefix+"Navigation.swift"))
+}
+
+func genCodeSwiftUINavigation(mock *Mock, buf *CodeBuffer) {
+	cp := mock.Meta.Ios.ClassPrefix
+	buf.add(`import SwiftUI
+
+/// Screens to be pushed to the navigation stack
+enum Route: Hashable {`)
+	for _, screen := range mock.Screens {
+		buf.add(`    case %s`, swiftIdentifier(screen.Id))
+	}
+	buf.add(`}
+
+struct %sRootView: View {
+    @State private var path: [Route] = []
+
+    var body: some View {
+        NavigationStack(path: $path) {
+            %s%sView(path: $path)
+                .navigationDestination(for: Route.self) { route in
+                    switch route {`,
+		cp,
+		cp,
+		strings.Title(mock.Launch.Screen))
+	for _, screen := range mock.Screens {
+		buf.add(`                    case .%s:
+                        %s%sView(path: $path)`,
+			swiftIdentifier(screen.Id),
+			cp,
+			strings.Title(screen.Id))
+	}
+	buf.add(`                    }
+                }
+        }
+    }
+}`)
+}
+
+func genSwiftUIView(mock *Mock, dir string, screen Screen) {
+	var buf CodeBuffer
+	genCodeSwiftUIView(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, mock.Meta.Ios.ClassPrefix+strings.Title(screen.Id)+"View.swift"))
+}
+
+func genCodeSwiftUIView(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`import SwiftUI
+
+struct %s%sView: View {
+    @Binding var path: [Route]`,
+		mock.Meta.Ios.ClassPrefix,
+		strings.Title(screen.Id))
+
+	// Inputs and controls need states to hold their values
+	views := []View{}
+	for i := range screen.Layout {
+		aggregateSwiftUIWidgets(&screen.Layout[i], &views)
+	}
+	for _, view := range views {
+		if view.Id == "" {
+			continue
+		}
+		switch view.Type {
+		case "input":
+			buf.add(`    @State private var %s = ""`, swiftIdentifier(view.Id))
+		case "switch", "checkbox":
+			buf.add(`    @State private var %s = %t`, swiftIdentifier(view.Id), view.Checked)
+		case "radio_group":
+			buf.add(`    @State private var %s = %s`, swiftIdentifier(view.Id), quoteString(view.Selected))
+		case "picker":
+			buf.add(`    @State private var %s = 0`, swiftIdentifier(view.Id))
+		}
+	}
+	// Views shown or hidden by the buttons need states of the visibilities
+	for _, view := range findToggledViews(&screen) {
+		if swd.Has(view.Type) {
+			buf.add(`    @State private var %s = %t`, swiftUIHiddenState(view), view.Hidden)
+		}
+	}
+
+	buf.add(`
+    var body: some View {`)
+	if 0 < len(screen.Layout) {
+		// Only parse root view
+		genSwiftUIViewRecur(mock, &screen, &screen.Layout[0], true, buf, 2)
+	} else {
+		buf.add(`        EmptyView()`)
+	}
+	buf.add(`        .navigationTitle(%s)
+    }
+}`, quoteString(screen.Name))
+}
+
+func aggregateSwiftUIWidgets(current *View, views *[]View) {
+	if !swd.Has(current.Type) {
+		return
+	}
+	*views = append(*views, *current)
+	for i := range current.Sub {
+		aggregateSwiftUIWidgets(&current.Sub[i], views)
+	}
+}
+
+func genSwiftUIViewRecur(mock *Mock, screen *Screen, view *View, stacked bool, buf *CodeBuffer, indent int) {
+	if !swd.Has(view.Type) {
+		return
+	}
+	widget := swd.Get(view.Type)
+
+	t := tab(indent)
+	switch view.Type {
+	case "label":
+		buf.add(`%sText(%s)`, t, quoteString(view.Label))
+	case "button":
+		buf.add(`%sButton(%s) {`, t, quoteString(view.Label))
+		for _, statement := range swiftUIActionStatements(mock, screen, view.Id, "click") {
+			buf.add(`%s    %s`, t, statement)
+		}
+		buf.add(`%s}`, t)
+	case "switch", "checkbox":
+		isOn := fmt.Sprintf(".constant(%t)", view.Checked)
+		if view.Id != "" {
+			isOn = "$" + swiftIdentifier(view.Id)
+		}
+		buf.add(`%sToggle(%s, isOn: %s)`, t, quoteString(view.Label), isOn)
+		if view.Type == "checkbox" {
+			// iOS has no check boxes, and the toggle is drawn as the button
+			buf.add(`%s    .toggleStyle(.button)`, t)
+		}
+		genSwiftUIOnChange(mock, screen, view, "changed", buf, indent+1)
+	case "radio_group":
+		buf.add(`%sPicker("", selection: $%s) {`, t, swiftIdentifier(view.Id))
+		for _, option := range view.Options {
+			buf.add(`%s    Text(%s).tag(%s)`, t, quoteString(option), quoteString(option))
+		}
+		buf.add(`%s}`, t)
+		buf.add(`%s.pickerStyle(.segmented)`, t)
+		genSwiftUIOnChange(mock, screen, view, "changed", buf, indent)
+	case "picker":
+		// Items are localized with the keys flattened in the strings files
+		buf.add(`%sPicker("", selection: $%s) {`, t, swiftIdentifier(view.Id))
+		for i := range LocalizedArray(mock, "base", view.Array) {
+			buf.add(`%s    Text(%s).tag(%d)`, t, quoteString(iosArrayItemKey(view.Array, i)), i)
+		}
+		buf.add(`%s}`, t)
+		buf.add(`%s.pickerStyle(.menu)`, t)
+		genSwiftUIOnChange(mock, screen, view, "selected", buf, indent)
+	case "input":
+		text := ".constant(\"\")"
+		if view.Id != "" {
+			text = "$" + swiftIdentifier(view.Id)
+		}
+		buf.add(`%sTextField(%s, text: %s)`, t, quoteString(view.Hint), text)
+	case "image":
+		buf.add(`%sImage(%s)`, t, quoteString(imageResourceName(view)))
+		// Images keep their sizes unless they fill the parents
+		if mode := convertSwiftUIContentMode(view); mode != "" {
+			buf.add(`%s    .resizable()`, t)
+			if mode != "stretch" {
+				buf.add(`%s    .aspectRatio(contentMode: %s)`, t, mode)
+			}
+		}
+	case "list":
+		// Rows are repeated with the items, and become buttons to fire item_click
+		statements := swiftUIActionStatements(mock, screen, view.Id, "item_click")
+		buf.add(`%sList {`, t)
+		for _, item := range view.Items {
+			row := RowWithItem(ListRow(view), item)
+			if len(statements) == 0 {
+				genSwiftUIViewRecur(mock, screen, &row, true, buf, indent+1)
+				continue
+			}
+			buf.add(`%s    Button {`, t)
+			for _, statement := range statements {
+				buf.add(`%s        %s`, t, statement)
+			}
+			buf.add(`%s    } label: {`, t)
+			genSwiftUIViewRecur(mock, screen, &row, true, buf, indent+2)
+			buf.add(`%s    }`, t)
+		}
+		buf.add(`%s}`, t)
+		buf.add(`%s.listStyle(.plain)`, t)
+	case "web":
+		if view.Html != "" {
+			buf.add(`%s%sWebPage(html: %s)`, t, mock.Meta.Ios.ClassPrefix, quoteString(webViewHtmlPath(view.Html)))
+		} else {
+			buf.add(`%s%sWebPage(url: %s)`, t, mock.Meta.Ios.ClassPrefix, quoteString(view.Url))
+		}
+	case "progress":
+		buf.add(`%sProgressView(value: %g)`, t, view.Value)
+	case "spinner_indicator":
+		buf.add(`%sProgressView()`, t)
+	case "relative":
+		buf.add(`%sZStack(alignment: %s) {`, t, convertSwiftUIGravity(view, widget, true))
+		// Views chained with "below" are stacked vertically
+		for _, chain := range chainViewsBelow(view.Sub) {
+			if len(chain) == 1 {
+				genSwiftUIViewRecur(mock, screen, &chain[0], false, buf, indent+1)
+				continue
+			}
+			buf.add(`%s    VStack {`, t)
+			for i := range chain {
+				genSwiftUIViewRecur(mock, screen, &chain[i], true, buf, indent+2)
+			}
+			buf.add(`%s    }`, t)
+			// The chain is positioned with the first view
+			if alignment := swiftUIAlignment(chain[0].AlignH, chain[0].AlignV); alignment != "" {
+				buf.add(`%s    .frame(maxWidth: .infinity, maxHeight: .infinity, alignment: %s)`, t, alignment)
+			}
+		}
+		buf.add(`%s}`, t)
+	case "frame":
+		// Views overlap and are positioned with their frames
+		buf.add(`%sZStack(alignment: %s) {`, t, convertSwiftUIGravity(view, widget, true))
+		for i := range view.Sub {
+			genSwiftUIViewRecur(mock, screen, &view.Sub[i], false, buf, indent+1)
+		}
+		buf.add(`%s}`, t)
+	case "grid":
+		buf.add(`%sGrid(alignment: %s) {`, t, convertSwiftUIGravity(view, widget, true))
+		row := -1
+		for i := range view.Sub {
+			cell := gridCell(view, &view.Sub[i])
+			if cell.Row != row {
+				if 0 <= row {
+					buf.add(`%s    }`, t)
+				}
+				buf.add(`%s    GridRow {`, t)
+				row = cell.Row
+			}
+			genSwiftUIViewRecur(mock, screen, &view.Sub[i], false, buf, indent+2)
+			if 1 < cell.Span {
+				buf.add(`%s.gridCellColumns(%d)`, swiftUIModifierIndent(&view.Sub[i], indent+2), cell.Span)
+			}
+		}
+		if 0 <= row {
+			buf.add(`%s    }`, t)
+		}
+		buf.add(`%s}`, t)
+	case "scroll":
+		axes := ""
+		if viewOrientation(view) == OrientationHorizontal {
+			axes = "(.horizontal)"
+		}
+		buf.add(`%sScrollView%s {`, t, axes)
+		for i := range view.Sub {
+			genSwiftUIViewRecur(mock, screen, &view.Sub[i], true, buf, indent+1)
+		}
+		buf.add(`%s}`, t)
+	case "linear":
+		// HStack uses vertical alignment
+		if viewOrientation(view) == OrientationHorizontal {
+			alignment := ".top"
+			if view.Gravity == GravityCenter || view.Gravity == GravityCenterV {
+				alignment = ".center"
+			}
+			buf.add(`%sHStack(alignment: %s) {`, t, alignment)
+		} else {
+			buf.add(`%s%s(alignment: %s) {`, t, widget.Name, convertSwiftUIGravity(view, widget, false))
+		}
+		for i := range view.Sub {
+			genSwiftUIViewRecur(mock, screen, &view.Sub[i], true, buf, indent+1)
+		}
+		buf.add(`%s}`, t)
+	default:
+		buf.add(`%s%s(alignment: %s) {`, t, widget.Name, convertSwiftUIGravity(view, widget, false))
+		for i := range view.Sub {
+			genSwiftUIViewRecur(mock, screen, &view.Sub[i], true, buf, indent+1)
+		}
+		buf.add(`%s}`, t)
+	}
+
+	m := swiftUIModifierIndent(view, indent)
+	if view.Id != "" {
+		buf.add(`%s.accessibilityIdentifier(%s)`, m, quoteString(view.Id))
+	}
+	if view.Padding != "" {
+		buf.add(`%s.padding(%s)`, m, convertSwiftUIDimension(view.Padding))
+	}
+	if modifier := convertSwiftUIFrame(view, widget, stacked); modifier != "" {
+		buf.add(`%s%s`, m, modifier)
+	}
+	if convertSwiftUIContentMode(view) == ".fill" {
+		// The image filling the frame is cropped
+		buf.add(`%s.clipped()`, m)
+	}
+	if view.Margin != "" {
+		buf.add(`%s.padding(%s)`, m, convertSwiftUIDimension(view.Margin))
+	}
+	for _, v := range findToggledViews(screen) {
+		if v.Id == view.Id {
+			// Hidden views keep their spaces
+			buf.add(`%s.opacity(%s ? 0 : 1)`, m, swiftUIHiddenState(v))
+		}
+	}
+}
+
+// Statements of the behaviors fired by the trigger of the widget.
+func swiftUIActionStatements(mock *Mock, screen *Screen, widget, trigger string) (statements []string) {
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Widget == widget && b.Trigger.Type == trigger &&
+			b.Action.Type == "transit_forward" && findScreen(mock, b.Action.Transit) != nil {
+			statements = append(statements, "path.append(."+swiftIdentifier(b.Action.Transit)+")")
+		}
+	}
+	for _, b := range findVisibilityBehaviors(screen, widget, trigger) {
+		if target := findView(screen, b.Action.Widget); swd.Has(target.Type) {
+			statements = append(statements, fmt.Sprintf("%s = %t", swiftUIHiddenState(target), b.Action.Type == ActionHide))
+		}
+	}
+	return
+}
+
+// Controls fire the behaviors of the trigger when their states are changed.
+func genSwiftUIOnChange(mock *Mock, screen *Screen, view *View, trigger string, buf *CodeBuffer, indent int) {
+	statements := swiftUIActionStatements(mock, screen, view.Id, trigger)
+	if view.Id == "" || len(statements) == 0 {
+		return
+	}
+	t := tab(indent)
+	buf.add(`%s.onChange(of: %s) { _ in`, t, swiftIdentifier(view.Id))
+	for _, statement := range statements {
+		buf.add(`%s    %s`, t, statement)
+	}
+	buf.add(`%s}`, t)
+}
+
+// Modifiers are aligned with the closing brace of the container
+func swiftUIModifierIndent(view *View, indent int) string {
+	switch view.Type {
+	case "label", "input", "image", "switch", "checkbox", "web", "progress", "spinner_indicator":
+		return tab(indent + 1)
+	}
+	return tab(indent)
+}
+
+// Converts the scale of the image which is resized to fill the parent
+// into the content mode, or "stretch" if the aspect ratio is not kept.
+// Empty string is returned for the image in the original size.
+func convertSwiftUIContentMode(view *View) string {
+	if view.Type != "image" || (view.SizeW != SizeFill && view.SizeH != SizeFill) {
+		return ""
+	}
+	switch view.Scale {
+	case ScaleFill:
+		return ".fill"
+	case ScaleStretch:
+		return "stretch"
+	case ScaleCenter:
+		return ""
+	}
+	return ".fit"
+}
+
+func swiftUIHiddenState(view *View) string {
+	return swiftIdentifier(view.Id) + "Hidden"
+}
+
+func convertSwiftUIDimension(value string) string {
+	if value == "normal" {
+		return "16"
+	}
+	return value
+}
+
+// Converts gravity of the container into SwiftUI alignment.
+// ZStack uses 2D alignment, and VStack uses horizontal alignment.
+func convertSwiftUIGravity(view *View, widget Widget, stack2D bool) string {
+	gravity := view.Gravity
+	if gravity == "" {
+		gravity = widget.Gravity
+	}
+	switch gravity {
+	case GravityCenterV:
+		return ".leading"
+	case GravityCenter:
+		return ".center"
+	}
+	if stack2D {
+		return ".topLeading"
+	}
+	return ".leading"
+}
+
+// Converts sizes and alignments into the frame modifier.
+// Vertical alignment only works when the view is not stacked vertically.
+func convertSwiftUIFrame(view *View, widget Widget, stacked bool) string {
+	sizeW := view.SizeW
+	if sizeW == "" {
+		sizeW = widget.SizeW
+	}
+	sizeH := view.SizeH
+	if sizeH == "" {
+		sizeH = widget.SizeH
+	}
+	alignV := view.AlignV
+	if stacked {
+		alignV = ""
+	}
+	var args []string
+	if sizeW == SizeFill || view.AlignH != "" {
+		args = append(args, "maxWidth: .infinity")
+	}
+	if sizeH == SizeFill || alignV != "" {
+		args = append(args, "maxHeight: .infinity")
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	if alignment := swiftUIAlignment(view.AlignH, alignV); alignment != "" {
+		args = append(args, "alignment: "+alignment)
+	}
+	return fmt.Sprintf(".frame(%s)", strings.Join(args, ", "))
+}
+
+func swiftUIAlignment(alignH, alignV string) string {
+	h := map[string]string{AlignLeft: "Leading", AlignCenter: "", AlignRight: "Trailing"}[alignH]
+	switch alignV {
+	case AlignTop:
+		return ".top" + h
+	case AlignBottom:
+		return ".bottom" + h
+	}
+	switch alignH {
+	case AlignLeft:
+		return ".leading"
+	case AlignRight:
+		return ".trailing"
+	case AlignCenter:
+		return ".center"
+	}
+	if alignV == AlignCenter {
+		return ".center"
+	}
+	return ""
+}
+
+func genSwiftUIColorSets(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeSwiftUIAssetCatalog(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "Assets.xcassets", "Contents.json"))
+	for _, c := range mock.Colors {
+		buf = CodeBuffer{}
+		genCodeSwiftUIColorSet(c, &buf)
+		genFile(&buf, filepath.Join(dir, "Assets.xcassets", c.Id+".colorset", "Contents.json"))
+	}
+}
+
+func genCodeSwiftUIAssetCatalog(mock *Mock, buf *CodeBuffer) {
+	buf.add(`{
+  "info" : {
+    "version" : 1,
+    "author" : "xcode"
+  }
+}`)
+}
+
+func genCodeSwiftUIColorSet(c Color, buf *CodeBuffer) {
+	a, r, g, b := hexToInt(c.Value)
+	buf.add(`{
+  "colors" : [
+    {
+      "idiom" : "universal",
+      "color" : {
+        "color-space" : "srgb",
+        "components" : {
+          "red" : "0x%02X",
+          "green" : "0x%02X",
+          "blue" : "0x%02X",
+          "alpha" : "%.3f"
+        }
+      }
+    }
+  ],
+  "info" : {
+    "version" : 1,
+    "author" : "xcode"
+  }
+}`, r, g, b, float64(a)/255.0)
+}
@@ -0,0 +1,206 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenSwiftUIImage(t *testing.T) {
+	defineSwiftUIWidgets()
+	var testcases = []struct {
+		view   View
+		expect []string
+	}{
+		{View{Id: "icon", Type: "image", Src: "icon.png"}, []string{`Image("icon")`}},
+		{View{Id: "logo", Type: "image", Src: "logo.png", SizeW: SizeFill}, []string{".resizable()", ".aspectRatio(contentMode: .fit)"}},
+		{View{Id: "cover", Type: "image", Src: "cover.png", SizeW: SizeFill, SizeH: SizeFill, Scale: ScaleFill}, []string{".aspectRatio(contentMode: .fill)", ".clipped()"}},
+		{View{Id: "bg", Type: "image", Src: "bg.png", SizeW: SizeFill, Scale: ScaleStretch}, []string{".resizable()"}},
+	}
+	for _, tc := range testcases {
+		screen := Screen{Id: "top", Layout: []View{{Type: "linear", Sub: []View{tc.view}}}}
+		var buf CodeBuffer
+		genSwiftUIViewRecur(&Mock{}, &screen, &screen.Layout[0], true, &buf, 0)
+		code := strings.Join(buf, "\n")
+		for _, expect := range tc.expect {
+			if !strings.Contains(code, expect) {
+				t.Errorf("Expected %q in\n%s", expect, code)
+			}
+		}
+		if tc.view.SizeW == "" && strings.Contains(code, ".resizable()") {
+			t.Errorf("Unexpected resizable image in\n%s", code)
+		}
+		if tc.view.Scale == ScaleStretch && strings.Contains(code, ".aspectRatio") {
+			t.Errorf("Unexpected aspect ratio in\n%s", code)
+		}
+	}
+}
+
+func TestGenSwiftUIToggles(t *testing.T) {
+	defineSwiftUIWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "agree", Type: "checkbox", Label: "agree", Checked: true},
+				{Id: "notify", Type: "switch", Label: "notify"},
+				{Id: "gender", Type: "radio_group", Options: []string{"male", "female"}, Selected: "female"},
+				{Id: "detail", Type: "label", Label: "detail"},
+			}},
+		}, Behaviors: []Behavior{
+			{Trigger: Trigger{Type: "changed", Widget: "agree"}, Action: Action{Type: "hide", Widget: "detail"}},
+			{Trigger: Trigger{Type: "changed", Widget: "gender"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+		}},
+		{Id: "second"},
+	}}
+	var buf CodeBuffer
+	genCodeSwiftUIView(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"    @State private var agree = true",
+		"    @State private var notify = false",
+		`    @State private var gender = "female"`,
+		"    @State private var detailHidden = false",
+		`Toggle("agree", isOn: $agree)
+                .toggleStyle(.button)
+                .onChange(of: agree) { _ in
+                    detailHidden = true
+                }`,
+		`Toggle("notify", isOn: $notify)`,
+		`Picker("", selection: $gender) {
+                Text("male").tag("male")
+                Text("female").tag("female")
+            }
+            .pickerStyle(.segmented)
+            .onChange(of: gender) { _ in
+                path.append(.second)
+            }`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenSwiftUIPicker(t *testing.T) {
+	defineSwiftUIWidgets()
+	mock := Mock{
+		Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "country", Type: "picker", Array: "countries"},
+				}},
+			}, Behaviors: []Behavior{
+				{Trigger: Trigger{Type: "selected", Widget: "country"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+			}},
+			{Id: "second"},
+		},
+		Strings: []String{
+			{Lang: "base", Arrays: []Array{{Id: "countries", Items: []string{"Japan", "U.S."}}}},
+		},
+	}
+	var buf CodeBuffer
+	genCodeSwiftUIView(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"    @State private var country = 0",
+		`Picker("", selection: $country) {
+                Text("countries.0").tag(0)
+                Text("countries.1").tag(1)
+            }
+            .pickerStyle(.menu)
+            .onChange(of: country) { _ in
+                path.append(.second)
+            }`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenSwiftUIList(t *testing.T) {
+	defineSwiftUIWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "users", Type: "list",
+					Row:   &View{Id: "name", Type: "label", Label: "name"},
+					Items: []map[string]string{{"name": "Alice"}, {"name": "Bob"}, {"name": `Say "Hi" \o/`}}},
+				{Id: "tags", Type: "list",
+					Row:   &View{Id: "tag", Type: "label", Label: "tag"},
+					Items: []map[string]string{{"tag": "new"}}},
+			}},
+		}, Behaviors: []Behavior{
+			{Trigger: Trigger{Type: "item_click", Widget: "users"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+		}},
+		{Id: "second"},
+	}}
+	var buf CodeBuffer
+	genCodeSwiftUIView(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`List {
+                Button {
+                    path.append(.second)
+                } label: {
+                    Text("Alice")`,
+		`Text("Bob")`,
+		// Texts of the items are escaped
+		`Text("Say \"Hi\" \\o/")`,
+		// Rows without behaviors are not buttons
+		`List {
+                Text("new")`,
+		`.listStyle(.plain)`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenSwiftUISample(t *testing.T) {
+	defineSwiftUIWidgets()
+	mock := loadSampleMock(t)
+
+	var buf CodeBuffer
+	genCodeSwiftUINavigation(&mock, &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`enum Route: Hashable {
+    case top
+    case second
+}`,
+		"struct MDRootView: View {",
+		"        NavigationStack(path: $path) {",
+		`                    case .second:
+                        MDSecondView(path: $path)`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	buf = CodeBuffer{}
+	genCodeSwiftUIView(&mock, mock.Screens[0], &buf)
+	code = strings.Join(buf, "\n")
+	for _, expect := range []string{
+		"struct MDTopView: View {",
+		`    @State private var userId = ""`,
+		`TextField("hint_user_id", text: $userId)`,
+		`Button("button_next") {
+                    path.append(.second)
+                }`,
+		`.navigationTitle("Mocker Demo")`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	// Titles are escaped as the string literals
+	mock.Screens[1].Name = `Say "Hello"`
+	buf = CodeBuffer{}
+	genCodeSwiftUIView(&mock, mock.Screens[1], &buf)
+	if code := strings.Join(buf, "\n"); !strings.Contains(code, `.navigationTitle("Say \"Hello\"")`) {
+		t.Errorf("Expected the escaped title in\n%s", code)
+	}
+}
@@ -0,0 +1,210 @@
+package gen
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+var (
+	alignHValues = []string{AlignLeft, AlignCenter, AlignRight}
+	alignVValues = []string{AlignTop, AlignCenter, AlignBottom}
+	orientations = []string{OrientationVertical, OrientationHorizontal}
+	iosLanguages = []string{IosLanguageObjC, IosLanguageSwift}
+	iosLayouts   = []string{IosLayoutCode, IosLayoutStoryboard}
+	scaleValues  = []string{ScaleFit, ScaleFill, ScaleStretch, ScaleCenter}
+)
+
+// Validate checks the definitions which generators cannot handle
+// and returns all errors found.
+func Validate(mock *Mock) (errs []error) {
+	if mock.Meta.Ios.Language != "" && !contains(iosLanguages, mock.Meta.Ios.Language) {
+		errs = append(errs, fmt.Errorf("meta: unsupported ios language: %s", mock.Meta.Ios.Language))
+	}
+	if mock.Meta.Ios.Layout != "" && !contains(iosLayouts, mock.Meta.Ios.Layout) {
+		errs = append(errs, fmt.Errorf("meta: unsupported ios layout: %s", mock.Meta.Ios.Layout))
+	}
+	for _, screen := range mock.Screens {
+		for _, view := range screen.Layout {
+			validateViewRecur(mock, &screen, &view, &errs)
+		}
+	}
+	validateArrays(mock, &errs)
+	validateImageNames(mock, &errs)
+	return
+}
+
+// ValidateGenerator checks the definitions which the generator of genId
+// cannot handle in addition to Validate.
+func ValidateGenerator(mock *Mock, genId string) (errs []error) {
+	switch genId {
+	case "android":
+		// RecyclerView is not available in the older support library
+		if hasListViews(mock) && androidCompileSdkLevel(mock) < androidSupportLibraryLevel {
+			errs = append(errs, fmt.Errorf("meta: list requires compile_sdk_version android-%d or later: %s", androidSupportLibraryLevel, mock.Meta.Android.CompileSdkVersion))
+		}
+	}
+	return
+}
+
+func validateViewRecur(mock *Mock, screen *Screen, view *View, errs *[]error) {
+	if view.AlignH != "" && !contains(alignHValues, view.AlignH) {
+		*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported align_h: %s", screen.Id, view.Id, view.AlignH))
+	}
+	if view.AlignV != "" && !contains(alignVValues, view.AlignV) {
+		*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported align_v: %s", screen.Id, view.Id, view.AlignV))
+	}
+	if view.Orientation != "" && !contains(orientations, view.Orientation) {
+		*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported orientation: %s", screen.Id, view.Id, view.Orientation))
+	}
+	if view.Weight < 0 {
+		*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported weight: %g", screen.Id, view.Id, view.Weight))
+	}
+	if view.Type == "image" {
+		if view.Placeholder != "" {
+			if _, _, err := parseImageSize(view.Placeholder); err != nil {
+				*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported placeholder: %s", screen.Id, view.Id, view.Placeholder))
+			}
+		}
+		if view.Scale != "" && !contains(scaleValues, view.Scale) {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported scale: %s", screen.Id, view.Id, view.Scale))
+		}
+	}
+	if view.Type == "radio_group" {
+		if view.Id == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: radio_group requires id", screen.Id))
+		}
+		if len(view.Options) == 0 {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: options are required for radio_group", screen.Id, view.Id))
+		}
+		if view.Selected != "" && !contains(view.Options, view.Selected) {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: selected is not in options: %s", screen.Id, view.Id, view.Selected))
+		}
+	}
+	if view.Type == "picker" {
+		if view.Id == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: picker requires id", screen.Id))
+		}
+		if view.Array == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: array is required for picker", screen.Id, view.Id))
+		} else if len(LocalizedArray(mock, "base", view.Array)) == 0 {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: array is not defined in base: %s", screen.Id, view.Id, view.Array))
+		}
+	}
+	if view.Type == "list" {
+		if view.Id == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: list requires id", screen.Id))
+		}
+		if view.Row == nil {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: row is required for list", screen.Id, view.Id))
+		} else {
+			validateViewRecur(mock, screen, view.Row, errs)
+		}
+	}
+	if view.Type == "web" {
+		// Web views in the storyboard are loaded by the view controller
+		if view.Id == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: web requires id", screen.Id))
+		}
+		if view.Url == "" && view.Html == "" {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: url or html is required for web", screen.Id, view.Id))
+		}
+	}
+	if view.Type == "progress" && (view.Value < 0 || 1 < view.Value) {
+		*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported value: %g", screen.Id, view.Id, view.Value))
+	}
+	if view.Type == "scroll" {
+		if len(view.Sub) != 1 {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: scroll must have exactly one sub view but %d", screen.Id, view.Id, len(view.Sub)))
+		}
+	}
+	if view.Type == "grid" {
+		if view.Columns < 1 {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: columns are required for grid", screen.Id, view.Id))
+		}
+		if view.Rows < 0 {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported rows: %d", screen.Id, view.Id, view.Rows))
+		}
+		for _, sv := range view.Sub {
+			if sv.Span < 0 || (0 < view.Columns && view.Columns < sv.Span) {
+				*errs = append(*errs, fmt.Errorf("screen %s: view %s: unsupported span: %d", screen.Id, sv.Id, sv.Span))
+			}
+		}
+	}
+	for _, sv := range view.Sub {
+		// Only relative layout places the sub views below the siblings
+		if sv.Below != "" && view.Type != "relative" {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: below has no effect in %s", screen.Id, sv.Id, view.Type))
+		}
+		validateViewRecur(mock, screen, &sv, errs)
+	}
+}
+
+// Images are copied with the resource names, so the different images must not share the names
+// such as "icons/star.png" and "badges/star.png", or "Star.png" and "star.png".
+func validateImageNames(mock *Mock, errs *[]error) {
+	sources := map[string]string{}
+	for i := range mock.Screens {
+		screen := &mock.Screens[i]
+		walkViews(screen, func(view *View) {
+			if view.Type != "image" {
+				return
+			}
+			name, src := imageResourceName(view), imageSource(view)
+			if found, ok := sources[name]; !ok {
+				sources[name] = src
+			} else if found != src {
+				*errs = append(*errs, fmt.Errorf("screen %s: view %s: image %s has the same resource name %s as %s", screen.Id, view.Id, src, name, found))
+			}
+		})
+	}
+}
+
+// Items of the arrays are selected by the index,
+// so the translations must have the same number of items as base.
+func validateArrays(mock *Mock, errs *[]error) {
+	for _, s := range mock.Strings {
+		if s.Lang == "base" {
+			continue
+		}
+		for _, a := range s.Arrays {
+			if base := LocalizedArray(mock, "base", a.Id); len(a.Items) != len(base) {
+				*errs = append(*errs, fmt.Errorf("strings %s: array %s: %d items but %d in base", s.Lang, a.Id, len(a.Items), len(base)))
+			}
+		}
+	}
+}
+
+// ValidateAssets checks that the image files exist
+// in the assets directory under inDir.
+// Images shown as placeholders don't need the files.
+func ValidateAssets(mock *Mock, inDir string) (errs []error) {
+	for i := range mock.Screens {
+		screen := &mock.Screens[i]
+		walkViews(screen, func(view *View) {
+			validateAssetsOfView(screen, view, inDir, &errs)
+		})
+	}
+	return
+}
+
+func validateAssetsOfView(screen *Screen, view *View, inDir string, errs *[]error) {
+	if _, _, ok := imagePlaceholderSize(view); view.Type == "image" && !ok {
+		if path := findImageFiles(inDir, view.Src)[1]; !fileExists(path) {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: missing asset: %s", screen.Id, view.Id, filepath.ToSlash(filepath.Join(AssetsDir, view.Src))))
+		}
+	}
+	if view.Type == "web" && view.Html != "" {
+		if path := filepath.Join(inDir, AssetsDir, filepath.FromSlash(view.Html)); !fileExists(path) {
+			*errs = append(*errs, fmt.Errorf("screen %s: view %s: missing asset: %s", screen.Id, view.Id, filepath.ToSlash(filepath.Join(AssetsDir, view.Html))))
+		}
+	}
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
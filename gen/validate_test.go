@@ -0,0 +1,294 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateAlign(t *testing.T) {
+	var testcases = []struct {
+		alignH string
+		alignV string
+		errors int
+	}{
+		{"", "", 0},
+		{"left", "top", 0},
+		{"center", "center", 0},
+		{"right", "bottom", 0},
+		{"top", "center", 1},
+		{"center", "left", 1},
+		{"foo", "bar", 2},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "label", Type: "label", AlignH: tc.alignH, AlignV: tc.alignV},
+				}},
+			}},
+		}}
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: align_h=%s, align_v=%s", tc.errors, len(errs), tc.alignH, tc.alignV)
+		}
+	}
+}
+
+func TestValidateAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mocker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, AssetsDir, "icons"), 0777)
+	ioutil.WriteFile(filepath.Join(dir, AssetsDir, "logo.png"), []byte{}, 0666)
+	ioutil.WriteFile(filepath.Join(dir, AssetsDir, "icons", "star.png"), []byte{}, 0666)
+
+	var testcases = []struct {
+		src         string
+		placeholder string
+		errors      int
+	}{
+		{"logo.png", "", 0},
+		{"icons/star.png", "", 0},
+		{"missing.png", "", 1},
+		{"icons/logo.png", "", 1},
+		{"", "", 0},
+		{"missing.png", "320x180", 0},
+	}
+	for _, tc := range testcases {
+		mock := Mock{Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "image", Type: "image", Src: tc.src, Placeholder: tc.placeholder},
+				}},
+			}},
+		}}
+		if errs := ValidateAssets(&mock, dir); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: src=%s, placeholder=%s", tc.errors, len(errs), tc.src, tc.placeholder)
+		}
+	}
+}
+
+func TestValidateRadioGroup(t *testing.T) {
+	var testcases = []struct {
+		id       string
+		options  []string
+		selected string
+		errors   int
+	}{
+		{"gender", []string{"male", "female"}, "", 0},
+		{"gender", []string{"male", "female"}, "female", 0},
+		{"gender", []string{"male", "female"}, "other", 1},
+		{"gender", nil, "", 1},
+		{"", []string{"male", "female"}, "male", 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: tc.id, Type: "radio_group", Options: tc.options, Selected: tc.selected})
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: id=%s, options=%v, selected=%s", tc.errors, len(errs), tc.id, tc.options, tc.selected)
+		}
+	}
+}
+
+func TestValidatePicker(t *testing.T) {
+	var testcases = []struct {
+		id     string
+		array  string
+		jaSize int
+		errors int
+	}{
+		{"fruit", "fruits", 3, 0},
+		{"fruit", "fruits", 2, 1},
+		{"fruit", "colors", 3, 1},
+		{"fruit", "", 3, 1},
+		{"", "fruits", 3, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: tc.id, Type: "picker", Array: tc.array})
+		mock.Strings = []String{
+			{Lang: "base", Arrays: []Array{{Id: "fruits", Items: []string{"Apple", "Banana", "Cherry"}}}},
+			{Lang: "ja", Arrays: []Array{{Id: "fruits", Items: []string{"りんご", "バナナ", "さくらんぼ"}[:tc.jaSize]}}},
+		}
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: id=%s, array=%s, ja items=%d", tc.errors, len(errs), tc.id, tc.array, tc.jaSize)
+		}
+	}
+}
+
+func TestValidateList(t *testing.T) {
+	row := &View{Type: "linear", Sub: []View{{Id: "name", Type: "label"}}}
+	var testcases = []struct {
+		id      string
+		row     *View
+		items   []map[string]string
+		fixture string
+		errors  int
+	}{
+		{"users", row, []map[string]string{{"name": "Alice"}}, "", 0},
+		{"users", row, nil, "fixtures/users.json", 0},
+		{"users", nil, nil, "", 1},
+		{"users", &View{Type: "label", AlignH: "top"}, nil, "", 1},
+		{"", row, nil, "", 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: tc.id, Type: "list", Row: tc.row, Items: tc.items, Fixture: tc.fixture})
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: id=%s, row=%v, items=%v, fixture=%s", tc.errors, len(errs), tc.id, tc.row, tc.items, tc.fixture)
+		}
+	}
+}
+
+func TestValidateGenerator(t *testing.T) {
+	var testcases = []struct {
+		genId      string
+		compileSdk string
+		errors     int
+	}{
+		{"android", "android-21", 0},
+		{"android", "android-19", 1},
+		{"android", "Google Inc.:Google APIs:19", 1},
+		{"ios", "android-19", 0},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: "users", Type: "list", Row: &View{Type: "label"}})
+		mock.Meta.Android.CompileSdkVersion = tc.compileSdk
+		if errs := ValidateGenerator(&mock, tc.genId); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: genId=%s, compileSdk=%s", tc.errors, len(errs), tc.genId, tc.compileSdk)
+		}
+	}
+}
+
+func TestValidateScroll(t *testing.T) {
+	form := View{Type: "linear", Sub: []View{{Id: "name", Type: "input"}}}
+	var testcases = []struct {
+		orientation string
+		sub         []View
+		errors      int
+	}{
+		{"", []View{form}, 0},
+		{"horizontal", []View{form}, 0},
+		{"diagonal", []View{form}, 1},
+		{"", nil, 1},
+		{"", []View{form, form}, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: "form", Type: "scroll", Orientation: tc.orientation, Sub: tc.sub})
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: orientation=%s, sub=%d", tc.errors, len(errs), tc.orientation, len(tc.sub))
+		}
+	}
+}
+
+func TestValidateLinear(t *testing.T) {
+	var testcases = []struct {
+		orientation string
+		weight      float64
+		errors      int
+	}{
+		{"", 0, 0},
+		{"horizontal", 2, 0},
+		{"vertical", 0.5, 0},
+		{"diagonal", 0, 1},
+		{"horizontal", -1, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(View{Id: "buttons", Type: "linear", Orientation: tc.orientation, Sub: []View{
+			{Id: "ok", Type: "button", Weight: tc.weight},
+		}})
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: orientation=%s, weight=%g", tc.errors, len(errs), tc.orientation, tc.weight)
+		}
+	}
+}
+
+func TestValidateProgress(t *testing.T) {
+	var testcases = []struct {
+		value  float64
+		errors int
+	}{
+		{0, 0},
+		{0.5, 0},
+		{1, 0},
+		{50, 1},
+		{-0.1, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(
+			View{Id: "bar", Type: "progress", Value: tc.value},
+			View{Id: "spinner", Type: "spinner_indicator", Hidden: true},
+		)
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("Expected %d errors but %d: value=%g", tc.errors, len(errs), tc.value)
+		}
+	}
+}
+
+func TestValidateWebView(t *testing.T) {
+	var testcases = []struct {
+		name   string
+		views  []View
+		errors int
+	}{
+		{"url", []View{{Id: "a", Type: "web", Url: "https://example.com/"}}, 0},
+		{"html", []View{{Id: "a", Type: "web", Html: "help/index.html"}}, 0},
+		{"without page", []View{{Id: "a", Type: "web"}}, 1},
+		{"without id", []View{{Type: "web", Url: "https://example.com/"}}, 1},
+		// Directories are copied as they are
+		{"same names in directories", []View{{Id: "a", Type: "web", Html: "a/index.html"}, {Id: "b", Type: "web", Html: "b/index.html"}}, 0},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(tc.views...)
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("%s: expected %d errors but %d: %v", tc.name, tc.errors, len(errs), errs)
+		}
+	}
+}
+
+func TestValidateImageNames(t *testing.T) {
+	var testcases = []struct {
+		name   string
+		views  []View
+		errors int
+	}{
+		{"same file", []View{{Id: "a", Type: "image", Src: "icons/star.png"}, {Id: "b", Type: "image", Src: "icons/star.png"}}, 0},
+		{"different names", []View{{Id: "a", Type: "image", Src: "star.png"}, {Id: "b", Type: "image", Src: "moon.png"}}, 0},
+		{"same names in directories", []View{{Id: "a", Type: "image", Src: "icons/star.png"}, {Id: "b", Type: "image", Src: "badges/star.png"}}, 1},
+		{"different cases", []View{{Id: "a", Type: "image", Src: "Star.png"}, {Id: "b", Type: "image", Src: "star.png"}}, 1},
+		{"same placeholders", []View{{Id: "a", Type: "image"}, {Id: "a", Type: "image", Placeholder: "100x100"}}, 0},
+		{"placeholders of different IDs", []View{{Id: "a-b", Type: "image"}, {Id: "a_b", Type: "image"}}, 1},
+		{"file named as placeholder", []View{{Id: "a", Type: "image"}, {Id: "b", Type: "image", Src: "placeholder_a_100x100.png"}}, 1},
+		{"in list row", []View{{Id: "a", Type: "image", Src: "icons/star.png"},
+			{Id: "list", Type: "list", Row: &View{Id: "b", Type: "image", Src: "badges/star.png"}}}, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(tc.views...)
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("%s: expected %d errors but %d: %v", tc.name, tc.errors, len(errs), errs)
+		}
+	}
+}
+
+func TestValidateContainers(t *testing.T) {
+	var testcases = []struct {
+		name   string
+		view   View
+		errors int
+	}{
+		{"frame", View{Type: "frame", Sub: []View{{Id: "a", Type: "image"}, {Id: "b", Type: "label", AlignH: "right"}}}, 0},
+		{"frame with below", View{Type: "frame", Sub: []View{{Id: "a", Type: "image"}, {Id: "b", Type: "label", Below: "a"}}}, 1},
+		{"linear with below", View{Type: "linear", Sub: []View{{Id: "a", Type: "label"}, {Id: "b", Type: "label", Below: "a"}}}, 1},
+		{"relative with below", View{Type: "relative", Sub: []View{{Id: "a", Type: "label"}, {Id: "b", Type: "label", Below: "a"}}}, 0},
+		{"grid", View{Type: "grid", Columns: 2, Rows: 2, Sub: []View{{Id: "a", Type: "label", Span: 2}}}, 0},
+		{"grid without columns", View{Type: "grid", Sub: []View{{Id: "a", Type: "label"}}}, 1},
+		{"grid with negative rows", View{Type: "grid", Columns: 2, Rows: -1}, 1},
+		{"grid with wide span", View{Type: "grid", Columns: 2, Sub: []View{{Id: "a", Type: "label", Span: 3}}}, 1},
+	}
+	for _, tc := range testcases {
+		mock := mockWithViews(tc.view)
+		if errs := Validate(&mock); len(errs) != tc.errors {
+			t.Errorf("%s: expected %d errors but %d: %v", tc.name, tc.errors, len(errs), errs)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package gen
+
+import "fmt"
+
+const (
+	ActionShow = "show"
+	ActionHide = "hide"
+)
+
+// Finds the behaviors which show or hide the views of the screen
+// when the widget fires the trigger.
+// Behaviors on the views which don't exist are ignored.
+func findVisibilityBehaviors(screen *Screen, widget, trigger string) (behaviors []Behavior) {
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Widget != widget || b.Trigger.Type != trigger {
+			continue
+		}
+		if (b.Action.Type == ActionShow || b.Action.Type == ActionHide) && findView(screen, b.Action.Widget) != nil {
+			behaviors = append(behaviors, b)
+		}
+	}
+	return
+}
+
+// Finds the views which are hidden initially or shown and hidden by the behaviors,
+// which need the states to be toggled in SwiftUI.
+func findToggledViews(screen *Screen) (views []*View) {
+	var find func(view *View)
+	find = func(view *View) {
+		toggled := view.Hidden
+		for _, b := range screen.Behaviors {
+			if (b.Action.Type == ActionShow || b.Action.Type == ActionHide) && b.Action.Widget == view.Id {
+				toggled = true
+			}
+		}
+		if toggled && view.Id != "" {
+			views = append(views, view)
+		}
+		for i := range view.Sub {
+			find(&view.Sub[i])
+		}
+	}
+	for i := range screen.Layout {
+		find(&screen.Layout[i])
+	}
+	return
+}
+
+// Statements of the behaviors in Objective-C or Swift.
+// self is the prefix to refer the properties of the view controller in Swift.
+func iosVisibilityStatements(screen *Screen, widget, trigger string, swift bool, self string) (statements []string) {
+	for _, b := range findVisibilityBehaviors(screen, widget, trigger) {
+		hidden := b.Action.Type == ActionHide
+		view := findView(screen, b.Action.Widget)
+		if swift {
+			target := self + swiftPropertyName(*view) + "?"
+			if view.Type == "switch" {
+				// Row of the label and the switch
+				target += ".superview?"
+			}
+			statements = append(statements, fmt.Sprintf("%s.isHidden = %t", target, hidden))
+		} else {
+			target := "self." + view.Id
+			if view.Type == "switch" {
+				target += ".superview"
+			}
+			statements = append(statements, fmt.Sprintf("%s.hidden = %s;", target, iosStoryboardBool(hidden)))
+		}
+	}
+	return
+}
@@ -0,0 +1,1040 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ksoichiro/mocker/layout"
+)
+
+type WebGenerator struct {
+	opt  *Options
+	mock *Mock
+}
+
+const (
+	webDeviceWidth  = 360
+	webDeviceHeight = 640
+)
+
+var wwd WidgetsDef
+
+func defineWebWidgets() {
+	wwd = WidgetsDef{}
+	wwd.Add("button", Widget{
+		Name:     "button",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("label", Widget{
+		Name:     "p",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("input", Widget{
+		Name:     "input",
+		Textable: true,
+		Gravity:  GravityCenter,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("image", Widget{
+		Name:     "img",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("switch", Widget{
+		Name:     "label",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("checkbox", Widget{
+		Name:     "label",
+		Textable: true,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("radio_group", Widget{
+		Name:        "div",
+		Textable:    false,
+		Orientation: OrientationVertical,
+		SizeW:       SizeFill,
+		SizeH:       SizeWrap,
+	})
+	wwd.Add("picker", Widget{
+		Name:     "select",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("list", Widget{
+		Name:     "div",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	wwd.Add("web", Widget{
+		Name:     "iframe",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	wwd.Add("progress", Widget{
+		Name:     "progress",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("spinner_indicator", Widget{
+		Name:     "span",
+		Textable: false,
+		SizeW:    SizeWrap,
+		SizeH:    SizeWrap,
+	})
+	wwd.Add("linear", Widget{
+		Name:        "div",
+		Textable:    false,
+		Orientation: OrientationVertical,
+		SizeW:       SizeFill,
+		SizeH:       SizeFill,
+	})
+	wwd.Add("relative", Widget{
+		Name:     "div",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	wwd.Add("scroll", Widget{
+		Name:     "div",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	wwd.Add("frame", Widget{
+		Name:     "div",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+	wwd.Add("grid", Widget{
+		Name:     "div",
+		Textable: false,
+		SizeW:    SizeFill,
+		SizeH:    SizeFill,
+	})
+}
+
+func (g *WebGenerator) Generate() {
+	defineWebWidgets()
+
+	outDir := g.opt.OutDir
+
+	var wg sync.WaitGroup
+
+	// Generate index to open the launch screen
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genWebIndex(mock, dir)
+	}(g.mock, outDir)
+
+	// Generate pages
+	for _, screen := range g.mock.Screens {
+		wg.Add(1)
+		go func(mock *Mock, dir string, screen Screen) {
+			defer wg.Done()
+			genWebPage(mock, dir, screen)
+		}(g.mock, outDir, screen)
+	}
+
+	// Generate resources
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genWebStyle(mock, dir)
+	}(g.mock, outDir)
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genWebScript(mock, dir)
+	}(g.mock, outDir)
+	wg.Add(1)
+	go func(mock *Mock, dir string) {
+		defer wg.Done()
+		genWebStrings(mock, dir)
+	}(g.mock, outDir)
+	wg.Add(1)
+	go func(mock *Mock, inDir, dir string) {
+		defer wg.Done()
+		genWebImages(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, outDir)
+	wg.Add(1)
+	go func(mock *Mock, inDir, dir string) {
+		defer wg.Done()
+		genWebViewAssets(mock, inDir, dir)
+	}(g.mock, g.opt.InDir, outDir)
+
+	wg.Wait()
+}
+
+func webPageName(screenId string) string {
+	return screenId + ".html"
+}
+
+func genWebIndex(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeWebIndex(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "index.html"))
+}
+
+func genCodeWebIndex(mock *Mock, buf *CodeBuffer) {
+	page := html.EscapeString(webPageName(mock.Launch.Screen))
+	buf.add(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="0; url=%s">
+<title>%s</title>
+</head>
+<body>
+<a href="%s">%s</a>
+</body>
+</html>`,
+		page,
+		html.EscapeString(mock.Name),
+		page,
+		html.EscapeString(mock.Name))
+}
+
+func genWebPage(mock *Mock, dir string, screen Screen) {
+	var buf CodeBuffer
+	genCodeWebPage(mock, screen, &buf)
+	genFile(&buf, filepath.Join(dir, webPageName(screen.Id)))
+}
+
+func genCodeWebPage(mock *Mock, screen Screen, buf *CodeBuffer) {
+	buf.add(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s - %s</title>
+<link rel="stylesheet" href="mocker.css">
+<script src="strings.js"></script>
+<script src="mocker.js"></script>
+</head>
+<body>
+<div class="mocker-toolbar">
+    <select id="mocker-lang">`,
+		html.EscapeString(screen.Name),
+		html.EscapeString(mock.Name))
+	for _, s := range mock.Strings {
+		buf.add(`        <option value="%s">%s</option>`, html.EscapeString(s.Lang), html.EscapeString(s.Lang))
+	}
+	buf.add(`    </select>
+</div>
+<div class="mocker-device">
+    <header class="mocker-title">`)
+	if screen.Id != mock.Launch.Screen {
+		buf.add(`        <button type="button" class="mocker-back" data-back>&lsaquo;</button>`)
+	}
+	buf.add(`        <h1>%s</h1>
+    </header>
+    <div class="mocker-screen">`, html.EscapeString(screen.Name))
+	if 0 < len(screen.Layout) {
+		// Only parse root view
+		genWebViewRecur(mock, &screen, nil, &screen.Layout[0], buf, 2)
+	}
+	buf.add(`    </div>
+</div>
+</body>
+</html>`)
+}
+
+// Views are placed in flex columns or rows:
+// the linear layout itself, or the chains of the relative layout.
+// Frame and grid layouts place them in the cells of the CSS grid instead.
+// parent is the container which has the column or the row.
+func genWebViewRecur(mock *Mock, screen *Screen, parent, view *View, buf *CodeBuffer, indent int) {
+	if !wwd.Has(view.Type) {
+		return
+	}
+	widget := wwd.Get(view.Type)
+
+	t := tab(indent)
+	attrs := ""
+	if view.Id != "" {
+		attrs += fmt.Sprintf(` id="%s"`, html.EscapeString(view.Id))
+	}
+	attrs += fmt.Sprintf(` class="%s"`, view.Type)
+	style := convertWebStyle(mock, parent, view, widget)
+
+	switch view.Type {
+	case "label":
+		buf.add(`%s<p%s%s%s>%s</p>`, t, attrs, webStringAttr("data-string", view.Label), style,
+			html.EscapeString(LocalizedString(mock, "base", view.Label)))
+	case "button":
+		attrs += webBehaviorAttrs(mock, screen, view.Id, "click", "click")
+		buf.add(`%s<button type="button"%s%s%s>%s</button>`, t, attrs, webStringAttr("data-string", view.Label), style,
+			html.EscapeString(LocalizedString(mock, "base", view.Label)))
+	case "switch", "checkbox":
+		// The text is before the switch, and after the check box
+		attrs += webBehaviorAttrs(mock, screen, view.Id, "changed", "change")
+		checked := ""
+		if view.Checked {
+			checked = " checked"
+		}
+		text := fmt.Sprintf(`<span%s>%s</span>`, webStringAttr("data-string", view.Label), html.EscapeString(LocalizedString(mock, "base", view.Label)))
+		input := fmt.Sprintf(`<input type="checkbox"%s>`, checked)
+		if view.Type == "switch" {
+			buf.add(`%s<label%s%s>%s%s</label>`, t, attrs, style, text, input)
+		} else {
+			buf.add(`%s<label%s%s>%s%s</label>`, t, attrs, style, input, text)
+		}
+	case "radio_group":
+		attrs += webBehaviorAttrs(mock, screen, view.Id, "changed", "change")
+		buf.add(`%s<div%s%s>`, t, attrs, style)
+		for _, option := range view.Options {
+			checked := ""
+			if option == view.Selected {
+				checked = " checked"
+			}
+			buf.add(`%s    <label><input type="radio" name="%s" value="%s"%s><span%s>%s</span></label>`, t,
+				html.EscapeString(view.Id), html.EscapeString(option), checked,
+				webStringAttr("data-string", option), html.EscapeString(LocalizedString(mock, "base", option)))
+		}
+		buf.add(`%s</div>`, t)
+	case "picker":
+		attrs += webBehaviorAttrs(mock, screen, view.Id, "selected", "change")
+		buf.add(`%s<select%s%s%s>`, t, attrs, webStringAttr("data-array", view.Array), style)
+		for _, item := range LocalizedArray(mock, "base", view.Array) {
+			buf.add(`%s    <option>%s</option>`, t, html.EscapeString(item))
+		}
+		buf.add(`%s</select>`, t)
+	case "list":
+		// Rows are repeated with the items, and each of them fires item_click
+		buf.add(`%s<div%s%s>`, t, attrs, style)
+		rowAttrs := webBehaviorAttrs(mock, screen, view.Id, "item_click", "click")
+		for _, item := range view.Items {
+			row := RowWithItem(ListRow(view), item)
+			buf.add(`%s    <div class="list_row"%s>`, t, rowAttrs)
+			genWebViewRecur(mock, screen, view, &row, buf, indent+2)
+			buf.add(`%s    </div>`, t)
+		}
+		buf.add(`%s</div>`, t)
+	case "input":
+		placeholder := ""
+		if view.Hint != "" {
+			placeholder = fmt.Sprintf(` placeholder="%s"`, html.EscapeString(LocalizedString(mock, "base", view.Hint)))
+		}
+		buf.add(`%s<input type="text"%s%s%s%s>`, t, attrs, webStringAttr("data-hint", view.Hint), placeholder, style)
+	case "image":
+		buf.add(`%s<img%s src="%s" alt=""%s>`, t, attrs, html.EscapeString(webImageName(view)), style)
+	case "web":
+		buf.add(`%s<iframe%s src="%s"%s></iframe>`, t, attrs, html.EscapeString(webViewSrc(view)), style)
+	case "progress":
+		buf.add(`%s<progress%s value="%g" max="1"%s></progress>`, t, attrs, view.Value, style)
+	case "spinner_indicator":
+		buf.add(`%s<span%s%s></span>`, t, attrs, style)
+	case "relative":
+		buf.add(`%s<div%s%s>`, t, attrs, style)
+		// Views chained with "below" are stacked in the positioned columns
+		for _, chain := range chainViewsBelow(view.Sub) {
+			buf.add(`%s    <div class="chain"%s>`, t, convertWebChainStyle(view, webGravity(view), chain))
+			for i := range chain {
+				genWebViewRecur(mock, screen, view, &chain[i], buf, indent+2)
+			}
+			buf.add(`%s    </div>`, t)
+		}
+		buf.add(`%s</div>`, t)
+	default:
+		buf.add(`%s<div%s%s>`, t, attrs, style)
+		for i := range view.Sub {
+			genWebViewRecur(mock, screen, view, &view.Sub[i], buf, indent+1)
+		}
+		buf.add(`%s</div>`, t)
+	}
+}
+
+// Behaviors of the trigger handled by mocker.js when the DOM event is fired.
+// Only the first transition is available as a link,
+// and the views are shown or hidden with it.
+func webBehaviorAttrs(mock *Mock, screen *Screen, widget, trigger, event string) (attrs string) {
+	for _, b := range screen.Behaviors {
+		if b.Trigger.Widget == widget && b.Trigger.Type == trigger &&
+			b.Action.Type == "transit_forward" && findScreen(mock, b.Action.Transit) != nil {
+			attrs += fmt.Sprintf(` data-href="%s"`, html.EscapeString(webPageName(b.Action.Transit)))
+			break
+		}
+	}
+	attrs += webVisibilityAttrs(screen, widget, trigger)
+	if attrs != "" && event != "click" {
+		attrs += fmt.Sprintf(` data-event="%s"`, event)
+	}
+	return
+}
+
+// Views shown or hidden by mocker.js when the trigger is fired.
+func webVisibilityAttrs(screen *Screen, widget, trigger string) (attrs string) {
+	var shown, hidden []string
+	for _, b := range findVisibilityBehaviors(screen, widget, trigger) {
+		if b.Action.Type == ActionShow {
+			shown = append(shown, b.Action.Widget)
+		} else {
+			hidden = append(hidden, b.Action.Widget)
+		}
+	}
+	if 0 < len(shown) {
+		attrs += fmt.Sprintf(` data-show="%s"`, html.EscapeString(strings.Join(shown, " ")))
+	}
+	if 0 < len(hidden) {
+		attrs += fmt.Sprintf(` data-hide="%s"`, html.EscapeString(strings.Join(hidden, " ")))
+	}
+	return
+}
+
+// Texts are replaced by mocker.js with the selected language.
+func webStringAttr(name, id string) string {
+	if id == "" {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%s"`, name, html.EscapeString(id))
+}
+
+// Converts layout params into the inline style of the item in the flex column,
+// or in the flex row of the horizontal linear layout.
+func convertWebStyle(mock *Mock, parent, view *View, widget Widget) string {
+	sizeW := view.SizeW
+	if sizeW == "" {
+		sizeW = widget.SizeW
+	}
+	sizeH := view.SizeH
+	if sizeH == "" {
+		sizeH = widget.SizeH
+	}
+	gravity := webGravity(view)
+	parentGravity := ""
+	row, cell := false, false
+	if parent != nil {
+		parentGravity = webGravity(parent)
+		row = parent.Type == "linear" && viewOrientation(parent) == OrientationHorizontal
+		cell = parent.Type == "frame" || parent.Type == "grid"
+	}
+
+	var props []string
+	if cell {
+		alignH, alignV := view.AlignH, view.AlignV
+		if alignH == "" && parentGravity == GravityCenter {
+			alignH = AlignCenter
+		}
+		if alignV == "" && (parentGravity == GravityCenter || parentGravity == GravityCenterV) {
+			alignV = AlignCenter
+		}
+		props = append(props, "justify-self: "+webGridAlignment(sizeW, alignH), "align-self: "+webGridAlignment(sizeH, alignV))
+		if parent.Type == "grid" && 1 < view.Span {
+			props = append(props, fmt.Sprintf("grid-column: span %d", view.Span))
+		}
+	} else if row {
+		// Axes are swapped in the row
+		if sizeH == SizeFill {
+			props = append(props, "align-self: stretch")
+		} else {
+			alignV := view.AlignV
+			if alignV == "" && (parentGravity == GravityCenter || parentGravity == GravityCenterV) {
+				alignV = AlignCenter
+			}
+			props = append(props, "align-self: "+webFlexAlignment(alignV))
+		}
+	} else if sizeW == SizeFill {
+		props = append(props, "align-self: stretch")
+	} else {
+		alignH := view.AlignH
+		if alignH == "" && parentGravity == GravityCenter {
+			alignH = AlignCenter
+		}
+		props = append(props, "align-self: "+webFlexAlignment(alignH))
+	}
+	if weight := linearWeight(parent, view, widget); 0 < weight {
+		props = append(props, "flex: "+strconv.FormatFloat(weight, 'f', -1, 64))
+	} else if sizeH == SizeFill && !row && !cell {
+		props = append(props, "flex: 1")
+	}
+	if view.Type == "relative" && (sizeW != SizeFill || sizeH != SizeFill) {
+		// Sub views are positioned absolutely, so the size is estimated
+		node := newLayoutNode(mock, view, "base")
+		w, h := layout.Measure(&node, webDeviceWidth)
+		if sizeW != SizeFill {
+			props = append(props, fmt.Sprintf("width: %dpx", w))
+		}
+		if sizeH != SizeFill {
+			props = append(props, fmt.Sprintf("height: %dpx", h))
+		}
+	}
+	if view.Type == "radio_group" && viewOrientation(view) == OrientationHorizontal {
+		props = append(props, "flex-direction: row")
+	}
+	if view.Type == "linear" && viewOrientation(view) == OrientationHorizontal {
+		props = append(props, "flex-direction: row")
+		if gravity == GravityCenter {
+			props = append(props, "justify-content: center")
+		}
+	} else if view.Type == "linear" && (gravity == GravityCenter || gravity == GravityCenterV) {
+		props = append(props, "justify-content: center")
+	}
+	if view.Type == "grid" {
+		props = append(props, fmt.Sprintf("grid-template-columns: repeat(%d, 1fr)", view.Columns))
+		if 0 < view.Rows {
+			props = append(props, fmt.Sprintf("grid-template-rows: repeat(%d, 1fr)", view.Rows))
+		}
+	}
+	if view.Type == "image" {
+		props = append(props, "object-fit: "+webObjectFit(view.Scale))
+	}
+	if wwd.Get(view.Type).Textable && gravity == GravityCenter {
+		props = append(props, "text-align: center")
+	}
+	if view.Margin != "" {
+		props = append(props, fmt.Sprintf("margin: %dpx", convertLayoutDimension(view.Margin)))
+	}
+	if view.Padding != "" {
+		props = append(props, fmt.Sprintf("padding: %dpx", convertLayoutDimension(view.Padding)))
+	}
+	if view.Hidden {
+		// Hidden views keep their spaces
+		props = append(props, "visibility: hidden")
+	}
+	return fmt.Sprintf(` style="%s"`, strings.Join(props, "; "))
+}
+
+// The chain spans the width of the relative layout,
+// and is positioned vertically with the first view.
+func convertWebChainStyle(view *View, gravity string, chain []View) string {
+	p := convertLayoutDimension(view.Padding)
+	props := []string{fmt.Sprintf("left: %dpx", p), fmt.Sprintf("right: %dpx", p)}
+	fill := false
+	for _, v := range chain {
+		sizeH := v.SizeH
+		if sizeH == "" {
+			sizeH = wwd.Get(v.Type).SizeH
+		}
+		fill = fill || sizeH == SizeFill
+	}
+	alignV := chain[0].AlignV
+	if alignV == "" && (gravity == GravityCenter || gravity == GravityCenterV) {
+		alignV = AlignCenter
+	}
+	switch {
+	case fill:
+		props = append(props, fmt.Sprintf("top: %dpx", p), fmt.Sprintf("bottom: %dpx", p))
+	case alignV == AlignBottom:
+		props = append(props, fmt.Sprintf("bottom: %dpx", p))
+	case alignV == AlignCenter:
+		props = append(props, fmt.Sprintf("top: %dpx", p), fmt.Sprintf("bottom: %dpx", p), "justify-content: center")
+	default:
+		props = append(props, fmt.Sprintf("top: %dpx", p))
+	}
+	return fmt.Sprintf(` style="%s"`, strings.Join(props, "; "))
+}
+
+func webGridAlignment(size, align string) string {
+	if size == SizeFill {
+		return "stretch"
+	}
+	switch align {
+	case AlignCenter:
+		return "center"
+	case AlignRight, AlignBottom:
+		return "end"
+	}
+	return "start"
+}
+
+func webObjectFit(scale string) string {
+	switch scale {
+	case ScaleFill:
+		return "cover"
+	case ScaleStretch:
+		return "fill"
+	case ScaleCenter:
+		return "none"
+	}
+	return "contain"
+}
+
+func webGravity(view *View) string {
+	if view.Gravity != "" {
+		return view.Gravity
+	}
+	return wwd.Get(view.Type).Gravity
+}
+
+func webFlexAlignment(align string) string {
+	switch align {
+	case AlignCenter:
+		return "center"
+	case AlignRight, AlignBottom:
+		return "flex-end"
+	}
+	return "flex-start"
+}
+
+// Images are shown at 1x, and placeholders are generated as PNG files.
+func webImageName(view *View) string {
+	ext := ".png"
+	if _, _, ok := imagePlaceholderSize(view); !ok {
+		ext = strings.ToLower(filepath.Ext(view.Src))
+	}
+	return "images/" + imageResourceName(view) + ext
+}
+
+func genWebImages(mock *Mock, inDir, dir string) {
+	for name, content := range webImages(mock, inDir) {
+		f := createFile(filepath.Join(dir, filepath.FromSlash(name)))
+		f.WriteString(content)
+		f.Close()
+	}
+}
+
+// Renders the images of the image views.
+// Keys are the file names relative to the output directory.
+func webImages(mock *Mock, inDir string) map[string]string {
+	files := map[string]string{}
+	for _, view := range collectImageViews(mock) {
+		if _, _, ok := imagePlaceholderSize(view); ok {
+			var b bytes.Buffer
+			png.Encode(&b, renderPlaceholder(view, 1))
+			files[webImageName(view)] = b.String()
+		} else if b, err := ioutil.ReadFile(findImageFiles(inDir, view.Src)[1]); err == nil {
+			files[webImageName(view)] = string(b)
+		} else {
+			fmt.Println("Error copying file", err)
+		}
+	}
+	return files
+}
+
+// Local HTML files are loaded from the assets directory of the prototype.
+func webViewSrc(view *View) string {
+	if view.Html != "" {
+		return AssetsDir + "/" + webViewHtmlPath(view.Html)
+	}
+	return view.Url
+}
+
+func genWebViewAssets(mock *Mock, inDir, dir string) {
+	for _, asset := range collectWebViewAssets(mock) {
+		src := filepath.Join(inDir, AssetsDir, asset.Path)
+		if err := copyTree(src, filepath.Join(dir, AssetsDir, asset.Path)); err != nil {
+			fmt.Println("Error copying file", err)
+		}
+	}
+}
+
+// Reads the assets of the web views.
+// Keys are the file names relative to the output directory.
+func webViewAssetFiles(mock *Mock, inDir string) map[string]string {
+	files := map[string]string{}
+	root := filepath.Join(inDir, AssetsDir)
+	for _, asset := range collectWebViewAssets(mock) {
+		err := filepath.Walk(filepath.Join(root, asset.Path), func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			b, err := ioutil.ReadFile(p)
+			if err == nil {
+				files[AssetsDir+"/"+filepath.ToSlash(rel)] = string(b)
+			}
+			return err
+		})
+		if err != nil {
+			fmt.Println("Error copying file", err)
+		}
+	}
+	return files
+}
+
+func genWebStyle(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeWebStyle(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "mocker.css"))
+}
+
+func genCodeWebStyle(mock *Mock, buf *CodeBuffer) {
+	if 0 < len(mock.Colors) {
+		buf.add(`:root {`)
+		for _, c := range mock.Colors {
+			a, r, g, b := hexToInt(c.Value)
+			buf.add(`    --%s: rgba(%d, %d, %d, %.3f);`, c.Id, r, g, b, float64(a)/255.0)
+		}
+		buf.add(`}
+`)
+	}
+	buf.add(`* {
+    box-sizing: border-box;
+}
+
+body {
+    margin: 0;
+    background: #eeeeee;
+    font-family: -apple-system, "Helvetica Neue", Roboto, sans-serif;
+    font-size: 16px;
+}
+
+.mocker-toolbar {
+    padding: 8px;
+    text-align: center;
+}
+
+.mocker-device {
+    display: flex;
+    flex-direction: column;
+    width: %dpx;
+    height: %dpx;
+    margin: 0 auto 16px;
+    overflow: hidden;
+    background: #ffffff;
+    border: 1px solid #cccccc;
+}
+
+.mocker-title {
+    display: flex;
+    align-items: center;
+    height: 48px;
+    padding: 0 8px;
+    background: #f7f7f7;
+    border-bottom: 1px solid #dddddd;
+}
+
+.mocker-title h1 {
+    margin: 0 8px;
+    font-size: 18px;
+}
+
+.mocker-back {
+    border: none;
+    background: none;
+    font-size: 24px;
+    cursor: pointer;
+}
+
+.mocker-screen {
+    display: flex;
+    flex: 1;
+    flex-direction: column;
+    min-height: 0;
+}
+
+.linear {
+    display: flex;
+    flex-direction: column;
+    min-height: 0;
+}
+
+.relative {
+    position: relative;
+    min-height: 0;
+}
+
+.scroll {
+    display: flex;
+    flex-direction: column;
+    min-height: 0;
+    overflow: auto;
+}
+
+.frame,
+.grid {
+    display: grid;
+    align-content: start;
+    min-height: 0;
+}
+
+.frame {
+    grid-template: 1fr / 1fr;
+}
+
+.frame > * {
+    grid-area: 1 / 1;
+}
+
+.chain {
+    position: absolute;
+    display: flex;
+    flex-direction: column;
+    pointer-events: none;
+}
+
+.chain > * {
+    pointer-events: auto;
+}
+
+.label {
+    margin: 0;
+}
+
+.button,
+.input {
+    min-height: 30px;
+    margin: 0;
+    font-size: 16px;
+}
+
+.list {
+    display: flex;
+    flex-direction: column;
+    min-height: 0;
+    overflow: auto;
+}
+
+.list_row {
+    display: flex;
+    flex-direction: column;
+    flex-shrink: 0;
+    border-bottom: 1px solid #dddddd;
+}
+
+.list_row[data-href],
+.list_row[data-show],
+.list_row[data-hide] {
+    cursor: pointer;
+}
+
+.picker {
+    min-height: 30px;
+    font-size: 16px;
+}
+
+.button[data-href],
+.button[data-show],
+.button[data-hide] {
+    cursor: pointer;
+}
+
+.switch,
+.checkbox,
+.radio_group label {
+    display: flex;
+    align-items: center;
+    min-height: 30px;
+}
+
+.switch {
+    justify-content: space-between;
+}
+
+.radio_group {
+    display: flex;
+    flex-direction: column;
+}
+
+.image {
+    display: block;
+    max-width: 100%%;
+    min-height: 0;
+}
+
+.web {
+    min-height: 0;
+    border: none;
+}
+
+.progress {
+    height: 4px;
+    margin: 0;
+}
+
+.spinner_indicator {
+    display: inline-block;
+    width: 20px;
+    height: 20px;
+    border: 2px solid #cccccc;
+    border-top-color: #666666;
+    border-radius: 50%%;
+    animation: mocker-spin 1s linear infinite;
+}
+
+@keyframes mocker-spin {
+    to {
+        transform: rotate(360deg);
+    }
+}`, webDeviceWidth, webDeviceHeight)
+}
+
+func genWebScript(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeWebScript(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "mocker.js"))
+}
+
+// The language is kept in the query string while navigating
+// because the pages must work with file: URLs.
+func genCodeWebScript(mock *Mock, buf *CodeBuffer) {
+	buf.add(`(function () {
+    var lang = new URLSearchParams(location.search).get("lang") || "base";
+
+    function localize(id) {
+        var defs = mockerStrings[lang] || {};
+        if (id in defs) {
+            return defs[id];
+        }
+        defs = mockerStrings["base"] || {};
+        return id in defs ? defs[id] : id;
+    }
+
+    function localizeArray(id) {
+        var arrays = mockerArrays[lang] || {};
+        if (id in arrays) {
+            return arrays[id];
+        }
+        arrays = mockerArrays["base"] || {};
+        return id in arrays ? arrays[id] : [];
+    }
+
+    function apply() {
+        document.querySelectorAll("[data-string]").forEach(function (e) {
+            e.textContent = localize(e.dataset.string);
+        });
+        document.querySelectorAll("[data-hint]").forEach(function (e) {
+            e.placeholder = localize(e.dataset.hint);
+        });
+        document.querySelectorAll("[data-array]").forEach(function (e) {
+            var items = localizeArray(e.dataset.array);
+            Array.prototype.forEach.call(e.options, function (option, i) {
+                if (i < items.length) {
+                    option.textContent = items[i];
+                }
+            });
+        });
+    }
+
+    function link(href) {
+        return lang === "base" ? href : href + "?lang=" + encodeURIComponent(lang);
+    }
+
+    function setVisibility(ids, visibility) {
+        ids.split(" ").forEach(function (id) {
+            var e = document.getElementById(id);
+            if (e) {
+                e.style.visibility = visibility;
+            }
+        });
+    }
+
+    document.addEventListener("DOMContentLoaded", function () {
+        var select = document.getElementById("mocker-lang");
+        if (select) {
+            select.value = lang;
+            select.addEventListener("change", function () {
+                lang = select.value;
+                apply();
+            });
+        }
+        // Controls fire the behaviors on the change events instead of the clicks
+        document.querySelectorAll("[data-href]").forEach(function (e) {
+            e.addEventListener(e.dataset.event || "click", function () {
+                location.href = link(e.dataset.href);
+            });
+        });
+        document.querySelectorAll("[data-show]").forEach(function (e) {
+            e.addEventListener(e.dataset.event || "click", function () {
+                setVisibility(e.dataset.show, "visible");
+            });
+        });
+        document.querySelectorAll("[data-hide]").forEach(function (e) {
+            e.addEventListener(e.dataset.event || "click", function () {
+                setVisibility(e.dataset.hide, "hidden");
+            });
+        });
+        document.querySelectorAll("[data-back]").forEach(function (e) {
+            e.addEventListener("click", function () {
+                history.back();
+            });
+        });
+        apply();
+    });
+})();`)
+}
+
+func genWebStrings(mock *Mock, dir string) {
+	var buf CodeBuffer
+	genCodeWebStrings(mock, &buf)
+	genFile(&buf, filepath.Join(dir, "strings.js"))
+}
+
+// Strings are defined as a script instead of JSON
+// because browsers cannot load files with file: URLs.
+func genCodeWebStrings(mock *Mock, buf *CodeBuffer) {
+	defs := map[string]map[string]string{}
+	arrays := map[string]map[string][]string{}
+	for _, s := range mock.Strings {
+		if defs[s.Lang] == nil {
+			defs[s.Lang] = map[string]string{}
+		}
+		for _, def := range s.Defs {
+			defs[s.Lang][def.Id] = def.Value
+		}
+		if 0 < len(s.Arrays) && arrays[s.Lang] == nil {
+			arrays[s.Lang] = map[string][]string{}
+		}
+		for _, a := range s.Arrays {
+			arrays[s.Lang][a.Id] = a.Items
+		}
+	}
+	b, _ := json.MarshalIndent(defs, "", "    ")
+	buf.add(`var mockerStrings = %s;`, string(b))
+	b, _ = json.MarshalIndent(arrays, "", "    ")
+	buf.add(`var mockerArrays = %s;`, string(b))
+}
+
+// WebFiles renders the prototype in memory.
+// Keys are the file names relative to the output directory.
+// inDir is the directory of the Mockerfile which has the assets.
+func WebFiles(mock *Mock, inDir string) map[string]string {
+	defineWebWidgets()
+
+	files := map[string]string{}
+	add := func(name string, genCode func(buf *CodeBuffer)) {
+		var buf CodeBuffer
+		genCode(&buf)
+		files[name] = strings.Join(buf, "\n") + "\n"
+	}
+	add("index.html", func(buf *CodeBuffer) { genCodeWebIndex(mock, buf) })
+	for _, screen := range mock.Screens {
+		screen := screen
+		add(webPageName(screen.Id), func(buf *CodeBuffer) { genCodeWebPage(mock, screen, buf) })
+	}
+	add("mocker.css", func(buf *CodeBuffer) { genCodeWebStyle(mock, buf) })
+	add("mocker.js", func(buf *CodeBuffer) { genCodeWebScript(mock, buf) })
+	add("strings.js", func(buf *CodeBuffer) { genCodeWebStrings(mock, buf) })
+	for name, content := range webImages(mock, inDir) {
+		files[name] = content
+	}
+	for name, content := range webViewAssetFiles(mock, inDir) {
+		files[name] = content
+	}
+	return files
+}
+
+// WebPageName returns the file name of the page for the screen.
+func WebPageName(screenId string) string {
+	return webPageName(screenId)
+}
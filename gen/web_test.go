@@ -0,0 +1,190 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenWebImage(t *testing.T) {
+	defineWebWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "logo", Type: "image", Placeholder: "320x180", Scale: ScaleFill},
+				{Id: "icon", Type: "image"},
+			}},
+		}},
+	}}
+	var buf CodeBuffer
+	genCodeWebPage(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`<img id="logo" class="image" src="images/placeholder_logo_320x180.png" alt=""`,
+		"object-fit: cover",
+		`<img id="icon" class="image" src="images/placeholder_icon_100x100.png" alt=""`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	files := WebFiles(&mock, ".")
+	for _, name := range []string{"images/placeholder_logo_320x180.png", "images/placeholder_icon_100x100.png"} {
+		if !strings.HasPrefix(files[name], "\x89PNG") {
+			t.Errorf("Expected PNG file: %s", name)
+		}
+	}
+}
+
+func TestGenWebToggles(t *testing.T) {
+	defineWebWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "agree", Type: "checkbox", Label: "agree", Checked: true},
+				{Id: "notify", Type: "switch", Label: "notify"},
+				{Id: "gender", Type: "radio_group", Options: []string{"male", "female"}, Selected: "female"},
+				{Id: "detail", Type: "label", Label: "detail"},
+			}},
+		}, Behaviors: []Behavior{
+			{Trigger: Trigger{Type: "changed", Widget: "agree"}, Action: Action{Type: "hide", Widget: "detail"}},
+			{Trigger: Trigger{Type: "changed", Widget: "gender"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+		}},
+		{Id: "second"},
+	}}
+	var buf CodeBuffer
+	genCodeWebPage(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`<label id="agree" class="checkbox" data-hide="detail" data-event="change" style="align-self: stretch"><input type="checkbox" checked><span data-string="agree">agree</span></label>`,
+		`<label id="notify" class="switch" style="align-self: stretch"><span data-string="notify">notify</span><input type="checkbox"></label>`,
+		`<div id="gender" class="radio_group" data-href="second.html" data-event="change"`,
+		`<label><input type="radio" name="gender" value="male"><span data-string="male">male</span></label>`,
+		`<label><input type="radio" name="gender" value="female" checked><span data-string="female">female</span></label>`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenWebPicker(t *testing.T) {
+	defineWebWidgets()
+	mock := Mock{
+		Screens: []Screen{
+			{Id: "top", Layout: []View{
+				{Type: "linear", Sub: []View{
+					{Id: "country", Type: "picker", Array: "countries"},
+				}},
+			}, Behaviors: []Behavior{
+				{Trigger: Trigger{Type: "selected", Widget: "country"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+			}},
+			{Id: "second"},
+		},
+		Strings: []String{
+			{Lang: "base", Arrays: []Array{{Id: "countries", Items: []string{"Japan", "U.S."}}}},
+			{Lang: "ja", Arrays: []Array{{Id: "countries", Items: []string{"日本", "アメリカ"}}}},
+		},
+	}
+	var buf CodeBuffer
+	genCodeWebPage(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`<select id="country" class="picker" data-href="second.html" data-event="change" data-array="countries"`,
+		`<option>Japan</option>`,
+		`<option>U.S.</option>`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	buf = CodeBuffer{}
+	genCodeWebStrings(&mock, &buf)
+	code = strings.Join(buf, "\n")
+	for _, expect := range []string{"var mockerArrays = {", `"日本",`} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
+
+func TestGenWebList(t *testing.T) {
+	defineWebWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Id: "users", Type: "list",
+				Row: &View{Type: "linear", Sub: []View{
+					{Id: "name", Type: "label", Label: "name"},
+					{Id: "email", Type: "label", Label: "email"},
+				}},
+				Items: []map[string]string{
+					{"name": "Alice", "email": "alice@example.com"},
+					{"name": "Bob"},
+				}},
+		}, Behaviors: []Behavior{
+			{Trigger: Trigger{Type: "item_click", Widget: "users"}, Action: Action{Type: "transit_forward", Transit: "second"}},
+		}},
+		{Id: "second"},
+	}}
+	var buf CodeBuffer
+	genCodeWebPage(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`<div id="users" class="list"`,
+		`<div class="list_row" data-href="second.html">`,
+		`>Alice</p>`,
+		`>alice@example.com</p>`,
+		`>Bob</p>`,
+		// Texts not in the item are left as the row
+		`>email</p>`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+	if n := strings.Count(code, `class="list_row"`); n != 2 {
+		t.Errorf("Expected 2 rows but %d in\n%s", n, code)
+	}
+}
+
+func TestGenWebSample(t *testing.T) {
+	defineWebWidgets()
+	mock := loadSampleMock(t)
+
+	files := WebFiles(&mock, "..")
+	var testcases = []struct {
+		file    string
+		expects []string
+	}{
+		{"index.html", []string{`<meta http-equiv="refresh" content="0; url=top.html">`}},
+		{"top.html", []string{
+			"<title>Mocker Demo - Mocker Demo</title>",
+			`<option value="ja">ja</option>`,
+			`<p id="label_demo" class="label" data-string="label_demo"`,
+			`>Welcome to Mocker Demo!</p>`,
+			`<input type="text" id="user_id" class="input" data-hint="hint_user_id" placeholder="Input your ID"`,
+			`<button type="button" id="next" class="button" data-href="second.html" data-string="button_next"`,
+		}},
+		// The launch screen has no back button
+		{"second.html", []string{`<button type="button" class="mocker-back" data-back>`, "<h1>Next</h1>"}},
+		{"strings.js", []string{`"button_next": "次へ"`}},
+		{"mocker.css", []string{"width: 360px;", "--bg_button: rgba(153, 0, 0, 1.000);"}},
+		{"mocker.js", []string{`e.addEventListener(e.dataset.event || "click", function () {`}},
+	}
+	for _, tc := range testcases {
+		content, ok := files[tc.file]
+		if !ok {
+			t.Errorf("Expected %s", tc.file)
+			continue
+		}
+		for _, expect := range tc.expects {
+			if !strings.Contains(content, expect) {
+				t.Errorf("Expected %q in %s\n%s", expect, tc.file, content)
+			}
+		}
+	}
+	if strings.Contains(files["top.html"], "mocker-back") {
+		t.Errorf("Unexpected back button in the launch screen")
+	}
+}
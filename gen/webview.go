@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Finds the web views of the screen.
+func collectWebViews(screen *Screen) (views []*View) {
+	walkViews(screen, func(view *View) {
+		if view.Type == "web" {
+			views = append(views, view)
+		}
+	})
+	return
+}
+
+func hasWebViews(mock *Mock) bool {
+	for i := range mock.Screens {
+		if 0 < len(collectWebViews(&mock.Screens[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether any web view loads the page from the network,
+// which needs the permission on Android.
+func hasRemoteWebViews(mock *Mock) bool {
+	for i := range mock.Screens {
+		for _, view := range collectWebViews(&mock.Screens[i]) {
+			if view.Html == "" && view.Url != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Asset copied into the apps for the web views.
+type webViewAsset struct {
+	// Path relative to the assets directory
+	Path string
+	Dir  bool
+}
+
+// Finds the assets copied into the apps for the web views.
+// HTML files in the directories are copied with the top directories
+// to keep the relative paths to their CSS, JavaScript and images,
+// and the ones directly in the assets directory are copied alone.
+// The assets are sorted by the paths without duplicates.
+func collectWebViewAssets(mock *Mock) (assets []webViewAsset) {
+	dirs := map[string]bool{}
+	var paths []string
+	for i := range mock.Screens {
+		for _, view := range collectWebViews(&mock.Screens[i]) {
+			if view.Html == "" {
+				continue
+			}
+			p, dir := webViewHtmlPath(view.Html), false
+			if i := strings.Index(p, "/"); 0 <= i {
+				p, dir = p[:i], true
+			}
+			if _, ok := dirs[p]; !ok {
+				dirs[p] = dir
+				paths = append(paths, p)
+			}
+		}
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		assets = append(assets, webViewAsset{Path: p, Dir: dirs[p]})
+	}
+	return
+}
+
+// Path of the HTML file relative to the assets directory,
+// which is also the path in the apps.
+func webViewHtmlPath(html string) string {
+	return path.Clean(filepath.ToSlash(html))
+}
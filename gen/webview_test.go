@@ -0,0 +1,140 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCollectWebViewAssets(t *testing.T) {
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "help", Type: "web", Html: "help/index.html"},
+				{Id: "faq", Type: "web", Html: "help/faq/index.html"},
+				{Id: "terms", Type: "web", Html: "terms/index.html"},
+				{Id: "about", Type: "web", Html: "about.html"},
+				{Id: "site", Type: "web", Url: "https://example.com/"},
+			}},
+		}},
+	}}
+	expect := []webViewAsset{{"about.html", false}, {"help", true}, {"terms", true}}
+	assets := collectWebViewAssets(&mock)
+	if len(assets) != len(expect) {
+		t.Fatalf("Expected %v but %v", expect, assets)
+	}
+	for i := range expect {
+		if assets[i] != expect[i] {
+			t.Errorf("Expected %v but %v", expect, assets)
+		}
+	}
+}
+
+func TestGenAndroidWebAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mocker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	inDir, mainDir := filepath.Join(dir, "in"), filepath.Join(dir, "out")
+	for _, f := range []string{"help/index.html", "help/css/style.css", "help/faq/index.html", "about.html", "logo.png"} {
+		createFile(filepath.Join(inDir, AssetsDir, filepath.FromSlash(f))).Close()
+	}
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "help", Type: "web", Html: "help/index.html"},
+				{Id: "about", Type: "web", Html: "about.html"},
+			}},
+		}},
+	}}
+	genAndroidWebAssets(&mock, inDir, mainDir)
+	var testcases = []struct {
+		file   string
+		expect bool
+	}{
+		// Files relative to the HTML file are copied with it
+		{"help/index.html", true},
+		{"help/css/style.css", true},
+		{"help/faq/index.html", true},
+		{"about.html", true},
+		{"logo.png", false},
+	}
+	for _, tc := range testcases {
+		if actual := fileExists(filepath.Join(mainDir, "assets", filepath.FromSlash(tc.file))); actual != tc.expect {
+			t.Errorf("Expected %t but %t: %s", tc.expect, actual, tc.file)
+		}
+	}
+}
+
+func TestGenWebViews(t *testing.T) {
+	defineWebWidgets()
+	dir, err := ioutil.TempDir("", "mocker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, f := range []string{"help/index.html", "help/css/style.css", "logo.png"} {
+		createFile(filepath.Join(dir, AssetsDir, filepath.FromSlash(f))).Close()
+	}
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "help", Type: "web", Html: "help/index.html"},
+				{Id: "site", Type: "web", Url: "https://example.com/?a=1&b=2"},
+			}},
+		}},
+	}}
+	var buf CodeBuffer
+	genCodeWebPage(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`<iframe id="help" class="web" src="assets/help/index.html"`,
+		`<iframe id="site" class="web" src="https://example.com/?a=1&amp;b=2"`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+
+	files := WebFiles(&mock, dir)
+	var testcases = []struct {
+		file   string
+		expect bool
+	}{
+		{"assets/help/index.html", true},
+		{"assets/help/css/style.css", true},
+		{"assets/logo.png", false},
+	}
+	for _, tc := range testcases {
+		if _, actual := files[tc.file]; actual != tc.expect {
+			t.Errorf("Expected %t but %t: %s", tc.expect, actual, tc.file)
+		}
+	}
+}
+
+func TestGenSwiftUIWebViews(t *testing.T) {
+	defineSwiftUIWidgets()
+	mock := Mock{Screens: []Screen{
+		{Id: "top", Layout: []View{
+			{Type: "linear", Sub: []View{
+				{Id: "help", Type: "web", Html: "help/./index.html"},
+				{Id: "site", Type: "web", Url: "https://example.com/"},
+			}},
+		}},
+	}}
+	var buf CodeBuffer
+	genCodeSwiftUIView(&mock, mock.Screens[0], &buf)
+	code := strings.Join(buf, "\n")
+	for _, expect := range []string{
+		`WebPage(html: "help/index.html")
+                .accessibilityIdentifier("help")`,
+		`WebPage(url: "https://example.com/")`,
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("Expected %q in\n%s", expect, code)
+		}
+	}
+}
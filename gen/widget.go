@@ -1,11 +1,21 @@
 package gen
 
 const (
-	GravityCenter       = "center"
-	GravityCenterV      = "center_v"
-	SizeFill            = "fill"
-	SizeWrap            = "wrap"
-	OrientationVertical = "vertical"
+	GravityCenter         = "center"
+	GravityCenterV        = "center_v"
+	SizeFill              = "fill"
+	SizeWrap              = "wrap"
+	OrientationVertical   = "vertical"
+	OrientationHorizontal = "horizontal"
+	AlignLeft             = "left"
+	AlignRight            = "right"
+	AlignTop              = "top"
+	AlignBottom           = "bottom"
+	AlignCenter           = "center"
+	ScaleFit              = "fit"
+	ScaleFill             = "fill"
+	ScaleStretch          = "stretch"
+	ScaleCenter           = "center"
 )
 
 // Default layout params for widgets
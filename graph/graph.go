@@ -0,0 +1,101 @@
+// Package graph draws the navigation between the screens
+// which is described by the behaviors.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/ksoichiro/mocker/gen"
+)
+
+type Graph struct {
+	Name   string
+	Nodes  []Node
+	Edges  []Edge
+	Launch string
+}
+
+// Node is the screen.
+type Node struct {
+	Id    string
+	Label string
+}
+
+// Edge is the behavior which transits to the other screen.
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// New builds the graph from the screens.
+// Behaviors transiting to the undefined screens are ignored.
+func New(mock *gen.Mock) *Graph {
+	g := &Graph{Name: mock.Name, Launch: mock.Launch.Screen}
+	screens := map[string]bool{}
+	for _, screen := range mock.Screens {
+		g.Nodes = append(g.Nodes, Node{Id: screen.Id, Label: screen.Name})
+		screens[screen.Id] = true
+	}
+	for _, screen := range mock.Screens {
+		for _, b := range screen.Behaviors {
+			if b.Action.Transit == "" || !screens[b.Action.Transit] {
+				continue
+			}
+			g.Edges = append(g.Edges, Edge{
+				From:  screen.Id,
+				To:    b.Action.Transit,
+				Label: strings.TrimSpace(b.Trigger.Type + " " + b.Trigger.Widget),
+			})
+		}
+	}
+	return g
+}
+
+// Dot writes the graph in the DOT language of Graphviz.
+func (g *Graph) Dot(w io.Writer) {
+	fmt.Fprintf(w, "digraph %s {\n", dotQuote(g.Name))
+	fmt.Fprintf(w, "    node [shape=box];\n")
+	for _, n := range g.Nodes {
+		if n.Id == g.Launch {
+			fmt.Fprintf(w, "    %s [label=%s, style=\"bold,filled\", fillcolor=\"#ffe08a\"];\n", dotQuote(n.Id), dotQuote(n.Label))
+		} else {
+			fmt.Fprintf(w, "    %s [label=%s];\n", dotQuote(n.Id), dotQuote(n.Label))
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(w, "    %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Label))
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Mermaid writes the graph as the flowchart of Mermaid.
+func (g *Graph) Mermaid(w io.Writer) {
+	fmt.Fprintf(w, "flowchart TD\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(w, "    %s[%s]\n", mermaidId(n.Id), mermaidQuote(n.Label))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(w, "    %s -->|%s| %s\n", mermaidId(e.From), mermaidQuote(e.Label), mermaidId(e.To))
+	}
+	fmt.Fprintf(w, "    classDef launch fill:#ffe08a,stroke-width:3px\n")
+	fmt.Fprintf(w, "    class %s launch\n", mermaidId(g.Launch))
+}
+
+var mermaidInvalidChars = regexp.MustCompile(`[^0-9A-Za-z_]`)
+
+// IDs are prefixed to avoid the keywords such as "end".
+func mermaidId(id string) string {
+	return "screen_" + mermaidInvalidChars.ReplaceAllString(id, "_")
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.Replace(s, `"`, "#quot;", -1) + `"`
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ksoichiro/mocker/gen"
+)
+
+func testMock() *gen.Mock {
+	return &gen.Mock{
+		Name: "Demo",
+		Screens: []gen.Screen{
+			{Id: "top", Name: "Top", Behaviors: []gen.Behavior{
+				{Trigger: gen.Trigger{Type: "click", Widget: "next"}, Action: gen.Action{Type: "transit_forward", Transit: "second"}},
+				{Trigger: gen.Trigger{Type: "click", Widget: "missing"}, Action: gen.Action{Type: "transit_forward", Transit: "unknown"}},
+			}},
+			{Id: "second", Name: `Say "Hi"`, Behaviors: []gen.Behavior{
+				{Trigger: gen.Trigger{Type: "click", Widget: "back"}, Action: gen.Action{Type: "transit_forward", Transit: "top"}},
+			}},
+			{Id: "end", Name: "Orphan"},
+		},
+		Launch: gen.Launch{Screen: "top"},
+	}
+}
+
+func TestNew(t *testing.T) {
+	g := New(testMock())
+	if len(g.Nodes) != 3 {
+		t.Errorf("Expected 3 nodes but %d", len(g.Nodes))
+	}
+	expect := []Edge{{"top", "second", "click next"}, {"second", "top", "click back"}}
+	if len(g.Edges) != len(expect) {
+		t.Fatalf("Expected %v but %v", expect, g.Edges)
+	}
+	for i := range expect {
+		if g.Edges[i] != expect[i] {
+			t.Errorf("Expected %v but %v", expect[i], g.Edges[i])
+		}
+	}
+	levels := g.levels()
+	if levels["top"] != 0 || levels["second"] != 1 || levels["end"] != 2 {
+		t.Errorf("Unexpected levels: %v", levels)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	g := New(testMock())
+	var testcases = []struct {
+		name   string
+		write  func(w *bytes.Buffer)
+		expect []string
+	}{
+		{"dot", func(w *bytes.Buffer) { g.Dot(w) }, []string{
+			`digraph "Demo" {`,
+			`"top" [label="Top", style="bold,filled", fillcolor="#ffe08a"];`,
+			`"second" [label="Say \"Hi\""];`,
+			`"top" -> "second" [label="click next"];`,
+		}},
+		{"mermaid", func(w *bytes.Buffer) { g.Mermaid(w) }, []string{
+			`screen_second["Say #quot;Hi#quot;"]`,
+			`screen_end["Orphan"]`,
+			`screen_second -->|"click back"| screen_top`,
+			`class screen_top launch`,
+		}},
+		{"svg", func(w *bytes.Buffer) { g.Svg(w) }, []string{
+			`fill="#ffe08a" stroke="#333333" stroke-width="3"`,
+			`>Say &#34;Hi&#34;</text>`,
+			`>click back</text>`,
+		}},
+	}
+	for _, tc := range testcases {
+		var b bytes.Buffer
+		tc.write(&b)
+		for _, expect := range tc.expect {
+			if !strings.Contains(b.String(), expect) {
+				t.Errorf("%s: expected %q in\n%s", tc.name, expect, b.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package graph
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+)
+
+const (
+	svgMargin   = 40
+	svgNodeH    = 40
+	svgColGap   = 40
+	svgRowGap   = 100
+	svgLoopSize = 40
+	svgCurve    = 30
+)
+
+type svgBox struct {
+	x, y, w, h float64
+}
+
+func (b svgBox) center() (float64, float64) {
+	return b.x + b.w/2, b.y + b.h/2
+}
+
+// Returns the point on the border of the box toward (x, y).
+func (b svgBox) border(x, y float64) (float64, float64) {
+	cx, cy := b.center()
+	dx, dy := x-cx, y-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	t := math.Min(b.w/2/math.Abs(dx), b.h/2/math.Abs(dy))
+	return cx + dx*t, cy + dy*t
+}
+
+// Svg draws the graph without Graphviz.
+// Screens are placed in rows by the number of transitions from the launch screen,
+// and the screens which cannot be reached are placed in the last row.
+func (g *Graph) Svg(w io.Writer) {
+	boxes, width, height := g.layoutSvg()
+
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="14">
+    <defs>
+        <marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto">
+            <path d="M 0 0 L 10 5 L 0 10 z" fill="#333333"/>
+        </marker>
+    </defs>
+`, width, height, width, height)
+
+	// Edges between the same screens are curved differently
+	parallels := map[[2]string]int{}
+	for _, e := range g.Edges {
+		key := [2]string{e.From, e.To}
+		g.writeSvgEdge(w, e, boxes, parallels[key])
+		parallels[key]++
+	}
+
+	for _, n := range g.Nodes {
+		b := boxes[n.Id]
+		fill, strokeWidth := "#ffffff", 1
+		if n.Id == g.Launch {
+			fill, strokeWidth = "#ffe08a", 3
+		}
+		cx, cy := b.center()
+		fmt.Fprintf(w, `    <rect x="%.0f" y="%.0f" width="%.0f" height="%.0f" rx="4" fill="%s" stroke="#333333" stroke-width="%d"/>
+    <text x="%.0f" y="%.0f" text-anchor="middle" dominant-baseline="central">%s</text>
+`, b.x, b.y, b.w, b.h, fill, strokeWidth, cx, cy, html.EscapeString(n.Label))
+	}
+	fmt.Fprintf(w, "</svg>\n")
+}
+
+func (g *Graph) layoutSvg() (boxes map[string]svgBox, width, height int) {
+	levels := g.levels()
+	var rows [][]Node
+	for _, n := range g.Nodes {
+		l := levels[n.Id]
+		for len(rows) <= l {
+			rows = append(rows, nil)
+		}
+		rows[l] = append(rows[l], n)
+	}
+
+	rowWidths := make([]float64, len(rows))
+	maxRowWidth := 0.0
+	for i, row := range rows {
+		for j, n := range row {
+			if 0 < j {
+				rowWidths[i] += svgColGap
+			}
+			rowWidths[i] += svgNodeWidth(n.Label)
+		}
+		maxRowWidth = math.Max(maxRowWidth, rowWidths[i])
+	}
+
+	boxes = map[string]svgBox{}
+	for i, row := range rows {
+		x := svgMargin + (maxRowWidth-rowWidths[i])/2
+		y := float64(svgMargin + i*(svgNodeH+svgRowGap))
+		for _, n := range row {
+			w := svgNodeWidth(n.Label)
+			boxes[n.Id] = svgBox{x, y, w, svgNodeH}
+			x += w + svgColGap
+		}
+	}
+	// Extra space on the right for the loops and their labels
+	extra := 0
+	for _, e := range g.Edges {
+		if e.From == e.To {
+			extra = max(extra, svgLoopSize+svgTextWidth(e.Label))
+		}
+	}
+	width = int(maxRowWidth) + svgMargin*2 + extra
+	height = svgMargin*2 + len(rows)*svgNodeH + max(len(rows)-1, 0)*svgRowGap
+	return
+}
+
+// Numbers of the transitions from the launch screen.
+// Unreachable screens have the number next to the largest one.
+func (g *Graph) levels() map[string]int {
+	levels := map[string]int{}
+	queue := []string{}
+	for _, n := range g.Nodes {
+		if n.Id == g.Launch {
+			levels[n.Id] = 0
+			queue = append(queue, n.Id)
+		}
+	}
+	last := 0
+	for 0 < len(queue) {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			if _, visited := levels[e.To]; e.From != id || visited {
+				continue
+			}
+			levels[e.To] = levels[id] + 1
+			last = max(last, levels[e.To])
+			queue = append(queue, e.To)
+		}
+	}
+	for _, n := range g.Nodes {
+		if _, ok := levels[n.Id]; !ok {
+			levels[n.Id] = last + 1
+		}
+	}
+	return levels
+}
+
+func svgNodeWidth(label string) float64 {
+	return float64(max(120, svgTextWidth(label)+32))
+}
+
+// Estimates the width of the text since it's rendered by the viewers.
+func svgTextWidth(s string) (w int) {
+	for _, r := range s {
+		if 0x1100 <= r {
+			w += 15
+		} else {
+			w += 8
+		}
+	}
+	return
+}
+
+func (g *Graph) writeSvgEdge(w io.Writer, e Edge, boxes map[string]svgBox, index int) {
+	from, to := boxes[e.From], boxes[e.To]
+	if e.From == e.To {
+		// Loop on the right side of the screen
+		size := float64(svgLoopSize + index*svgLoopSize/2)
+		x, cy := from.x+from.w, from.y+from.h/2
+		fmt.Fprintf(w, `    <path d="M %.0f %.0f C %.0f %.0f %.0f %.0f %.0f %.0f" fill="none" stroke="#333333" marker-end="url(#arrow)"/>
+`, x, cy-10, x+size, cy-size, x+size, cy+size, x, cy+10)
+		writeSvgEdgeLabel(w, x+size*0.75+4, cy, "start", e.Label)
+		return
+	}
+
+	fcx, fcy := from.center()
+	tcx, tcy := to.center()
+	x1, y1 := from.border(tcx, tcy)
+	x2, y2 := to.border(fcx, fcy)
+
+	// Edges going down are straight unless they are parallel,
+	// and the others are curved to be apart from them.
+	offset := float64((index+1)/2*svgCurve) * math.Pow(-1, float64(index))
+	if to.y <= from.y {
+		offset = float64((index + 1) * svgCurve)
+	}
+	dx, dy := x2-x1, y2-y1
+	l := math.Hypot(dx, dy)
+	mx, my := (x1+x2)/2-dy/l*offset, (y1+y2)/2+dx/l*offset
+	fmt.Fprintf(w, `    <path d="M %.0f %.0f Q %.0f %.0f %.0f %.0f" fill="none" stroke="#333333" marker-end="url(#arrow)"/>
+`, x1, y1, mx, my, x2, y2)
+	writeSvgEdgeLabel(w, 0.25*x1+0.5*mx+0.25*x2, 0.25*y1+0.5*my+0.25*y2, "middle", e.Label)
+}
+
+func writeSvgEdgeLabel(w io.Writer, x, y float64, anchor, label string) {
+	fmt.Fprintf(w, `    <text x="%.0f" y="%.0f" font-size="12" fill="#555555" text-anchor="%s" dominant-baseline="central" stroke="#ffffff" stroke-width="4" paint-order="stroke">%s</text>
+`, x, y, anchor, html.EscapeString(label))
+}
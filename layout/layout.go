@@ -0,0 +1,502 @@
+// Package layout resolves view trees into absolute frames
+// with the rules of the linear, the relative, the frame, the grid and the scroll layouts.
+package layout
+
+const (
+	Fill = "fill"
+	Wrap = "wrap"
+
+	Linear   = "linear"
+	Relative = "relative"
+	Scroll   = "scroll"
+	Grid     = "grid"
+	// Named after Android's layout since Frame is the resolved position
+	FrameLayout = "frame"
+
+	Vertical   = "vertical"
+	Horizontal = "horizontal"
+
+	Left    = "left"
+	Right   = "right"
+	Top     = "top"
+	Bottom  = "bottom"
+	Center  = "center"
+	CenterV = "center_v"
+)
+
+// Node is a view to be laid out.
+// Types other than the containers are treated as widgets.
+type Node struct {
+	Id      string
+	Type    string
+	SizeW   string
+	SizeH   string
+	AlignH  string
+	AlignV  string
+	Gravity string
+	Below   string
+	Margin  int
+	Padding int
+	// Direction to stack the sub nodes of Linear or to scroll the content of Scroll
+	Orientation string
+	// Share of the rest of the size in Linear
+	Weight float64
+	// Number of the columns and the rows of Grid.
+	// Rows share the height equally if Rows is set.
+	Columns int
+	Rows    int
+	// Number of the columns which the node spans in Grid
+	Span int
+	// Size of the widget contents used when the size is Wrap.
+	// Containers calculate it from the sub nodes instead.
+	ContentW int
+	ContentH int
+	Sub      []Node
+}
+
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Frame is the resolved position of the node.
+// X and Y are absolute in the screen.
+type Frame struct {
+	Rect
+	Node *Node
+	Sub  []Frame
+}
+
+// Resolve lays out the root node in the screen of the given size.
+// The root node is placed like a sub view of a relative layout without padding.
+func Resolve(root *Node, width, height int) Frame {
+	screen := Node{Type: Relative}
+	rects := place(&screen, []Node{*root}, Rect{0, 0, width, height})
+	return resolve(root, rects[0])
+}
+
+func resolve(node *Node, r Rect) Frame {
+	f := Frame{Rect: r, Node: node}
+	if !isContainer(node) {
+		return f
+	}
+	rects := place(node, node.Sub, r)
+	for i := range node.Sub {
+		f.Sub = append(f.Sub, resolve(&node.Sub[i], rects[i]))
+	}
+	return f
+}
+
+// Measure returns the size of the node when it's wrapped.
+// Widths of the contents are limited with maxW.
+func Measure(node *Node, maxW int) (w, h int) {
+	if !isContainer(node) {
+		return min(node.ContentW, maxW), node.ContentH
+	}
+	innerW := maxW - node.Padding*2
+	if node.Type == Grid {
+		w, h = measureGrid(node, innerW)
+		return w + node.Padding*2, h + node.Padding*2
+	}
+	row := isRow(node)
+	bottoms := map[string]int{}
+	for i := range node.Sub {
+		sub := &node.Sub[i]
+		m := sub.Margin
+		sw := innerW - m*2
+		// Views in the row are wrapped to be placed side by side
+		if sub.SizeW != Fill || row {
+			sw, _ = Measure(sub, sw)
+		}
+		_, sh := Measure(sub, sw)
+		if row {
+			w += sw + m*2
+			h = max(h, sh+m*2)
+			continue
+		}
+		w = max(w, sw+m*2)
+		switch node.Type {
+		case Linear, Scroll:
+			h += sh + m*2
+		case FrameLayout:
+			h = max(h, sh+m*2)
+		default:
+			top := 0
+			if b, ok := bottoms[sub.Below]; ok && sub.Below != "" {
+				top = b
+			}
+			bottoms[sub.Id] = top + sh + m*2
+			h = max(h, top+sh+m*2)
+		}
+	}
+	if row {
+		w = min(w, innerW)
+	}
+	return w + node.Padding*2, h + node.Padding*2
+}
+
+// Views in the grid are measured in the cells,
+// and the widest one determines the width of the columns.
+func measureGrid(node *Node, innerW int) (w, h int) {
+	cells := gridCells(node.Columns, node.Sub)
+	heights := map[int]int{}
+	for i, c := range cells {
+		sub := &node.Sub[i]
+		m := sub.Margin
+		cellW := innerW * c.Span / c.Columns
+		sw := cellW - m*2
+		if sub.SizeW != Fill {
+			sw, _ = Measure(sub, sw)
+		}
+		_, sh := Measure(sub, sw)
+		w = max(w, (sw+m*2)*c.Columns/c.Span)
+		heights[c.Row] = max(heights[c.Row], sh+m*2)
+	}
+	for _, rh := range heights {
+		h += rh
+	}
+	return min(w, innerW), h
+}
+
+// Calculates the rects of the sub nodes inside the container.
+func place(container *Node, subs []Node, r Rect) []Rect {
+	p := container.Padding
+	inner := Rect{r.X + p, r.Y + p, r.W - p*2, r.H - p*2}
+	rects := make([]Rect, len(subs))
+	heights := make([]int, len(subs))
+
+	if container.Type == Scroll {
+		// Content takes the size it needs in the scrolling direction,
+		// and fills the scroll view at least if it matches the parent
+		for i := range subs {
+			sub := &subs[i]
+			m := sub.Margin
+			viewW, viewH := inner.W-m*2, inner.H-m*2
+			w := viewW
+			if container.Orientation == Horizontal {
+				w = naturalWidth(sub)
+				if sub.SizeW == Fill {
+					w = max(w, viewW)
+				}
+			} else if sub.SizeW != Fill {
+				w, _ = Measure(sub, viewW)
+			}
+			_, h := Measure(sub, w)
+			if sub.SizeH == Fill {
+				if container.Orientation == Horizontal {
+					h = viewH
+				} else {
+					h = max(h, viewH)
+				}
+			}
+			rects[i] = Rect{inner.X + m, inner.Y + m, w, h}
+		}
+		return rects
+	}
+	if isRow(container) {
+		return placeRow(container, subs, inner)
+	}
+	if container.Type == FrameLayout {
+		// Views overlap each other in the frame
+		for i := range subs {
+			rects[i] = placeInCell(container, &subs[i], inner)
+		}
+		return rects
+	}
+	if container.Type == Grid {
+		return placeGrid(container, subs, inner)
+	}
+
+	// Horizontal position is common to the layouts
+	for i := range subs {
+		sub := &subs[i]
+		m := sub.Margin
+		w := inner.W - m*2
+		if sub.SizeW != Fill {
+			w, _ = Measure(sub, w)
+		}
+		alignH := sub.AlignH
+		if alignH == "" && container.Gravity == Center {
+			alignH = Center
+		}
+		switch alignH {
+		case Right:
+			rects[i].X = inner.X + inner.W - m - w
+		case Center:
+			rects[i].X = inner.X + (inner.W-w)/2
+		default:
+			rects[i].X = inner.X + m
+		}
+		rects[i].W = w
+		_, heights[i] = Measure(sub, w)
+	}
+
+	centerV := container.Gravity == Center || container.Gravity == CenterV
+	if container.Type == Linear {
+		// Views which have the weights share the rest of the height
+		fixed, weights := 0, 0.0
+		for i := range subs {
+			fixed += subs[i].Margin * 2
+			if w := weight(&subs[i], subs[i].SizeH); 0 < w {
+				weights += w
+			} else {
+				fixed += heights[i]
+			}
+		}
+		y := inner.Y
+		if weights == 0 && centerV {
+			y += (inner.H - fixed) / 2
+		}
+		for i := range subs {
+			m := subs[i].Margin
+			h := heights[i]
+			if w := weight(&subs[i], subs[i].SizeH); 0 < w {
+				h = max(int(float64(inner.H-fixed)*w/weights), 0)
+			}
+			rects[i].Y = y + m
+			rects[i].H = h
+			y += h + m*2
+		}
+		return rects
+	}
+
+	bottoms := map[string]int{}
+	for i := range subs {
+		sub := &subs[i]
+		m := sub.Margin
+		h := heights[i]
+		alignV := sub.AlignV
+		if alignV == "" && centerV {
+			alignV = Center
+		}
+		var top int
+		if b, ok := bottoms[sub.Below]; ok && sub.Below != "" {
+			top = b + m
+		} else if sub.SizeH == Fill {
+			top = inner.Y + m
+		} else {
+			switch alignV {
+			case Bottom:
+				top = inner.Y + inner.H - m - h
+			case Center:
+				top = inner.Y + (inner.H-h)/2
+			default:
+				top = inner.Y + m
+			}
+		}
+		if sub.SizeH == Fill {
+			h = max(inner.Y+inner.H-m-top, 0)
+		}
+		rects[i].Y = top
+		rects[i].H = h
+		if sub.Id != "" {
+			bottoms[sub.Id] = top + h + m
+		}
+	}
+	return rects
+}
+
+// Calculates the rects of the sub nodes placed side by side
+// in the horizontal linear layout.
+func placeRow(container *Node, subs []Node, inner Rect) []Rect {
+	rects := make([]Rect, len(subs))
+
+	// Views which have the weights share the rest of the width
+	fixed, weights := 0, 0.0
+	for i := range subs {
+		m := subs[i].Margin
+		fixed += m * 2
+		if w := weight(&subs[i], subs[i].SizeW); 0 < w {
+			weights += w
+		} else {
+			rects[i].W, _ = Measure(&subs[i], inner.W-m*2)
+			fixed += rects[i].W
+		}
+	}
+	x := inner.X
+	if weights == 0 && container.Gravity == Center {
+		x += (inner.W - fixed) / 2
+	}
+	centerV := container.Gravity == Center || container.Gravity == CenterV
+	for i := range subs {
+		sub := &subs[i]
+		m := sub.Margin
+		if w := weight(sub, sub.SizeW); 0 < w {
+			rects[i].W = max(int(float64(inner.W-fixed)*w/weights), 0)
+		}
+		_, h := Measure(sub, rects[i].W)
+		alignV := sub.AlignV
+		if alignV == "" && centerV {
+			alignV = Center
+		}
+		switch {
+		case sub.SizeH == Fill:
+			h = max(inner.H-m*2, 0)
+			rects[i].Y = inner.Y + m
+		case alignV == Bottom:
+			rects[i].Y = inner.Y + inner.H - m - h
+		case alignV == Center:
+			rects[i].Y = inner.Y + (inner.H-h)/2
+		default:
+			rects[i].Y = inner.Y + m
+		}
+		rects[i].X = x + m
+		rects[i].H = h
+		x += rects[i].W + m*2
+	}
+	return rects
+}
+
+// Calculates the rects of the sub nodes in the cells of the grid.
+// Rows take the height of the tallest views in them
+// unless the rows share the height.
+func placeGrid(container *Node, subs []Node, inner Rect) []Rect {
+	rects := make([]Rect, len(subs))
+	cells := gridCells(container.Columns, subs)
+	rows := container.Rows
+	heights := []int{}
+	for i, c := range cells {
+		for len(heights) <= c.Row {
+			heights = append(heights, 0)
+		}
+		m := subs[i].Margin
+		w := inner.W*c.Span/c.Columns - m*2
+		if subs[i].SizeW != Fill {
+			w, _ = Measure(&subs[i], w)
+		}
+		_, h := Measure(&subs[i], w)
+		heights[c.Row] = max(heights[c.Row], h+m*2)
+	}
+	if 0 < rows {
+		rows = max(rows, len(heights))
+		for r := range heights {
+			heights[r] = inner.H / rows
+		}
+	}
+	tops := make([]int, len(heights))
+	for r := 1; r < len(heights); r++ {
+		tops[r] = tops[r-1] + heights[r-1]
+	}
+	for i, c := range cells {
+		x := inner.X + inner.W*c.Column/c.Columns
+		cell := Rect{x, inner.Y + tops[c.Row], inner.X + inner.W*(c.Column+c.Span)/c.Columns - x, heights[c.Row]}
+		rects[i] = placeInCell(container, &subs[i], cell)
+	}
+	return rects
+}
+
+// Calculates the rect of the node placed with AlignH and AlignV in the cell.
+func placeInCell(container, sub *Node, cell Rect) Rect {
+	m := sub.Margin
+	r := Rect{W: cell.W - m*2}
+	if sub.SizeW != Fill {
+		r.W, _ = Measure(sub, r.W)
+	}
+	alignH := sub.AlignH
+	if alignH == "" && container.Gravity == Center {
+		alignH = Center
+	}
+	switch alignH {
+	case Right:
+		r.X = cell.X + cell.W - m - r.W
+	case Center:
+		r.X = cell.X + (cell.W-r.W)/2
+	default:
+		r.X = cell.X + m
+	}
+	_, r.H = Measure(sub, r.W)
+	alignV := sub.AlignV
+	if alignV == "" && (container.Gravity == Center || container.Gravity == CenterV) {
+		alignV = Center
+	}
+	switch {
+	case sub.SizeH == Fill:
+		r.H = max(cell.H-m*2, 0)
+		r.Y = cell.Y + m
+	case alignV == Bottom:
+		r.Y = cell.Y + cell.H - m - r.H
+	case alignV == Center:
+		r.Y = cell.Y + (cell.H-r.H)/2
+	default:
+		r.Y = cell.Y + m
+	}
+	return r
+}
+
+// Cell is the position of the view in the grid.
+type Cell struct {
+	Row, Column, Span int
+	// Number of the columns of the grid
+	Columns int
+}
+
+// GridCells places the views into the cells from left to right,
+// and wraps them when the row doesn't have enough columns.
+// Spans are limited with the number of the columns.
+func GridCells(columns int, spans []int) []Cell {
+	columns = max(columns, 1)
+	cells := make([]Cell, len(spans))
+	row, column := 0, 0
+	for i, span := range spans {
+		span = min(max(span, 1), columns)
+		if columns < column+span {
+			row, column = row+1, 0
+		}
+		cells[i] = Cell{row, column, span, columns}
+		column += span
+	}
+	return cells
+}
+
+func gridCells(columns int, subs []Node) []Cell {
+	spans := make([]int, len(subs))
+	for i := range subs {
+		spans[i] = subs[i].Span
+	}
+	return GridCells(columns, spans)
+}
+
+// Weight of the node in the direction of the linear layout.
+// Nodes which fill the parent without the weight share the rest equally.
+func weight(node *Node, size string) float64 {
+	if 0 < node.Weight {
+		return node.Weight
+	}
+	if size == Fill {
+		return 1
+	}
+	return 0
+}
+
+func isRow(node *Node) bool {
+	return node.Type == Linear && node.Orientation == Horizontal
+}
+
+// Returns the width of the node when the width is not limited,
+// where the views which fill the parent are wrapped.
+func naturalWidth(node *Node) (w int) {
+	if !isContainer(node) {
+		return node.ContentW
+	}
+	cells := gridCells(node.Columns, node.Sub)
+	for i := range node.Sub {
+		sw := naturalWidth(&node.Sub[i]) + node.Sub[i].Margin*2
+		switch {
+		case isRow(node):
+			w += sw
+		case node.Type == Grid:
+			// Columns are as wide as the widest view
+			w = max(w, sw*cells[i].Columns/cells[i].Span)
+		default:
+			w = max(w, sw)
+		}
+	}
+	return w + node.Padding*2
+}
+
+func isContainer(node *Node) bool {
+	switch node.Type {
+	case Linear, Relative, Scroll, FrameLayout, Grid:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package layout
+
+import "testing"
+
+func widget(id string, w, h int) Node {
+	return Node{Id: id, Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: w, ContentH: h}
+}
+
+func TestResolve(t *testing.T) {
+	var testcases = []struct {
+		name   string
+		root   Node
+		expect []Rect
+	}{
+		{
+			"linear stacks views vertically",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 100, 20),
+				widget("b", 50, 30),
+			}},
+			[]Rect{{0, 0, 100, 20}, {0, 20, 50, 30}},
+		},
+		{
+			"linear with padding and margin",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Padding: 10, Sub: []Node{
+				{Id: "a", Type: "label", SizeW: Fill, SizeH: Wrap, ContentH: 20, Margin: 5},
+				widget("b", 50, 30),
+			}},
+			[]Rect{{15, 15, 270, 20}, {10, 40, 50, 30}},
+		},
+		{
+			"linear shares the rest of the height",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 100, 100),
+				{Id: "b", Type: "label", SizeW: Fill, SizeH: Fill},
+				{Id: "c", Type: "label", SizeW: Fill, SizeH: Fill},
+			}},
+			[]Rect{{0, 0, 100, 100}, {0, 100, 300, 150}, {0, 250, 300, 150}},
+		},
+		{
+			"linear with center gravity",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Gravity: Center, Sub: []Node{
+				widget("a", 100, 20),
+				widget("b", 50, 30),
+			}},
+			[]Rect{{100, 175, 100, 20}, {125, 195, 50, 30}},
+		},
+		{
+			"linear with align_h",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Padding: 10, Sub: []Node{
+				{Id: "a", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 100, ContentH: 20, AlignH: Right},
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 100, ContentH: 20, AlignH: Center},
+			}},
+			[]Rect{{190, 10, 100, 20}, {100, 30, 100, 20}},
+		},
+		{
+			"linear shares the rest of the height with weights",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 100, 100),
+				{Id: "b", Type: "label", SizeW: Fill, SizeH: Wrap, Weight: 1},
+				{Id: "c", Type: "label", SizeW: Fill, SizeH: Wrap, Weight: 2},
+			}},
+			[]Rect{{0, 0, 100, 100}, {0, 100, 300, 100}, {0, 200, 300, 200}},
+		},
+		{
+			"horizontal linear places views side by side",
+			Node{Type: Linear, Orientation: Horizontal, SizeW: Fill, SizeH: Fill, Padding: 10, Sub: []Node{
+				widget("a", 100, 20),
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 50, ContentH: 30, Margin: 5},
+			}},
+			[]Rect{{10, 10, 100, 20}, {115, 15, 50, 30}},
+		},
+		{
+			"horizontal linear shares the rest of the width",
+			Node{Type: Linear, Orientation: Horizontal, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 60, 20),
+				{Id: "b", Type: "label", SizeW: Fill, SizeH: Wrap, ContentH: 20},
+				{Id: "c", Type: "label", SizeW: Wrap, SizeH: Fill, Weight: 2},
+			}},
+			[]Rect{{0, 0, 60, 20}, {60, 0, 80, 20}, {140, 0, 160, 400}},
+		},
+		{
+			"horizontal linear with center gravity",
+			Node{Type: Linear, Orientation: Horizontal, SizeW: Fill, SizeH: Fill, Gravity: Center, Sub: []Node{
+				widget("a", 100, 20),
+				widget("b", 50, 30),
+			}},
+			[]Rect{{75, 190, 100, 20}, {175, 185, 50, 30}},
+		},
+		{
+			"relative places views with below",
+			Node{Type: Relative, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 100, 20),
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 50, ContentH: 30, Below: "a", Margin: 5},
+			}},
+			[]Rect{{0, 0, 100, 20}, {5, 25, 50, 30}},
+		},
+		{
+			"relative with align_v",
+			Node{Type: Relative, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				{Id: "a", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 100, ContentH: 20, AlignV: Bottom},
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 100, ContentH: 20, AlignV: Center},
+			}},
+			[]Rect{{0, 380, 100, 20}, {0, 190, 100, 20}},
+		},
+		{
+			"relative fills below the sibling",
+			Node{Type: Relative, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 100, 20),
+				{Id: "b", Type: "label", SizeW: Fill, SizeH: Fill, Below: "a"},
+			}},
+			[]Rect{{0, 0, 100, 20}, {0, 20, 300, 380}},
+		},
+		{
+			"frame overlaps views",
+			Node{Type: FrameLayout, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				{Id: "a", Type: "label", SizeW: Fill, SizeH: Fill},
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 40, ContentH: 20, AlignH: Right},
+				{Id: "c", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 100, ContentH: 20, AlignH: Center, AlignV: Center},
+			}},
+			[]Rect{{0, 0, 300, 400}, {260, 0, 40, 20}, {100, 190, 100, 20}},
+		},
+		{
+			"grid places views in the cells",
+			Node{Type: Grid, SizeW: Fill, SizeH: Fill, Columns: 3, Sub: []Node{
+				widget("a", 50, 20),
+				{Id: "b", Type: "label", SizeW: Fill, SizeH: Wrap, ContentH: 30, Span: 2},
+				{Id: "c", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 50, ContentH: 20, AlignH: Center},
+				{Id: "d", Type: "label", SizeW: Fill, SizeH: Wrap, ContentH: 20, Span: 5},
+			}},
+			[]Rect{{0, 0, 50, 20}, {100, 0, 200, 30}, {25, 30, 50, 20}, {0, 50, 300, 20}},
+		},
+		{
+			"grid rows share the height",
+			Node{Type: Grid, SizeW: Fill, SizeH: Fill, Columns: 2, Rows: 2, Sub: []Node{
+				{Id: "a", Type: "label", SizeW: Fill, SizeH: Fill},
+				{Id: "b", Type: "label", SizeW: Wrap, SizeH: Wrap, ContentW: 50, ContentH: 20, AlignV: Bottom},
+			}},
+			[]Rect{{0, 0, 150, 200}, {150, 180, 50, 20}},
+		},
+		{
+			"wrapped container fits the contents",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				{Type: Linear, SizeW: Wrap, SizeH: Wrap, Padding: 5, Sub: []Node{
+					widget("a", 100, 20),
+					widget("b", 50, 30),
+				}},
+				widget("c", 10, 10),
+			}},
+			[]Rect{{0, 0, 110, 60}, {0, 60, 10, 10}},
+		},
+		{
+			"contents wider than the parent are clipped",
+			Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+				widget("a", 500, 20),
+			}},
+			[]Rect{{0, 0, 300, 20}},
+		},
+	}
+	for _, tc := range testcases {
+		f := Resolve(&tc.root, 300, 400)
+		if f.Rect != (Rect{0, 0, 300, 400}) {
+			t.Errorf("%s: expected root to fill the screen but %v", tc.name, f.Rect)
+		}
+		if len(f.Sub) != len(tc.expect) {
+			t.Errorf("%s: expected %d frames but %d", tc.name, len(tc.expect), len(f.Sub))
+			continue
+		}
+		for i, expect := range tc.expect {
+			if f.Sub[i].Rect != expect {
+				t.Errorf("%s: expected %v but %v: sub %d", tc.name, expect, f.Sub[i].Rect, i)
+			}
+		}
+	}
+}
+
+func TestResolveNested(t *testing.T) {
+	root := Node{Type: Relative, SizeW: Fill, SizeH: Fill, Padding: 10, Sub: []Node{
+		{Type: Linear, SizeW: Fill, SizeH: Wrap, Padding: 5, Sub: []Node{
+			widget("a", 100, 20),
+		}},
+	}}
+	f := Resolve(&root, 300, 400)
+	if expect := (Rect{10, 10, 280, 30}); f.Sub[0].Rect != expect {
+		t.Errorf("Expected %v but %v", expect, f.Sub[0].Rect)
+	}
+	// Frames are absolute in the screen
+	if expect := (Rect{15, 15, 100, 20}); f.Sub[0].Sub[0].Rect != expect {
+		t.Errorf("Expected %v but %v", expect, f.Sub[0].Sub[0].Rect)
+	}
+	if f.Sub[0].Sub[0].Node != &root.Sub[0].Sub[0] {
+		t.Errorf("Expected frame to refer the node")
+	}
+}
+
+func TestResolveScroll(t *testing.T) {
+	form := Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{
+		widget("a", 100, 300),
+		widget("b", 500, 300),
+	}}
+	var testcases = []struct {
+		name        string
+		orientation string
+		content     Node
+		expect      Rect
+	}{
+		{"vertical scroll extends the content", Vertical, form, Rect{10, 10, 280, 600}},
+		{"vertical scroll fills the content", Vertical, Node{Type: Linear, SizeW: Fill, SizeH: Fill, Sub: []Node{widget("a", 100, 20)}}, Rect{10, 10, 280, 380}},
+		{"horizontal scroll extends the content", Horizontal, form, Rect{10, 10, 500, 380}},
+		{"horizontal scroll wraps the content", Horizontal, Node{Type: Linear, SizeW: Wrap, SizeH: Wrap, Sub: []Node{widget("a", 100, 20)}}, Rect{10, 10, 100, 20}},
+	}
+	for _, tc := range testcases {
+		root := Node{Type: Scroll, SizeW: Fill, SizeH: Fill, Padding: 10, Orientation: tc.orientation, Sub: []Node{tc.content}}
+		f := Resolve(&root, 300, 400)
+		if f.Rect != (Rect{0, 0, 300, 400}) {
+			t.Errorf("%s: expected scroll to fill the screen but %v", tc.name, f.Rect)
+		}
+		if f.Sub[0].Rect != tc.expect {
+			t.Errorf("%s: expected %v but %v", tc.name, tc.expect, f.Sub[0].Rect)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	var testcases = []struct {
+		name    string
+		node    Node
+		maxW    int
+		expectW int
+		expectH int
+	}{
+		{"widget", widget("a", 100, 20), 300, 100, 20},
+		{"widget limited", widget("a", 100, 20), 80, 80, 20},
+		{"linear", Node{Type: Linear, Padding: 10, Sub: []Node{
+			widget("a", 100, 20),
+			{Id: "b", Type: "label", ContentW: 50, ContentH: 30, Margin: 5},
+		}}, 300, 120, 80},
+		{"horizontal linear", Node{Type: Linear, Orientation: Horizontal, Padding: 10, Sub: []Node{
+			widget("a", 100, 20),
+			{Id: "b", Type: "label", SizeW: Fill, ContentW: 50, ContentH: 30, Margin: 5},
+		}}, 300, 180, 60},
+		{"horizontal linear limited", Node{Type: Linear, Orientation: Horizontal, Sub: []Node{
+			widget("a", 200, 20),
+			widget("b", 200, 20),
+		}}, 300, 300, 20},
+		{"relative", Node{Type: Relative, Sub: []Node{
+			widget("a", 100, 20),
+			widget("b", 50, 30),
+			{Id: "c", Type: "label", ContentW: 50, ContentH: 30, Below: "a"},
+		}}, 300, 100, 50},
+		{"grid", Node{Type: Grid, Columns: 2, Sub: []Node{
+			widget("a", 100, 20),
+			widget("b", 50, 30),
+			{Id: "c", Type: "label", ContentW: 60, ContentH: 10, Span: 2},
+		}}, 300, 200, 40},
+	}
+	for _, tc := range testcases {
+		if w, h := Measure(&tc.node, tc.maxW); w != tc.expectW || h != tc.expectH {
+			t.Errorf("%s: expected %dx%d but %dx%d", tc.name, tc.expectW, tc.expectH, w, h)
+		}
+	}
+}
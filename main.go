@@ -1,14 +1,20 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/ksoichiro/mocker/analyze"
 	"github.com/ksoichiro/mocker/encoding/mockerfile"
+	"github.com/ksoichiro/mocker/export"
 	"github.com/ksoichiro/mocker/gen"
+	"github.com/ksoichiro/mocker/graph"
+	"github.com/ksoichiro/mocker/serve"
 )
 
 const (
@@ -26,6 +32,14 @@ func main() {
 	}
 	switch os.Args[1] {
 	case "gen", "g":
+	case "serve":
+		os.Exit(runServe(os.Args[2:]))
+	case "export":
+		os.Exit(runExport(os.Args[2:]))
+	case "graph":
+		os.Exit(runGraph(os.Args[2:]))
+	case "analyze":
+		os.Exit(runAnalyze(os.Args[2:]))
 	case "version":
 		printVersion()
 		os.Exit(ExitCodeSuccess)
@@ -56,6 +70,10 @@ func main() {
 		OutDir: *outDir,
 	}
 	mock := parseConfigs(&opt)
+	errs := append(gen.Validate(&mock), gen.ValidateGenerator(&mock, genId)...)
+	if printErrors("Invalid Mockerfile:", append(errs, gen.ValidateAssets(&mock, *inDir)...)) {
+		os.Exit(ExitCodeError)
+	}
 	//gen(&opt, &mock, genId)
 	g := gen.NewGenerator(&opt, &mock, genId)
 	if g == nil {
@@ -71,6 +89,10 @@ func printUsage() {
 Usage: %s command
 Command:
   g[en]    generate source code (see 'Generator')
+  serve    preview web prototype (see 'Server')
+  export   export screens as images (see 'Exporter')
+  graph    draw screen flow (see 'Graph')
+  analyze  find problems of screen flow (see 'Analyzer')
   help     show this help
   version  show version of mocker
 
@@ -79,18 +101,220 @@ Generator:
 
   ID:
     android  Java and XML code for Android app
-    ios      Objective-C code for iOS app
+    ios      Objective-C or Swift code for iOS app
+    swiftui  SwiftUI code for iOS app
+    web      HTML, CSS and JavaScript for clickable prototype
+    spec     Markdown and HTML specification document
 
   options:
     -in=".": Input directory which has Mockerfile
     -out="out": Output directory for generated codes
+
+Server:
+  mocker serve [options]
+
+  Open http://localhost:8080/?screen=ID to start from the screen.
+  Pages are reloaded when Mockerfile is changed.
+
+  options:
+    -in=".": Input directory which has Mockerfile
+    -addr=":8080": Address to listen on
+
+Exporter:
+  mocker export FORMAT [options]
+
+  FORMAT:
+    svg      SVG wireframe for each screen
+    png      PNG image for each screen
+
+  options:
+    -in=".": Input directory which has Mockerfile
+    -out="out": Output directory for exported images
+    -device="360x640": Size of the device to lay out the screens
+    -lang="base": Language of the strings to be rendered
+    -baseline="": Directory of PNG images to compare with
+
+Graph:
+  mocker graph [options]
+
+  options:
+    -in=".": Input directory which has Mockerfile
+    -format="dot": Format of the graph: dot, mermaid or svg
+    -out="": Output file, or standard output if empty
+
+Analyzer:
+  mocker analyze [options]
+
+  Exits with error if any problem is found.
+  Pushed screens without explicit exits are reported as info.
+
+  options:
+    -in=".": Input directory which has Mockerfile
+    -json=false: Print problems in JSON
 `, os.Args[0])
 }
 
+func runServe(args []string) int {
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		inDir = fs.String("in", ".", "Input directory which has Mockerfile.")
+		addr  = fs.String("addr", ":8080", "Address to listen on.")
+	)
+	fs.Parse(args)
+
+	fmt.Printf("Serving %s on %s\n", filepath.Join(*inDir, "Mockerfile"), *addr)
+	if err := serve.New(*inDir).ListenAndServe(*addr); err != nil {
+		fmt.Println("Error serving", err)
+		return ExitCodeError
+	}
+	return ExitCodeSuccess
+}
+
+func runExport(args []string) int {
+	// Export needs format ID
+	if len(args) < 1 {
+		printUsage()
+		return ExitCodeError
+	}
+	formatId := args[0]
+
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		inDir    = fs.String("in", ".", "Input directory which has Mockerfile.")
+		outDir   = fs.String("out", "out", "Output directory for exported images.")
+		device   = fs.String("device", "360x640", "Size of the device to lay out the screens.")
+		lang     = fs.String("lang", "base", "Language of the strings to be rendered.")
+		baseline = fs.String("baseline", "", "Directory of PNG images to compare with.")
+	)
+	fs.Parse(args[1:])
+
+	width, height, err := export.ParseDevice(*device)
+	if err != nil {
+		fmt.Println("Invalid option:", err)
+		return ExitCodeError
+	}
+	mock := parseConfigs(&gen.Options{InDir: *inDir})
+	if printErrors("Invalid Mockerfile:", append(gen.Validate(&mock), gen.ValidateAssets(&mock, *inDir)...)) {
+		return ExitCodeError
+	}
+	opt := export.Options{
+		OutDir: *outDir,
+		Width:  width,
+		Height: height,
+		Lang:   *lang,
+	}
+	e := export.NewExporter(&opt, &mock, formatId)
+	if e == nil {
+		fmt.Printf("Invalid format ID: %s\n", formatId)
+		printUsage()
+		return ExitCodeError
+	}
+	if printErrors("Cannot export:", export.Validate(&mock, &opt)) {
+		return ExitCodeError
+	}
+	e.Export()
+
+	// Rendered images are compared to find the changes of the layouts
+	if *baseline != "" && formatId == "png" {
+		if diffs := export.ComparePng(*outDir, *baseline, &mock); 0 < len(diffs) {
+			for _, diff := range diffs {
+				fmt.Println("Changed:", diff)
+			}
+			return ExitCodeError
+		}
+	}
+	return ExitCodeSuccess
+}
+
+func runGraph(args []string) int {
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		inDir   = fs.String("in", ".", "Input directory which has Mockerfile.")
+		format  = fs.String("format", "dot", "Format of the graph: dot, mermaid or svg.")
+		outFile = fs.String("out", "", "Output file, or standard output if empty.")
+	)
+	fs.Parse(args)
+
+	// Only the screens and the behaviors are used, so the asset files are not checked
+	mock := parseConfigs(&gen.Options{InDir: *inDir})
+	if printErrors("Invalid Mockerfile:", gen.Validate(&mock)) {
+		return ExitCodeError
+	}
+	g := graph.New(&mock)
+	var write func(io.Writer)
+	switch *format {
+	case "dot":
+		write = g.Dot
+	case "mermaid":
+		write = g.Mermaid
+	case "svg":
+		write = g.Svg
+	default:
+		fmt.Printf("Invalid format: %s\n", *format)
+		printUsage()
+		return ExitCodeError
+	}
+
+	w := os.Stdout
+	if *outFile != "" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			fmt.Println("Error creating file", err)
+			return ExitCodeError
+		}
+		defer f.Close()
+		w = f
+	}
+	write(w)
+	return ExitCodeSuccess
+}
+
+func runAnalyze(args []string) int {
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		inDir    = fs.String("in", ".", "Input directory which has Mockerfile.")
+		jsonMode = fs.Bool("json", false, "Print problems in JSON.")
+	)
+	fs.Parse(args)
+
+	// Only the screens and the behaviors are used, so the asset files are not checked
+	mock := parseConfigs(&gen.Options{InDir: *inDir})
+	if printErrors("Invalid Mockerfile:", gen.Validate(&mock)) {
+		return ExitCodeError
+	}
+	findings := analyze.Analyze(&mock)
+	if *jsonMode {
+		if findings == nil {
+			findings = []analyze.Finding{}
+		}
+		b, _ := json.MarshalIndent(findings, "", "  ")
+		fmt.Println(string(b))
+	} else {
+		for _, f := range findings {
+			fmt.Printf("%s: %s: %s\n", f.Severity, f.Type, f.Message)
+		}
+		if !analyze.HasProblems(findings) {
+			fmt.Println("No problems found.")
+		}
+	}
+	if analyze.HasProblems(findings) {
+		return ExitCodeError
+	}
+	return ExitCodeSuccess
+}
+
 func printVersion() {
 	fmt.Println("mocker version \"" + Version + "\"")
 }
 
+// Prints the errors with the prefix and reports whether there are any.
+func printErrors(prefix string, errs []error) bool {
+	for _, err := range errs {
+		fmt.Println(prefix, err)
+	}
+	return 0 < len(errs)
+}
+
 func parseConfigs(opt *gen.Options) (mock gen.Mock) {
 	filename := filepath.Join(opt.InDir, "Mockerfile")
 	xmlFile, err := os.Open(filename)
@@ -106,6 +330,9 @@ func parseConfigs(opt *gen.Options) (mock gen.Mock) {
 		fmt.Println("Error unmarshaling Mockerfile", err)
 		return
 	}
+	if printErrors("Error loading fixtures", gen.LoadFixtures(&mock, opt.InDir)) {
+		os.Exit(ExitCodeError)
+	}
 
 	return
 }
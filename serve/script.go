@@ -0,0 +1,66 @@
+package serve
+
+// Script injected into the pages to reload them,
+// to show the errors and to switch the device frames.
+const serveScript = `(function () {
+    var devices = [
+        {name: "Phone (360x640)", width: 360, height: 640},
+        {name: "Phone (414x896)", width: 414, height: 896},
+        {name: "Tablet (768x1024)", width: 768, height: 1024},
+        {name: "Tablet (1024x768)", width: 1024, height: 768}
+    ];
+
+    function showErrors(errs) {
+        var overlay = document.getElementById("mocker-overlay");
+        if (!overlay) {
+            overlay = document.createElement("div");
+            overlay.id = "mocker-overlay";
+            overlay.style.cssText = "position: fixed; top: 0; right: 0; bottom: 0; left: 0; z-index: 10000;" +
+                " overflow: auto; padding: 16px; background: rgba(0, 0, 0, 0.85); color: #ff6666;" +
+                " font: 14px monospace; white-space: pre-wrap;";
+            document.body.appendChild(overlay);
+        }
+        overlay.textContent = errs.join("\n");
+    }
+
+    function resize(frame, index) {
+        var device = devices[index] || devices[0];
+        frame.style.width = device.width + "px";
+        frame.style.height = device.height + "px";
+    }
+
+    function addDeviceSelector() {
+        var toolbar = document.querySelector(".mocker-toolbar");
+        var frame = document.querySelector(".mocker-device");
+        if (!toolbar || !frame) {
+            return;
+        }
+        var select = document.createElement("select");
+        select.id = "mocker-device";
+        devices.forEach(function (device, i) {
+            var option = document.createElement("option");
+            option.value = i;
+            option.textContent = device.name;
+            select.appendChild(option);
+        });
+        select.value = localStorage.getItem("mocker-device") || 0;
+        select.addEventListener("change", function () {
+            localStorage.setItem("mocker-device", select.value);
+            resize(frame, select.value);
+        });
+        toolbar.appendChild(select);
+        resize(frame, select.value);
+    }
+
+    document.addEventListener("DOMContentLoaded", function () {
+        addDeviceSelector();
+        var source = new EventSource("` + eventsPath + `");
+        source.addEventListener("reload", function () {
+            location.reload();
+        });
+        source.addEventListener("errors", function (e) {
+            showErrors(JSON.parse(e.data));
+        });
+    });
+})();
+`
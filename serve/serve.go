@@ -0,0 +1,256 @@
+// Package serve previews the web prototype of the Mockerfile
+// and reloads the browsers when the Mockerfile is changed.
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ksoichiro/mocker/encoding/mockerfile"
+	"github.com/ksoichiro/mocker/gen"
+)
+
+const (
+	eventsPath = "/_mocker/events"
+	scriptPath = "/_mocker/serve.js"
+)
+
+type Server struct {
+	InDir string
+
+	mu      sync.Mutex
+	files   map[string]string
+	screens map[string]bool
+	launch  string
+	errs    []string
+	clients map[chan event]bool
+}
+
+type event struct {
+	name string
+	data string
+}
+
+// New creates the server and loads the Mockerfile in the directory.
+func New(inDir string) *Server {
+	s := &Server{InDir: inDir, clients: map[chan event]bool{}}
+	s.load()
+	return s
+}
+
+// ListenAndServe serves the prototype and watches the Mockerfile.
+func (s *Server) ListenAndServe(addr string) error {
+	go s.watch(500 * time.Millisecond)
+	return http.ListenAndServe(addr, s)
+}
+
+func (s *Server) filename() string {
+	return filepath.Join(s.InDir, "Mockerfile")
+}
+
+// Renders the prototype again and notifies the browsers.
+// Files rendered last time are kept while the Mockerfile has errors.
+func (s *Server) load() {
+	mock, errs := loadMock(s.filename())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs = errs
+	if 0 < len(errs) {
+		s.broadcast(errorEvent(errs))
+		return
+	}
+	s.files = gen.WebFiles(&mock, s.InDir)
+	s.launch = mock.Launch.Screen
+	s.screens = map[string]bool{}
+	for _, screen := range mock.Screens {
+		s.screens[screen.Id] = true
+	}
+	s.broadcast(event{name: "reload", data: "{}"})
+}
+
+func loadMock(filename string) (mock gen.Mock, errs []string) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return mock, []string{fmt.Sprint("Error opening file ", err)}
+	}
+	if err := mockerfile.Unmarshal(b, &mock); err != nil {
+		return mock, []string{fmt.Sprint("Error unmarshaling Mockerfile ", err)}
+	}
+	for _, err := range gen.LoadFixtures(&mock, filepath.Dir(filename)) {
+		errs = append(errs, fmt.Sprint("Error loading fixtures ", err))
+	}
+	for _, err := range append(gen.Validate(&mock), gen.ValidateAssets(&mock, filepath.Dir(filename))...) {
+		errs = append(errs, fmt.Sprint("Invalid Mockerfile: ", err))
+	}
+	return
+}
+
+// Polls the modification time of the Mockerfile
+// to work without the file system notification.
+func (s *Server) watch(interval time.Duration) {
+	last := modTime(s.filename())
+	for range time.Tick(interval) {
+		if t := modTime(s.filename()); !t.Equal(last) {
+			last = t
+			s.load()
+		}
+	}
+}
+
+func modTime(filename string) (t time.Time) {
+	if fi, err := os.Stat(filename); err == nil {
+		t = fi.ModTime()
+	}
+	return
+}
+
+// Sends the event to the browsers. s.mu must be locked.
+// Slow clients miss the event instead of blocking the server.
+func (s *Server) broadcast(e event) {
+	for ch := range s.clients {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func errorEvent(errs []string) event {
+	b, _ := json.Marshal(errs)
+	return event{name: "errors", data: string(b)}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case eventsPath:
+		s.serveEvents(w, r)
+	case scriptPath:
+		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+		fmt.Fprint(w, serveScript)
+	case "/", "/index.html":
+		s.serveIndex(w, r)
+	default:
+		s.serveFile(w, r)
+	}
+}
+
+// Redirects to the page of the screen in the "screen" parameter,
+// or the launch screen. Other parameters such as "lang" are kept.
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	files, screens, launch := s.files, s.screens, s.launch
+	s.mu.Unlock()
+	if files == nil {
+		s.serveErrorPage(w)
+		return
+	}
+
+	query := r.URL.Query()
+	id := query.Get("screen")
+	if id == "" {
+		id = launch
+	}
+	if !screens[id] {
+		http.Error(w, fmt.Sprintf("Screen not found: %s", id), http.StatusNotFound)
+		return
+	}
+	query.Del("screen")
+	u := url.URL{Path: "/" + gen.WebPageName(id), RawQuery: query.Encode()}
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	files := s.files
+	s.mu.Unlock()
+
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	content, ok := files[name]
+	if !ok {
+		if files == nil && path.Ext(name) == ".html" {
+			s.serveErrorPage(w)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+	// Pages of the web views are served as they are
+	if path.Ext(name) == ".html" && !strings.HasPrefix(name, gen.AssetsDir+"/") {
+		content = injectScript(content)
+	}
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, content)
+}
+
+// Shown when the Mockerfile has errors since the server started.
+// The errors are shown by the overlay and the page is reloaded when fixed.
+func (s *Server) serveErrorPage(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, injectScript(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>mocker</title>
+</head>
+<body>
+</body>
+</html>
+`))
+}
+
+func injectScript(page string) string {
+	return strings.Replace(page, "</head>", fmt.Sprintf("<script src=\"%s\"></script>\n</head>", scriptPath), 1)
+}
+
+// Streams the events with Server-Sent Events.
+// The current errors are sent first so that new pages show the overlay.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	ch := make(chan event, 1)
+	s.mu.Lock()
+	s.clients[ch] = true
+	errs := s.errs
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	if 0 < len(errs) {
+		writeEvent(w, errorEvent(errs))
+	}
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-ch:
+			writeEvent(w, e)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e event) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.name, e.data)
+}
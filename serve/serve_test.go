@@ -0,0 +1,136 @@
+package serve
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testMockerfile = `{
+    "screens": [
+        {"id": "top", "name": "Top", "layout": [{"type": "linear"}]},
+        {"id": "second", "name": "Second", "layout": [{"type": "linear"}]}
+    ],
+    "launch": {"screen": "top"}
+}`
+
+func newTestServer(t *testing.T, mockerfile string) (*Server, string) {
+	dir, err := ioutil.TempDir("", "mocker-serve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, "Mockerfile"), []byte(mockerfile), 0666)
+	return New(dir), dir
+}
+
+func get(s *Server, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+	return w
+}
+
+func TestServeIndex(t *testing.T) {
+	s, dir := newTestServer(t, testMockerfile)
+	defer os.RemoveAll(dir)
+
+	var testcases = []struct {
+		target   string
+		code     int
+		location string
+	}{
+		{"/", http.StatusFound, "/top.html"},
+		{"/?screen=second", http.StatusFound, "/second.html"},
+		{"/?screen=second&lang=ja", http.StatusFound, "/second.html?lang=ja"},
+		{"/?screen=unknown", http.StatusNotFound, ""},
+	}
+	for _, tc := range testcases {
+		w := get(s, tc.target)
+		if w.Code != tc.code {
+			t.Errorf("%s: expected %d but %d", tc.target, tc.code, w.Code)
+		}
+		if location := w.Header().Get("Location"); location != tc.location {
+			t.Errorf("%s: expected %q but %q", tc.target, tc.location, location)
+		}
+	}
+}
+
+func TestServeFile(t *testing.T) {
+	s, dir := newTestServer(t, testMockerfile)
+	defer os.RemoveAll(dir)
+
+	w := get(s, "/second.html")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected %d but %d", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), scriptPath) {
+		t.Errorf("Expected the script to be injected")
+	}
+	if w := get(s, "/mocker.css"); w.Code != http.StatusOK || strings.Contains(w.Body.String(), scriptPath) {
+		t.Errorf("Expected the style to be served as is")
+	}
+	if w := get(s, "/third.html"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected %d but %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestServeWebViewAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mocker-serve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "assets", "help"), 0777)
+	ioutil.WriteFile(filepath.Join(dir, "assets", "help", "index.html"), []byte("<p>Help</p>"), 0666)
+	ioutil.WriteFile(filepath.Join(dir, "Mockerfile"), []byte(`{
+    "screens": [
+        {"id": "top", "name": "Top", "layout": [{"id": "help", "type": "web", "html": "help/index.html"}]}
+    ],
+    "launch": {"screen": "top"}
+}`), 0666)
+	s := New(dir)
+
+	w := get(s, "/assets/help/index.html")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected %d but %d", http.StatusOK, w.Code)
+	}
+	if w.Body.String() != "<p>Help</p>" {
+		t.Errorf("Expected the page of the web view to be served as is: %q", w.Body.String())
+	}
+}
+
+func TestLoadKeepsFilesOnError(t *testing.T) {
+	s, dir := newTestServer(t, testMockerfile)
+	defer os.RemoveAll(dir)
+
+	ch := make(chan event, 1)
+	s.clients[ch] = true
+	ioutil.WriteFile(filepath.Join(dir, "Mockerfile"), []byte(`{"meta": {"ios": {"language": "java"}}}`), 0666)
+	s.load()
+
+	if e := <-ch; e.name != "errors" || !strings.Contains(e.data, "unsupported ios language") {
+		t.Errorf("Expected errors event but %v", e)
+	}
+	if w := get(s, "/top.html"); w.Code != http.StatusOK {
+		t.Errorf("Expected the last pages to be served but %d", w.Code)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Mockerfile"), []byte(testMockerfile), 0666)
+	s.load()
+	if e := <-ch; e.name != "reload" {
+		t.Errorf("Expected reload event but %v", e)
+	}
+}
+
+func TestServeErrorPage(t *testing.T) {
+	s, dir := newTestServer(t, `{`)
+	defer os.RemoveAll(dir)
+
+	w := get(s, "/")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), scriptPath) {
+		t.Errorf("Expected the page to show the errors")
+	}
+}